@@ -0,0 +1,126 @@
+package artifactory
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The maximum number of items fetched by a single AQL query by their paths.
+const aqlItemsBatchSize = 500
+
+// The fields of an item which are not returned by the search, and are fetched by an AQL query.
+type aqlItem struct {
+	Repo   string        `json:"repo"`
+	Path   string        `json:"path"`
+	Name   string        `json:"name"`
+	Sha256 string        `json:"sha256,omitempty"`
+	Stats  []aqlItemStat `json:"stats,omitempty"`
+}
+
+type aqlItemStat struct {
+	Downloaded string `json:"downloaded,omitempty"`
+}
+
+// Returns the path of the item, in the '<repo>/<path>/<name>' format of the search results.
+func (item *aqlItem) getFullPath() string {
+	if item.Path == "." {
+		return item.Repo + "/" + item.Name
+	}
+	return item.Repo + "/" + item.Path + "/" + item.Name
+}
+
+// Fetches the included fields of the items, by their '<repo>/<path>/<name>' paths.
+// Returns the items by their paths. Items which don't exist are not returned.
+func getAqlItemsByPaths(serviceManager artifactory.ArtifactoryServicesManager, paths []string, include ...string) (map[string]*aqlItem, error) {
+	items := map[string]*aqlItem{}
+	for start := 0; start < len(paths); start += aqlItemsBatchSize {
+		end := start + aqlItemsBatchSize
+		if end > len(paths) {
+			end = len(paths)
+		}
+		query, err := createAqlItemsQuery(paths[start:end], include)
+		if err != nil {
+			return nil, err
+		}
+		results, err := runAqlItemsQuery(serviceManager, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range results {
+			items[item.getFullPath()] = item
+		}
+	}
+	return items, nil
+}
+
+// Fetches the included fields of the artifacts of a published build.
+func getAqlBuildItems(serviceManager artifactory.ArtifactoryServicesManager, buildName, buildNumber string, include ...string) ([]*aqlItem, error) {
+	condition, err := json.Marshal(map[string]string{"artifact.module.build.name": buildName, "artifact.module.build.number": buildNumber})
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	return runAqlItemsQuery(serviceManager, "items.find("+string(condition)+").include("+getAqlIncludedFields(include)+")")
+}
+
+func runAqlItemsQuery(serviceManager artifactory.ArtifactoryServicesManager, query string) ([]*aqlItem, error) {
+	stream, err := serviceManager.Aql(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+	body, err := ioutil.ReadAll(stream)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	response := &struct {
+		Results []*aqlItem `json:"results"`
+	}{}
+	err = json.Unmarshal(body, response)
+	return response.Results, errorutils.CheckError(err)
+}
+
+func createAqlItemsQuery(paths, include []string) (string, error) {
+	condition, err := createAqlItemsCondition(paths)
+	if err != nil {
+		return "", err
+	}
+	return `items.find(` + condition + `).include(` + getAqlIncludedFields(include) + `)`, nil
+}
+
+// Returns the AQL condition which matches the items by their exact '<repo>/<path>/<name>' paths.
+func createAqlItemsCondition(paths []string) (string, error) {
+	var conditions []string
+	for _, itemPath := range paths {
+		repo, dir, name := splitSearchResultPath(itemPath)
+		condition, err := json.Marshal(map[string]string{"repo": repo, "path": dir, "name": name})
+		if errorutils.CheckError(err) != nil {
+			return "", err
+		}
+		conditions = append(conditions, string(condition))
+	}
+	return `{"$or":[` + strings.Join(conditions, ",") + `]}`, nil
+}
+
+// The repo, path and name of the items are always included.
+func getAqlIncludedFields(include []string) string {
+	includedFields := []string{`"repo"`, `"path"`, `"name"`}
+	for _, field := range include {
+		includedFields = append(includedFields, `"`+field+`"`)
+	}
+	return strings.Join(includedFields, ",")
+}
+
+// Splits the path of a search result to its repository, path and name, as they are represented in AQL.
+func splitSearchResultPath(resultPath string) (repo, itemPath, name string) {
+	parts := strings.Split(resultPath, "/")
+	repo, name = parts[0], parts[len(parts)-1]
+	itemPath = "."
+	if len(parts) > 2 {
+		itemPath = strings.Join(parts[1:len(parts)-1], "/")
+	}
+	return
+}
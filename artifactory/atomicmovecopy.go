@@ -0,0 +1,292 @@
+package artifactory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/journal"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// A move or copy of a single file.
+type atomicOperation struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+type atomicMoveCopyReport struct {
+	summary.Summary
+	Error string `json:"error,omitempty"`
+	// The completed operations which were reversed after the failure.
+	RolledBack []*atomicOperation `json:"rolledBack,omitempty"`
+	// The completed operations which couldn't be reversed. They are reversed by the next run of the same command.
+	RollbackFailures []*atomicOperation `json:"rollbackFailures,omitempty"`
+	// The journal of the operations which couldn't be reversed.
+	Journal string `json:"journal,omitempty"`
+}
+
+// Moves or copies the files of the spec one by one, recording each completed operation in a journal.
+// If an operation fails, the completed operations are reversed: moved files are moved back and copies are deleted.
+// Returns the number of files which were moved or copied, and the number of files which were not.
+func runAtomicMoveCopy(moveType services.MoveType, moveSpec *spec.SpecFiles, serverDetails *config.ServerDetails, retries int) (int, int, error) {
+	serviceManager, err := utils.CreateServiceManager(serverDetails, retries, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	operationsJournal, err := journal.Open(string(moveType)+"-atomic", serverDetails.ArtifactoryUrl, moveSpec)
+	if err != nil {
+		return 0, 0, err
+	}
+	transaction := &moveCopyTransaction{moveType: moveType, client: &artifactoryMoveCopyClient{serviceManager: serviceManager}, journal: operationsJournal}
+	if len(operationsJournal.Entries()) > 0 {
+		log.Warn(fmt.Sprintf("A previous atomic %s with the same details did not complete. Rolling back its %d completed operations...", moveType, len(operationsJournal.Entries())))
+		report := &atomicMoveCopyReport{Summary: *summary.GetSummaryReport(0, 0, nil)}
+		if err = transaction.rollback(operationsJournal.Entries(), report); err != nil {
+			return 0, 0, printAtomicMoveCopyReport(report, err)
+		}
+	}
+	operations, err := planAtomicMoveCopy(serviceManager, moveSpec)
+	if err != nil {
+		return 0, 0, err
+	}
+	report, err := transaction.run(operations)
+	return report.Totals.Success, report.Totals.Failure, printAtomicMoveCopyReport(report, err)
+}
+
+func printAtomicMoveCopyReport(report *atomicMoveCopyReport, err error) error {
+	if err != nil {
+		report.Status = summary.Failure
+		report.Error = err.Error()
+	}
+	content, mErr := json.Marshal(report)
+	if errorutils.CheckError(mErr) != nil {
+		log.Error(mErr)
+	} else {
+		log.Output(clientutils.IndentJson(content))
+	}
+	return err
+}
+
+// Lists the files of the spec and the paths they are moved or copied to.
+func planAtomicMoveCopy(serviceManager artifactory.ArtifactoryServicesManager, moveSpec *spec.SpecFiles) ([]*journal.Entry, error) {
+	var operations []*journal.Entry
+	for i := range moveSpec.Files {
+		file := moveSpec.Get(i)
+		params, err := file.ToArtifactoryCommonParams()
+		if err != nil {
+			return nil, err
+		}
+		if params.Recursive, err = file.IsRecursive(true); err != nil {
+			return nil, err
+		}
+		flat, err := file.IsFlat(false)
+		if err != nil {
+			return nil, err
+		}
+		reader, err := serviceManager.SearchFiles(services.SearchParams{ArtifactoryCommonParams: params})
+		if err != nil {
+			return nil, err
+		}
+		for item := new(serviceutils.ResultItem); reader.NextRecord(item) == nil; item = new(serviceutils.ResultItem) {
+			if item.Type == "folder" {
+				continue
+			}
+			target, err := getMoveCopyTargetPath(params.Target, params.Pattern, item.Path, item.GetItemRelativePath(), flat)
+			if err != nil {
+				reader.Close()
+				return nil, err
+			}
+			if strings.HasSuffix(target, "/") {
+				target += item.Name
+			}
+			operations = append(operations, &journal.Entry{SourcePath: item.GetItemRelativePath(), TargetPath: target})
+		}
+		err = reader.GetError()
+		reader.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return operations, nil
+}
+
+// Returns the path a file is moved or copied to, the same way the move and copy commands do.
+func getMoveCopyTargetPath(specTarget, specPattern, sourceItemPath, sourceItemRelativePath string, flat bool) (string, error) {
+	target := specTarget
+	if !flat {
+		if strings.Contains(target, "/") {
+			file, dir := fileutils.GetFileAndDirFromPath(target)
+			target = clientutils.TrimPath(dir + "/" + sourceItemPath + "/" + file)
+		} else {
+			target = clientutils.TrimPath(target + "/" + sourceItemPath + "/")
+		}
+	}
+	return clientutils.BuildTargetPath(specPattern, sourceItemRelativePath, target, true)
+}
+
+// The Artifactory operations of an atomic move or copy.
+type moveCopyClient interface {
+	moveOrCopy(moveType services.MoveType, sourcePath, targetPath string) error
+	exists(path string) (bool, error)
+	delete(path string) error
+}
+
+type moveCopyTransaction struct {
+	moveType services.MoveType
+	client   moveCopyClient
+	journal  *journal.Journal
+}
+
+func (mct *moveCopyTransaction) run(operations []*journal.Entry) (*atomicMoveCopyReport, error) {
+	report := &atomicMoveCopyReport{Summary: *summary.GetSummaryReport(0, len(operations), nil)}
+	// Overwritten files cannot be restored, so the operations start only if none of the target files exist.
+	var existingTargets []string
+	for _, operation := range operations {
+		exists, err := mct.client.exists(operation.TargetPath)
+		if err != nil {
+			return report, err
+		}
+		if exists {
+			existingTargets = append(existingTargets, operation.TargetPath)
+		}
+	}
+	if len(existingTargets) > 0 {
+		return report, errorutils.CheckError(errors.New("the following target files already exist, and cannot be overwritten by an atomic " + string(mct.moveType) + ":\n" + strings.Join(existingTargets, "\n")))
+	}
+
+	var completed []*journal.Entry
+	for _, operation := range operations {
+		err := mct.client.moveOrCopy(mct.moveType, operation.SourcePath, operation.TargetPath)
+		if err == nil {
+			completed = append(completed, operation)
+			err = mct.journal.Add(operation)
+		}
+		if err != nil {
+			log.Error(err)
+			log.Info(fmt.Sprintf("Rolling back %d completed operations...", len(completed)))
+			if rollbackErr := mct.rollback(completed, report); rollbackErr != nil {
+				err = errors.New(err.Error() + "\n" + rollbackErr.Error())
+			}
+			report.Totals.Success, report.Totals.Failure = len(report.RollbackFailures), len(operations)-len(report.RollbackFailures)
+			return report, err
+		}
+	}
+	report.Totals.Success, report.Totals.Failure = len(operations), 0
+	return report, mct.journal.Remove()
+}
+
+// Reverses the completed operations, from the last to the first.
+// The journal is left with the operations which couldn't be reversed.
+func (mct *moveCopyTransaction) rollback(completed []*journal.Entry, report *atomicMoveCopyReport) error {
+	var failed []*journal.Entry
+	for i := len(completed) - 1; i >= 0; i-- {
+		operation := completed[i]
+		var err error
+		if mct.moveType == services.MOVE {
+			log.Info("Rolling back: moving " + operation.TargetPath + " back to " + operation.SourcePath)
+			err = mct.client.moveOrCopy(services.MOVE, operation.TargetPath, operation.SourcePath)
+		} else {
+			log.Info("Rolling back: deleting " + operation.TargetPath)
+			err = mct.client.delete(operation.TargetPath)
+		}
+		reportedOperation := &atomicOperation{Source: operation.SourcePath, Target: operation.TargetPath}
+		if err != nil {
+			log.Error(err)
+			failed = append(failed, operation)
+			report.RollbackFailures = append(report.RollbackFailures, reportedOperation)
+			continue
+		}
+		report.RolledBack = append(report.RolledBack, reportedOperation)
+	}
+	err := mct.journal.Remove()
+	if err == nil {
+		err = mct.journal.Add(failed...)
+	}
+	if err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		report.Journal = mct.journal.GetPath()
+		return errorutils.CheckError(errors.New(fmt.Sprintf("failed rolling back %d operations. Run the command again to retry rolling them back", len(failed))))
+	}
+	return nil
+}
+
+type artifactoryMoveCopyClient struct {
+	serviceManager artifactory.ArtifactoryServicesManager
+}
+
+func (amc *artifactoryMoveCopyClient) moveOrCopy(moveType services.MoveType, sourcePath, targetPath string) error {
+	if moveType == services.MOVE {
+		log.Info("Moving artifact: " + sourcePath + " to: " + targetPath)
+	} else {
+		log.Info("Copying artifact: " + sourcePath + " to: " + targetPath)
+	}
+	requestUrl, err := serviceutils.BuildArtifactoryUrl(amc.getUrl(), path.Join("api", string(moveType), sourcePath), map[string]string{"to": targetPath})
+	if err != nil {
+		return err
+	}
+	httpClientDetails := amc.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := amc.serviceManager.Client().SendPost(requestUrl, nil, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errorutils.CheckError(errors.New("failed to " + string(moveType) + " " + sourcePath + " to " + targetPath + ". Artifactory response: " + resp.Status + "\n" + clientutils.IndentJson(body)))
+	}
+	return nil
+}
+
+func (amc *artifactoryMoveCopyClient) exists(itemPath string) (bool, error) {
+	requestUrl, err := serviceutils.BuildArtifactoryUrl(amc.getUrl(), path.Join("api", "storage", itemPath), map[string]string{})
+	if err != nil {
+		return false, err
+	}
+	httpClientDetails := amc.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, _, err := amc.serviceManager.Client().SendGet(requestUrl, true, &httpClientDetails)
+	if err != nil {
+		return false, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+	return false, errorutils.CheckError(errors.New("failed checking whether " + itemPath + " exists. Artifactory response: " + resp.Status + "\n" + clientutils.IndentJson(body)))
+}
+
+func (amc *artifactoryMoveCopyClient) delete(itemPath string) error {
+	requestUrl, err := serviceutils.BuildArtifactoryUrl(amc.getUrl(), itemPath, map[string]string{})
+	if err != nil {
+		return err
+	}
+	httpClientDetails := amc.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := amc.serviceManager.Client().SendDelete(requestUrl, nil, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	// A copy which was already deleted is rolled back.
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return errorutils.CheckError(errors.New("failed deleting " + itemPath + ". Artifactory response: " + resp.Status + "\n" + clientutils.IndentJson(body)))
+	}
+	return nil
+}
+
+func (amc *artifactoryMoveCopyClient) getUrl() string {
+	return amc.serviceManager.GetConfig().GetServiceDetails().GetUrl()
+}
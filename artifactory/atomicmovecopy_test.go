@@ -0,0 +1,125 @@
+package artifactory
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/journal"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMoveCopyTargetPath(t *testing.T) {
+	tests := []struct {
+		target   string
+		pattern  string
+		path     string
+		flat     bool
+		expected string
+	}{
+		{"target/", "source/a/*", "a/b", false, "target/a/b/"},
+		{"target/", "source/a/*", "a/b", true, "target/"},
+		{"target", "source/a/*", "a/b", false, "target/a/b/"},
+		{"target/{1}/", "source/(*)/file.jar", "a", true, "target/a/"},
+		{"target/renamed.jar", "source/file.jar", ".", true, "target/renamed.jar"},
+	}
+	for _, test := range tests {
+		target, err := getMoveCopyTargetPath(test.target, test.pattern, test.path, "source/"+test.path+"/file.jar", test.flat)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, target, test)
+	}
+}
+
+// Records the operations and fails the operations of the given source paths.
+type fakeMoveCopyClient struct {
+	calls         []string
+	existing      map[string]bool
+	failedSources map[string]bool
+}
+
+func (fmc *fakeMoveCopyClient) moveOrCopy(moveType services.MoveType, sourcePath, targetPath string) error {
+	fmc.calls = append(fmc.calls, string(moveType)+" "+sourcePath+" "+targetPath)
+	if fmc.failedSources[sourcePath] {
+		return errors.New("failed")
+	}
+	return nil
+}
+
+func (fmc *fakeMoveCopyClient) exists(path string) (bool, error) {
+	return fmc.existing[path], nil
+}
+
+func (fmc *fakeMoveCopyClient) delete(path string) error {
+	fmc.calls = append(fmc.calls, "delete "+path)
+	if fmc.failedSources[path] {
+		return errors.New("failed")
+	}
+	return nil
+}
+
+func TestAtomicMoveCopy(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	oldHome := os.Getenv(coreutils.HomeDir)
+	assert.NoError(t, os.Setenv(coreutils.HomeDir, tmpDir))
+	defer os.Setenv(coreutils.HomeDir, oldHome)
+	operations := []*journal.Entry{{SourcePath: "a/1", TargetPath: "b/1"}, {SourcePath: "a/2", TargetPath: "b/2"}, {SourcePath: "a/3", TargetPath: "b/3"}}
+
+	t.Run("success", func(t *testing.T) {
+		transaction, client := newFakeTransaction(t, services.MOVE, &fakeMoveCopyClient{})
+		report, err := transaction.run(operations)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"move a/1 b/1", "move a/2 b/2", "move a/3 b/3"}, client.calls)
+		assert.Equal(t, summary.Totals{Success: 3}, *report.Totals)
+		assert.Empty(t, transaction.journal.Entries())
+	})
+
+	t.Run("existingTarget", func(t *testing.T) {
+		transaction, client := newFakeTransaction(t, services.COPY, &fakeMoveCopyClient{existing: map[string]bool{"b/2": true}})
+		_, err := transaction.run(operations)
+		assert.EqualError(t, err, "the following target files already exist, and cannot be overwritten by an atomic copy:\nb/2")
+		assert.Empty(t, client.calls)
+	})
+
+	t.Run("moveRollback", func(t *testing.T) {
+		transaction, client := newFakeTransaction(t, services.MOVE, &fakeMoveCopyClient{failedSources: map[string]bool{"a/3": true}})
+		report, err := transaction.run(operations)
+		assert.EqualError(t, err, "failed")
+		assert.Equal(t, []string{"move a/1 b/1", "move a/2 b/2", "move a/3 b/3", "move b/2 a/2", "move b/1 a/1"}, client.calls)
+		assert.Equal(t, []*atomicOperation{{Source: "a/2", Target: "b/2"}, {Source: "a/1", Target: "b/1"}}, report.RolledBack)
+		assert.Equal(t, summary.Totals{Failure: 3}, *report.Totals)
+		assert.Empty(t, transaction.journal.Entries())
+	})
+
+	t.Run("copyRollbackFailure", func(t *testing.T) {
+		transaction, client := newFakeTransaction(t, services.COPY, &fakeMoveCopyClient{failedSources: map[string]bool{"a/3": true, "b/1": true}})
+		report, err := transaction.run(operations)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"copy a/1 b/1", "copy a/2 b/2", "copy a/3 b/3", "delete b/2", "delete b/1"}, client.calls)
+		assert.Equal(t, []*atomicOperation{{Source: "a/2", Target: "b/2"}}, report.RolledBack)
+		assert.Equal(t, []*atomicOperation{{Source: "a/1", Target: "b/1"}}, report.RollbackFailures)
+		assert.Equal(t, summary.Totals{Success: 1, Failure: 2}, *report.Totals)
+		assert.Equal(t, transaction.journal.GetPath(), report.Journal)
+
+		// The operations which weren't rolled back are kept in the journal.
+		reopened, err := journal.Open("copy-atomic", t.Name())
+		assert.NoError(t, err)
+		if assert.Len(t, reopened.Entries(), 1) {
+			assert.Equal(t, "b/1", reopened.Entries()[0].TargetPath)
+		}
+	})
+}
+
+func newFakeTransaction(t *testing.T, moveType services.MoveType, client *fakeMoveCopyClient) (*moveCopyTransaction, *fakeMoveCopyClient) {
+	operationsJournal, err := journal.Open(string(moveType)+"-atomic", t.Name())
+	assert.NoError(t, err)
+	return &moveCopyTransaction{moveType: moveType, client: client, journal: operationsJournal}, client
+}
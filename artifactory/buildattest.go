@@ -0,0 +1,91 @@
+package artifactory
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/attestation"
+	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The name of the provenance file, uploaded under '<repo>/<build name>/<build number>/'.
+const provenanceFileName = "provenance" + attestation.FileExtension
+
+// Creates the signed provenance of a published build, and uploads it to the repository.
+// The subjects of the provenance are the artifacts of the build, with their sha256 checksums in Artifactory.
+// The provenance file is linked to the build by the build.name, build.number and build.timestamp properties.
+func uploadBuildProvenance(rtDetails *config.ServerDetails, buildConfiguration *utils.BuildConfiguration, builderId string, signer *attestation.Signer, repo string) error {
+	buildName, buildNumber := buildConfiguration.BuildName, buildConfiguration.BuildNumber
+	serviceManager, err := utils.CreateServiceManager(rtDetails, -1, false)
+	if err != nil {
+		return err
+	}
+	buildInfo, err := getPublishedBuildInfo(serviceManager, buildName, buildNumber, buildConfiguration.Project)
+	if err != nil {
+		return err
+	}
+	items, err := getAqlBuildItems(serviceManager, buildName, buildNumber, "sha256")
+	if err != nil {
+		return err
+	}
+	artifacts := map[string]string{}
+	for _, item := range items {
+		if item.Sha256 != "" {
+			artifacts[item.getFullPath()] = item.Sha256
+		}
+	}
+	statement, err := attestation.CreateStatement(buildInfo, artifacts, builderId)
+	if err != nil {
+		return err
+	}
+	envelope, err := signer.Sign(statement)
+	if err != nil {
+		return err
+	}
+
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer fileutils.RemoveTempDir(tempDir)
+	provenancePath := filepath.Join(tempDir, provenanceFileName)
+	if err = ioutil.WriteFile(provenancePath, append(envelope, '\n'), 0644); errorutils.CheckError(err) != nil {
+		return err
+	}
+	params := services.NewUploadParams()
+	params.Pattern = provenancePath
+	params.Target = strings.TrimSuffix(repo, "/") + "/" + buildName + "/" + buildNumber + "/"
+	params.Flat = true
+	params.TargetProps = getProvenanceProps(buildInfo)
+	uploaded, failed, err := serviceManager.UploadFiles(params)
+	if err != nil {
+		return err
+	}
+	if failed > 0 || uploaded == 0 {
+		return errorutils.CheckError(errors.New("failed to upload the provenance of build " + buildName + "/" + buildNumber + " to " + params.Target))
+	}
+	log.Info("The provenance of build " + buildName + "/" + buildNumber + " was uploaded to " + params.Target + provenanceFileName)
+	return nil
+}
+
+func getProvenanceProps(buildInfo *buildinfocmd.BuildInfo) *serviceutils.Properties {
+	props := serviceutils.NewProperties()
+	props.AddProperty("build.name", buildInfo.Name)
+	props.AddProperty("build.number", buildInfo.Number)
+	if started, err := time.Parse(buildinfocmd.TimeFormat, buildInfo.Started); err == nil {
+		props.AddProperty("build.timestamp", strconv.FormatInt(started.UnixNano()/int64(time.Millisecond), 10))
+	}
+	props.AddProperty("attestation.predicateType", attestation.PredicateType)
+	return props
+}
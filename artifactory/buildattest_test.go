@@ -0,0 +1,87 @@
+package artifactory
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/attestation"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadBuildProvenance(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	assert.NoError(t, err)
+	keyPath := filepath.Join(tmpDir, "key.pem")
+	assert.NoError(t, ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+	signer, err := attestation.LoadSigner(keyPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// A fake Artifactory, which serves the published build-info and the sha256 checksums of its artifacts.
+	var aqlQuery, uploadPath string
+	var uploadedContent []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/build/app/42":
+			w.Write([]byte(`{"buildInfo":{"name":"app","number":"42","started":"2021-03-01T10:00:00.000+0200",
+				"vcs":[{"url":"https://github.com/acme/app.git","revision":"6a1b2c"}],
+				"modules":[{"id":"app","artifacts":[{"name":"app.zip","sha1":"a1"}]}]}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/search/aql":
+			body, _ := ioutil.ReadAll(r.Body)
+			aqlQuery = string(body)
+			w.Write([]byte(`{"results":[{"repo":"generic-local","path":"app/42","name":"app.zip","sha256":"abc"}]}`))
+		case r.Method == http.MethodPut:
+			uploadPath = r.URL.Path
+			uploadedContent, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	buildConfiguration := &utils.BuildConfiguration{BuildName: "app", BuildNumber: "42"}
+	err = uploadBuildProvenance(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, buildConfiguration, "https://ci.acme.io/job/app/42", signer, "attestations-local/")
+	assert.NoError(t, err)
+	assert.Equal(t, `items.find({"artifact.module.build.name":"app","artifact.module.build.number":"42"}).include("repo","path","name","sha256")`, aqlQuery)
+	pathAndProps := strings.Split(uploadPath, ";")
+	assert.Equal(t, "/attestations-local/app/42/provenance.intoto.jsonl", pathAndProps[0])
+	assert.Contains(t, pathAndProps, "build.name=app")
+	assert.Contains(t, pathAndProps, "build.number=42")
+	assert.Contains(t, pathAndProps, "build.timestamp=1614585600000")
+
+	envelope := new(attestation.Envelope)
+	if !assert.NoError(t, json.Unmarshal(uploadedContent, envelope)) {
+		return
+	}
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	assert.NoError(t, err)
+	statement := new(attestation.Statement)
+	assert.NoError(t, json.Unmarshal(payload, statement))
+	assert.Equal(t, []attestation.Subject{{Name: "generic-local/app/42/app.zip", Digest: map[string]string{"sha256": "abc"}}}, statement.Subject)
+	assert.Equal(t, "https://ci.acme.io/job/app/42", statement.Predicate.Builder.Id)
+	assert.Equal(t, []attestation.Material{{Uri: "git+https://github.com/acme/app.git", Digest: map[string]string{"sha1": "6a1b2c"}}}, statement.Predicate.Materials)
+}
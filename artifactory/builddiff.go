@@ -0,0 +1,314 @@
+package artifactory
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The differences between two build-infos of the same build.
+// The statuses of modules, artifacts, dependencies, environment variables and VCS entries are of the second build, compared with the first.
+type buildInfoDifference struct {
+	BuildName string              `json:"buildName"`
+	From      string              `json:"from"`
+	To        string              `json:"to"`
+	Modules   []*moduleDifference `json:"modules,omitempty"`
+	Env       []*envDifference    `json:"env,omitempty"`
+	Vcs       []*vcsDifference    `json:"vcs,omitempty"`
+}
+
+type moduleDifference struct {
+	Id           string                  `json:"id"`
+	Status       string                  `json:"status"`
+	Artifacts    []*artifactDifference   `json:"artifacts,omitempty"`
+	Dependencies []*dependencyDifference `json:"dependencies,omitempty"`
+}
+
+type artifactDifference struct {
+	Name   string           `json:"name"`
+	Status string           `json:"status"`
+	Sha1   *valueDifference `json:"sha1,omitempty"`
+}
+
+// Dependencies are compared by their IDs without their versions, so that a version upgrade is reported as a change.
+type dependencyDifference struct {
+	Id      string           `json:"id"`
+	Status  string           `json:"status"`
+	Version *valueDifference `json:"version,omitempty"`
+	Sha1    *valueDifference `json:"sha1,omitempty"`
+}
+
+type envDifference struct {
+	Key    string           `json:"key"`
+	Status string           `json:"status"`
+	Value  *valueDifference `json:"value"`
+}
+
+type vcsDifference struct {
+	Url      string           `json:"url"`
+	Status   string           `json:"status"`
+	Revision *valueDifference `json:"revision,omitempty"`
+	Branch   *valueDifference `json:"branch,omitempty"`
+}
+
+// The values of the first and second builds. Omitted values are missing, or empty, in their build.
+type valueDifference struct {
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+func buildDiffCmd(c *cli.Context) error {
+	if c.NArg() != 3 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	buildName, fromNumber, toNumber := c.Args().Get(0), c.Args().Get(1), c.Args().Get(2)
+	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	serviceManager, err := utils.CreateServiceManager(rtDetails, retries, false)
+	if err != nil {
+		return err
+	}
+	from, err := getPublishedBuildInfo(serviceManager, buildName, fromNumber, c.String("project"))
+	if err != nil {
+		return err
+	}
+	to, err := getPublishedBuildInfo(serviceManager, buildName, toNumber, c.String("project"))
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(diffBuildInfos(from, to))
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	log.Output(clientutils.IndentJson(content))
+	return nil
+}
+
+func getPublishedBuildInfo(serviceManager artifactory.ArtifactoryServicesManager, buildName, buildNumber, projectKey string) (*buildinfocmd.BuildInfo, error) {
+	params := services.NewBuildInfoParams()
+	params.BuildName, params.BuildNumber, params.ProjectKey = buildName, buildNumber, projectKey
+	publishedBuildInfo, found, err := serviceManager.GetBuildInfo(params)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errorutils.CheckError(errors.New("build " + buildName + "/" + buildNumber + " was not found in Artifactory"))
+	}
+	return &publishedBuildInfo.BuildInfo, nil
+}
+
+func diffBuildInfos(from, to *buildinfocmd.BuildInfo) *buildInfoDifference {
+	difference := &buildInfoDifference{BuildName: to.Name, From: from.Number, To: to.Number}
+
+	fromModules, toModules, ids := map[string]*buildinfocmd.Module{}, map[string]*buildinfocmd.Module{}, map[string]bool{}
+	for i := range from.Modules {
+		fromModules[from.Modules[i].Id] = &from.Modules[i]
+		ids[from.Modules[i].Id] = true
+	}
+	for i := range to.Modules {
+		toModules[to.Modules[i].Id] = &to.Modules[i]
+		ids[to.Modules[i].Id] = true
+	}
+	for _, id := range getSortedKeys(ids) {
+		fromModule, toModule := fromModules[id], toModules[id]
+		moduleDiff := &moduleDifference{Id: id, Status: getDiffStatus(fromModule != nil, toModule != nil)}
+		var fromArtifacts, toArtifacts []buildinfocmd.Artifact
+		var fromDependencies, toDependencies []buildinfocmd.Dependency
+		if fromModule != nil {
+			fromArtifacts, fromDependencies = fromModule.Artifacts, fromModule.Dependencies
+		}
+		if toModule != nil {
+			toArtifacts, toDependencies = toModule.Artifacts, toModule.Dependencies
+		}
+		moduleDiff.Artifacts = diffArtifacts(fromArtifacts, toArtifacts)
+		moduleDiff.Dependencies = diffDependencies(fromDependencies, toDependencies)
+		if moduleDiff.Status != diffChanged || len(moduleDiff.Artifacts) > 0 || len(moduleDiff.Dependencies) > 0 {
+			difference.Modules = append(difference.Modules, moduleDiff)
+		}
+	}
+
+	keys := map[string]bool{}
+	for key := range from.Properties {
+		keys[key] = true
+	}
+	for key := range to.Properties {
+		keys[key] = true
+	}
+	for _, key := range getSortedKeys(keys) {
+		fromValue, inFrom := from.Properties[key]
+		toValue, inTo := to.Properties[key]
+		if inFrom && inTo && fromValue == toValue {
+			continue
+		}
+		difference.Env = append(difference.Env, &envDifference{Key: key, Status: getDiffStatus(inFrom, inTo), Value: &valueDifference{From: fromValue, To: toValue}})
+	}
+
+	difference.Vcs = diffVcs(from.VcsList, to.VcsList)
+	return difference
+}
+
+func diffArtifacts(from, to []buildinfocmd.Artifact) []*artifactDifference {
+	fromSha1s, toSha1s, names := map[string]string{}, map[string]string{}, map[string]bool{}
+	for _, artifact := range from {
+		fromSha1s[artifact.Name] = getSha1(artifact.Checksum)
+		names[artifact.Name] = true
+	}
+	for _, artifact := range to {
+		toSha1s[artifact.Name] = getSha1(artifact.Checksum)
+		names[artifact.Name] = true
+	}
+	var differences []*artifactDifference
+	for _, name := range getSortedKeys(names) {
+		fromSha1, inFrom := fromSha1s[name]
+		toSha1, inTo := toSha1s[name]
+		if inFrom && inTo && fromSha1 == toSha1 {
+			continue
+		}
+		differences = append(differences, &artifactDifference{Name: name, Status: getDiffStatus(inFrom, inTo), Sha1: &valueDifference{From: fromSha1, To: toSha1}})
+	}
+	return differences
+}
+
+type dependencyVersion struct {
+	version string
+	sha1    string
+}
+
+func diffDependencies(from, to []buildinfocmd.Dependency) []*dependencyDifference {
+	duplicated := getDuplicatedDependencies(from, to)
+	fromVersions, toVersions, ids := getDependencyVersions(from, duplicated), getDependencyVersions(to, duplicated), map[string]bool{}
+	for id := range fromVersions {
+		ids[id] = true
+	}
+	for id := range toVersions {
+		ids[id] = true
+	}
+	var differences []*dependencyDifference
+	for _, id := range getSortedKeys(ids) {
+		fromVersion, inFrom := fromVersions[id]
+		toVersion, inTo := toVersions[id]
+		if inFrom && inTo && fromVersion == toVersion {
+			continue
+		}
+		dependencyDiff := &dependencyDifference{Id: id, Status: getDiffStatus(inFrom, inTo)}
+		if fromVersion.version != toVersion.version {
+			dependencyDiff.Version = &valueDifference{From: fromVersion.version, To: toVersion.version}
+		}
+		if fromVersion.sha1 != toVersion.sha1 {
+			dependencyDiff.Sha1 = &valueDifference{From: fromVersion.sha1, To: toVersion.sha1}
+		}
+		differences = append(differences, dependencyDiff)
+	}
+	return differences
+}
+
+// Returns the versions of the dependencies by their IDs without their versions.
+// The version of a dependency is the last part of its ID, such as the version of 'org.acme:lib:1.0'.
+// The dependencies whose IDs without their versions are duplicated are compared by their full IDs.
+func getDependencyVersions(dependencies []buildinfocmd.Dependency, duplicated map[string]bool) map[string]dependencyVersion {
+	versions := map[string]dependencyVersion{}
+	for _, dependency := range dependencies {
+		id, version := splitDependencyId(dependency.Id)
+		if duplicated[id] {
+			id, version = dependency.Id, ""
+		}
+		versions[id] = dependencyVersion{version: version, sha1: getSha1(dependency.Checksum)}
+	}
+	return versions
+}
+
+// Returns the IDs without their versions which appear more than once in the dependencies of either module,
+// such as 'org.acme:lib' of a module which depends on both 'org.acme:lib:1.0' and 'org.acme:lib:2.0'.
+func getDuplicatedDependencies(from, to []buildinfocmd.Dependency) map[string]bool {
+	duplicated := map[string]bool{}
+	for _, dependencies := range [][]buildinfocmd.Dependency{from, to} {
+		counts := map[string]int{}
+		for _, dependency := range dependencies {
+			id, _ := splitDependencyId(dependency.Id)
+			if counts[id]++; counts[id] > 1 {
+				duplicated[id] = true
+			}
+		}
+	}
+	return duplicated
+}
+
+// Splits the ID of a dependency to its ID without the version and its version.
+func splitDependencyId(dependencyId string) (id, version string) {
+	if separator := strings.LastIndex(dependencyId, ":"); separator > 0 {
+		return dependencyId[:separator], dependencyId[separator+1:]
+	}
+	return dependencyId, ""
+}
+
+func diffVcs(from, to []buildinfocmd.Vcs) []*vcsDifference {
+	fromVcs, toVcs, urls := map[string]buildinfocmd.Vcs{}, map[string]buildinfocmd.Vcs{}, map[string]bool{}
+	for _, vcs := range from {
+		fromVcs[vcs.Url] = vcs
+		urls[vcs.Url] = true
+	}
+	for _, vcs := range to {
+		toVcs[vcs.Url] = vcs
+		urls[vcs.Url] = true
+	}
+	var differences []*vcsDifference
+	for _, url := range getSortedKeys(urls) {
+		fromEntry, inFrom := fromVcs[url]
+		toEntry, inTo := toVcs[url]
+		if inFrom && inTo && fromEntry.Revision == toEntry.Revision && fromEntry.Branch == toEntry.Branch {
+			continue
+		}
+		vcsDiff := &vcsDifference{Url: url, Status: getDiffStatus(inFrom, inTo)}
+		if fromEntry.Revision != toEntry.Revision {
+			vcsDiff.Revision = &valueDifference{From: fromEntry.Revision, To: toEntry.Revision}
+		}
+		if fromEntry.Branch != toEntry.Branch {
+			vcsDiff.Branch = &valueDifference{From: fromEntry.Branch, To: toEntry.Branch}
+		}
+		differences = append(differences, vcsDiff)
+	}
+	return differences
+}
+
+func getDiffStatus(inFrom, inTo bool) string {
+	switch {
+	case !inFrom:
+		return diffAdded
+	case !inTo:
+		return diffRemoved
+	}
+	return diffChanged
+}
+
+func getSha1(checksum *buildinfocmd.Checksum) string {
+	if checksum == nil {
+		return ""
+	}
+	return checksum.Sha1
+}
+
+func getSortedKeys(keysSet map[string]bool) []string {
+	keys := make([]string, 0, len(keysSet))
+	for key := range keysSet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,112 @@
+package artifactory
+
+import (
+	"testing"
+
+	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffBuildInfos(t *testing.T) {
+	from := &buildinfocmd.BuildInfo{
+		Name:   "app",
+		Number: "41",
+		Modules: []buildinfocmd.Module{
+			{
+				Id: "org:app:41",
+				Artifacts: []buildinfocmd.Artifact{
+					{Name: "app.jar", Checksum: &buildinfocmd.Checksum{Sha1: "a1"}},
+					{Name: "app.pom", Checksum: &buildinfocmd.Checksum{Sha1: "p1"}},
+					{Name: "app-sources.jar", Checksum: &buildinfocmd.Checksum{Sha1: "s1"}},
+				},
+				Dependencies: []buildinfocmd.Dependency{
+					{Id: "org:lib:1.0", Checksum: &buildinfocmd.Checksum{Sha1: "l1"}},
+					{Id: "org:util:2.0", Checksum: &buildinfocmd.Checksum{Sha1: "u2"}},
+					{Id: "org:old:1.0", Checksum: &buildinfocmd.Checksum{Sha1: "o1"}},
+				},
+			},
+			{Id: "org:unchanged:1.0", Artifacts: []buildinfocmd.Artifact{{Name: "unchanged.jar", Checksum: &buildinfocmd.Checksum{Sha1: "x"}}}},
+			{Id: "org:removed:1.0", Artifacts: []buildinfocmd.Artifact{{Name: "removed.jar", Checksum: &buildinfocmd.Checksum{Sha1: "r"}}}},
+		},
+		Properties: buildinfocmd.Env{"buildInfo.env.JAVA_HOME": "/jdk8", "buildInfo.env.CI": "true", "buildInfo.env.OLD": "1"},
+		VcsList:    []buildinfocmd.Vcs{{Url: "https://git/app.git", Revision: "abc", Branch: "main"}, {Url: "https://git/same.git", Revision: "123"}},
+	}
+	to := &buildinfocmd.BuildInfo{
+		Name:   "app",
+		Number: "42",
+		Modules: []buildinfocmd.Module{
+			{
+				Id: "org:app:41",
+				Artifacts: []buildinfocmd.Artifact{
+					{Name: "app.jar", Checksum: &buildinfocmd.Checksum{Sha1: "a2"}},
+					{Name: "app.pom", Checksum: &buildinfocmd.Checksum{Sha1: "p1"}},
+					{Name: "app-javadoc.jar", Checksum: &buildinfocmd.Checksum{Sha1: "j2"}},
+				},
+				Dependencies: []buildinfocmd.Dependency{
+					{Id: "org:lib:1.1", Checksum: &buildinfocmd.Checksum{Sha1: "l2"}},
+					{Id: "org:util:2.0", Checksum: &buildinfocmd.Checksum{Sha1: "u2"}},
+					{Id: "org:new:1.0"},
+				},
+			},
+			{Id: "org:unchanged:1.0", Artifacts: []buildinfocmd.Artifact{{Name: "unchanged.jar", Checksum: &buildinfocmd.Checksum{Sha1: "x"}}}},
+			{Id: "org:added:1.0"},
+		},
+		Properties: buildinfocmd.Env{"buildInfo.env.JAVA_HOME": "/jdk11", "buildInfo.env.CI": "true", "buildInfo.env.NEW": "2"},
+		VcsList:    []buildinfocmd.Vcs{{Url: "https://git/app.git", Revision: "def", Branch: "main"}, {Url: "https://git/same.git", Revision: "123"}},
+	}
+
+	expected := &buildInfoDifference{
+		BuildName: "app",
+		From:      "41",
+		To:        "42",
+		Modules: []*moduleDifference{
+			{Id: "org:added:1.0", Status: diffAdded},
+			{
+				Id:     "org:app:41",
+				Status: diffChanged,
+				Artifacts: []*artifactDifference{
+					{Name: "app-javadoc.jar", Status: diffAdded, Sha1: &valueDifference{To: "j2"}},
+					{Name: "app-sources.jar", Status: diffRemoved, Sha1: &valueDifference{From: "s1"}},
+					{Name: "app.jar", Status: diffChanged, Sha1: &valueDifference{From: "a1", To: "a2"}},
+				},
+				Dependencies: []*dependencyDifference{
+					{Id: "org:lib", Status: diffChanged, Version: &valueDifference{From: "1.0", To: "1.1"}, Sha1: &valueDifference{From: "l1", To: "l2"}},
+					{Id: "org:new", Status: diffAdded, Version: &valueDifference{To: "1.0"}},
+					{Id: "org:old", Status: diffRemoved, Version: &valueDifference{From: "1.0"}, Sha1: &valueDifference{From: "o1"}},
+				},
+			},
+			{Id: "org:removed:1.0", Status: diffRemoved, Artifacts: []*artifactDifference{{Name: "removed.jar", Status: diffRemoved, Sha1: &valueDifference{From: "r"}}}},
+		},
+		Env: []*envDifference{
+			{Key: "buildInfo.env.JAVA_HOME", Status: diffChanged, Value: &valueDifference{From: "/jdk8", To: "/jdk11"}},
+			{Key: "buildInfo.env.NEW", Status: diffAdded, Value: &valueDifference{To: "2"}},
+			{Key: "buildInfo.env.OLD", Status: diffRemoved, Value: &valueDifference{From: "1"}},
+		},
+		Vcs: []*vcsDifference{{Url: "https://git/app.git", Status: diffChanged, Revision: &valueDifference{From: "abc", To: "def"}}},
+	}
+	assert.Equal(t, expected, diffBuildInfos(from, to))
+}
+
+func TestGetDependencyVersions(t *testing.T) {
+	dependencies := []buildinfocmd.Dependency{{Id: "org:lib:1.0"}, {Id: "org:lib:2.0"}, {Id: "org:other:1.0"}, {Id: "no-version", Checksum: &buildinfocmd.Checksum{Sha1: "s"}}}
+	duplicated := getDuplicatedDependencies(dependencies, nil)
+	assert.Equal(t, map[string]bool{"org:lib": true}, duplicated)
+	assert.Equal(t, map[string]dependencyVersion{
+		"org:lib:1.0": {},
+		"org:lib:2.0": {},
+		"org:other":   {version: "1.0"},
+		"no-version":  {sha1: "s"},
+	}, getDependencyVersions(dependencies, duplicated))
+}
+
+func TestDiffDuplicatedDependencies(t *testing.T) {
+	// A dependency duplicated in one of the builds is compared by its full ID in both builds.
+	from := []buildinfocmd.Dependency{{Id: "org:lib:1.0"}, {Id: "org:lib:2.0"}}
+	to := []buildinfocmd.Dependency{{Id: "org:lib:2.0"}}
+	differences := diffDependencies(from, to)
+	if assert.Len(t, differences, 1) {
+		assert.Equal(t, "org:lib:1.0", differences[0].Id)
+		assert.Equal(t, diffRemoved, differences[0].Status)
+	}
+	assert.Empty(t, diffDependencies(from, from))
+}
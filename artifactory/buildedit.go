@@ -0,0 +1,123 @@
+package artifactory
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func buildEditCmd(c *cli.Context) error {
+	if c.NArg() > 2 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if !c.IsSet("remove-module") && !c.IsSet("props") {
+		return cliutils.PrintHelpAndReturnError("The --remove-module or --props option is expected.", c)
+	}
+	buildConfiguration := createBuildConfiguration(c)
+	if err := validateBuildConfiguration(c, buildConfiguration); err != nil {
+		return err
+	}
+	props, err := parseBuildProperties(c.String("props"))
+	if err != nil {
+		return err
+	}
+	partials, err := readPartialFiles(buildConfiguration)
+	if err != nil {
+		return err
+	}
+	if c.IsSet("remove-module") {
+		if err = removeModulePartials(buildConfiguration, partials, c.String("remove-module")); err != nil {
+			return err
+		}
+	}
+	if len(props) > 0 {
+		envExclude := cliutils.GetEnvExclude("")
+		if envExclude == "" {
+			envExclude = defaultEnvExclude
+		}
+		excludedProps, err := getExcludedBuildProperties(props, envExclude)
+		if err != nil {
+			return err
+		}
+		if len(excludedProps) > 0 {
+			log.Warn("The properties " + strings.Join(excludedProps, ", ") + " match the environment variables exclude patterns '" + envExclude + "', so build-publish will not publish them. " +
+				"To publish them, run build-publish with an --env-exclude option which doesn't match them.")
+		}
+		// The properties are saved as collected environment variables. Being the latest, they override the variables collected before.
+		err = utils.SavePartialBuildInfo(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project, func(partial *buildinfocmd.Partial) {
+			partial.Env = props
+		})
+		if err != nil {
+			return err
+		}
+		log.Info("Set the properties of build " + buildConfiguration.BuildName + "/" + buildConfiguration.BuildNumber + ".")
+	}
+	return nil
+}
+
+// Parses properties in the form of "key1=value1;key2=value2".
+// Keys without the build-info environment prefix get it, as the environment variables collected by 'build-collect-env'.
+func parseBuildProperties(propsStr string) (buildinfocmd.Env, error) {
+	props := buildinfocmd.Env{}
+	for _, prop := range strings.Split(propsStr, ";") {
+		if prop == "" {
+			continue
+		}
+		separator := strings.Index(prop, "=")
+		if separator <= 0 {
+			return nil, errorutils.CheckError(errors.New("invalid property '" + prop + "'. Properties are expected in the form of key1=value1;key2=value2"))
+		}
+		key := prop[:separator]
+		if !strings.HasPrefix(key, buildinfocmd.BuildInfoEnvPrefix) {
+			key = buildinfocmd.BuildInfoEnvPrefix + key
+		}
+		props[key] = prop[separator+1:]
+	}
+	return props, nil
+}
+
+// Returns the keys of the properties which 'build-publish' filters out by the exclude patterns, since it publishes them as environment variables.
+func getExcludedBuildProperties(props buildinfocmd.Env, envExclude string) ([]string, error) {
+	includedProps, err := buildinfocmd.Configuration{EnvExclude: envExclude}.ExcludeFilter()(props)
+	if err != nil {
+		return nil, err
+	}
+	var excludedProps []string
+	for key := range props {
+		if _, included := includedProps[key]; !included {
+			excludedProps = append(excludedProps, strings.TrimPrefix(key, buildinfocmd.BuildInfoEnvPrefix))
+		}
+	}
+	sort.Strings(excludedProps)
+	return excludedProps, nil
+}
+
+// Removes the partial build-info files of the module's artifacts, dependencies and checksum.
+// Environment variables and VCS details belong to the build, so they're kept even if they were collected with the module.
+func removeModulePartials(buildConfiguration *utils.BuildConfiguration, partials []*partialFile, moduleId string) error {
+	buildName, buildNumber := buildConfiguration.BuildName, buildConfiguration.BuildNumber
+	removed := 0
+	for _, partialFile := range partials {
+		partial := partialFile.partial
+		if getPartialModuleId(buildName, partial) != moduleId || partial.Env != nil || partial.VcsList != nil {
+			continue
+		}
+		if err := os.Remove(partialFile.path); errorutils.CheckError(err) != nil {
+			return err
+		}
+		removed++
+	}
+	if removed == 0 {
+		return errorutils.CheckError(errors.New("module '" + moduleId + "' was not found in the build-info of build " + buildName + "/" + buildNumber))
+	}
+	log.Info("Removed module " + moduleId + " from build " + buildName + "/" + buildNumber + ".")
+	return nil
+}
@@ -0,0 +1,271 @@
+package artifactory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The version of the build export file format.
+const buildExportVersion = 1
+
+// The build-info collected for a build on one agent, to be imported by the agent which publishes the build.
+type buildExport struct {
+	Version int    `json:"version"`
+	Name    string `json:"name"`
+	Number  string `json:"number"`
+	// The time the build started on the exporting agent.
+	Started time.Time `json:"started"`
+	// The partial build-infos saved by the build commands, such as 'upload' and 'build-add-git'.
+	Partials []*buildinfocmd.Partial `json:"partials"`
+	// The build-infos generated by the build tools extractors, such as the Maven and Gradle extractors.
+	BuildInfos []*buildinfocmd.BuildInfo `json:"buildInfos,omitempty"`
+}
+
+func buildExportCmd(c *cli.Context) error {
+	if c.NArg() > 2 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if c.String("file") == "" {
+		return cliutils.PrintHelpAndReturnError("The --file option is mandatory.", c)
+	}
+	buildConfiguration := createBuildConfiguration(c)
+	if err := validateBuildConfiguration(c, buildConfiguration); err != nil {
+		return err
+	}
+	export, err := createBuildExport(buildConfiguration)
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(export, "", "  ")
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(c.String("file"), content, 0600); errorutils.CheckError(err) != nil {
+		return err
+	}
+	log.Info("Exported the build-info of build " + export.Name + "/" + export.Number + " to " + c.String("file"))
+	return nil
+}
+
+func createBuildExport(buildConfiguration *utils.BuildConfiguration) (*buildExport, error) {
+	buildName, buildNumber, projectKey := buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project
+	partials, err := readPartialFiles(buildConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	generalDetails, err := utils.ReadBuildInfoGeneralDetails(buildName, buildNumber, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	buildInfos, err := utils.GetGeneratedBuildsInfo(buildName, buildNumber, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	export := &buildExport{Version: buildExportVersion, Name: buildName, Number: buildNumber, Started: generalDetails.Timestamp, Partials: []*buildinfocmd.Partial{}, BuildInfos: buildInfos}
+	for _, partialFile := range partials {
+		export.Partials = append(export.Partials, partialFile.partial)
+	}
+	return export, nil
+}
+
+func buildImportCmd(c *cli.Context) error {
+	// The build name and number are provided together, or not at all.
+	if c.NArg() != 0 && c.NArg() != 2 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if c.String("file") == "" {
+		return cliutils.PrintHelpAndReturnError("The --file option is mandatory.", c)
+	}
+	exports, err := readBuildExports(c.String("file"))
+	if err != nil {
+		return err
+	}
+	// The build is the build of the exports, unless another build is provided.
+	buildConfiguration := createBuildConfiguration(c)
+	if buildConfiguration.BuildName == "" || buildConfiguration.BuildNumber == "" {
+		buildConfiguration.BuildName, buildConfiguration.BuildNumber = exports[0].Name, exports[0].Number
+		for _, export := range exports[1:] {
+			if export.Name != buildConfiguration.BuildName || export.Number != buildConfiguration.BuildNumber {
+				return cliutils.PrintHelpAndReturnError("The files are exports of different builds. Provide the build name and number to import them to.", c)
+			}
+		}
+	}
+	return importBuildExports(buildConfiguration, exports)
+}
+
+// Reads the build export files, by a list of paths in the form of "path1;path2;...". The paths may contain wildcards.
+func readBuildExports(files string) ([]*buildExport, error) {
+	var paths []string
+	for _, pattern := range strings.Split(files, ";") {
+		if pattern == "" {
+			continue
+		}
+		matches, err := filepath.Glob(pattern)
+		if errorutils.CheckError(err) != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, errorutils.CheckError(errors.New("no build export files match '" + pattern + "'"))
+		}
+		paths = append(paths, matches...)
+	}
+	var exports []*buildExport
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if errorutils.CheckError(err) != nil {
+			return nil, err
+		}
+		export := new(buildExport)
+		if err = json.Unmarshal(content, export); err != nil {
+			return nil, errorutils.CheckError(fmt.Errorf("failed to parse the build export file %s: %s", path, err.Error()))
+		}
+		if export.Version != buildExportVersion {
+			return nil, errorutils.CheckError(errors.New("the build export file " + path + " is of unsupported version " + strconv.Itoa(export.Version)))
+		}
+		exports = append(exports, export)
+	}
+	if len(exports) == 0 {
+		return nil, errorutils.CheckError(errors.New("no build export files were provided"))
+	}
+	return exports, nil
+}
+
+// Adds the partial and generated build-infos of the exports to the build-info collected for the build.
+// Build-infos which were already collected, or imported, are skipped.
+// The modules of the exports are merged by their IDs, and their artifacts and dependencies are deduplicated when the build is published.
+func importBuildExports(buildConfiguration *utils.BuildConfiguration, exports []*buildExport) error {
+	buildName, buildNumber, projectKey := buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project
+	existingPartials, started, err := readCollectedPartials(buildConfiguration)
+	if err != nil {
+		return err
+	}
+	existingBuildInfos, err := utils.GetGeneratedBuildsInfo(buildName, buildNumber, projectKey)
+	if err != nil {
+		return err
+	}
+	keys := map[string]bool{}
+	for _, partial := range existingPartials {
+		keys[getImportKey(partial)] = true
+	}
+	for _, buildInfo := range existingBuildInfos {
+		keys[getImportKey(buildInfo)] = true
+	}
+
+	imported, skipped := 0, 0
+	for _, export := range exports {
+		// The build started when it started on the earliest agent.
+		if started == nil || export.Started.Before(*started) {
+			exportStarted := export.Started
+			started = &exportStarted
+		}
+		for _, partial := range export.Partials {
+			key := getImportKey(partial)
+			if keys[key] {
+				skipped++
+				continue
+			}
+			keys[key] = true
+			exportedPartial := partial
+			err = utils.SavePartialBuildInfo(buildName, buildNumber, projectKey, func(partial *buildinfocmd.Partial) {
+				*partial = *exportedPartial
+			})
+			if err != nil {
+				return err
+			}
+			imported++
+		}
+		for _, buildInfo := range export.BuildInfos {
+			key := getImportKey(buildInfo)
+			if keys[key] {
+				skipped++
+				continue
+			}
+			keys[key] = true
+			if err = utils.SaveBuildInfo(buildName, buildNumber, projectKey, buildInfo); err != nil {
+				return err
+			}
+			imported++
+		}
+	}
+	if err = saveBuildStarted(buildConfiguration, *started); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Imported %d build-info parts from %d files to build %s/%s. %d duplicate parts were skipped.", imported, len(exports), buildName, buildNumber, skipped))
+	return nil
+}
+
+// Returns the partials already collected for the build, and the time the build started.
+// If nothing was collected for the build, no partials and no start time are returned.
+func readCollectedPartials(buildConfiguration *utils.BuildConfiguration) ([]*buildinfocmd.Partial, *time.Time, error) {
+	detailsPath, err := getBuildDetailsPath(buildConfiguration)
+	if err != nil {
+		return nil, nil, err
+	}
+	exists, err := fileutils.IsFileExists(detailsPath, false)
+	if err != nil || !exists {
+		return nil, nil, err
+	}
+	partialFiles, err := readPartialFiles(buildConfiguration)
+	if err != nil {
+		return nil, nil, err
+	}
+	var partials []*buildinfocmd.Partial
+	for _, partialFile := range partialFiles {
+		partials = append(partials, partialFile.partial)
+	}
+	generalDetails, err := utils.ReadBuildInfoGeneralDetails(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project)
+	if err != nil {
+		return nil, nil, err
+	}
+	return partials, &generalDetails.Timestamp, nil
+}
+
+// Unlike utils.SaveBuildGeneralDetails, which keeps the start time of a build once it's saved, the start time is overridden.
+func saveBuildStarted(buildConfiguration *utils.BuildConfiguration, started time.Time) error {
+	detailsPath, err := getBuildDetailsPath(buildConfiguration)
+	if err != nil {
+		return err
+	}
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(detailsPath)); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(&buildinfocmd.General{Timestamp: started}, "", "  ")
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	return errorutils.CheckError(ioutil.WriteFile(detailsPath, content, 0600))
+}
+
+func getBuildDetailsPath(buildConfiguration *utils.BuildConfiguration) (string, error) {
+	buildDir, err := utils.GetBuildDir(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(buildDir, partialsDirName, utils.BuildInfoDetails), nil
+}
+
+// Build-infos are identified by their content. Partial build-infos are identified regardless of the time they were saved,
+// so that the VCS details and environment variables collected identically by several agents are imported once.
+func getImportKey(buildInfoPart interface{}) string {
+	if partial, isPartial := buildInfoPart.(*buildinfocmd.Partial); isPartial {
+		withoutTimestamp := *partial
+		withoutTimestamp.Timestamp = 0
+		buildInfoPart = &withoutTimestamp
+	}
+	content, _ := json.Marshal(buildInfoPart)
+	return fmt.Sprintf("%T:%s", buildInfoPart, content)
+}
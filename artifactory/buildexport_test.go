@@ -0,0 +1,104 @@
+package artifactory
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportAndImportBuild(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	buildConfiguration := &utils.BuildConfiguration{BuildName: "build-export-test-" + strconv.FormatInt(time.Now().UnixNano(), 10), BuildNumber: "1"}
+	buildName, buildNumber := buildConfiguration.BuildName, buildConfiguration.BuildNumber
+	defer utils.RemoveBuildDir(buildName, buildNumber, "")
+
+	// Each agent collects the artifacts of its module, and the same VCS details.
+	vcs := []buildinfocmd.Vcs{{Url: "https://github.com/acme/app.git", Revision: "abc"}}
+	collectAndExport := func(agent string, timestamp int64) {
+		assert.NoError(t, utils.SaveBuildGeneralDetails(buildName, buildNumber, ""))
+		assert.NoError(t, utils.SavePartialBuildInfo(buildName, buildNumber, "", func(partial *buildinfocmd.Partial) {
+			partial.Timestamp, partial.ModuleId, partial.ModuleType = timestamp, agent, buildinfocmd.Generic
+			partial.Artifacts = []buildinfocmd.Artifact{{Name: agent + ".zip", Checksum: &buildinfocmd.Checksum{Sha1: agent}}}
+		}))
+		assert.NoError(t, utils.SavePartialBuildInfo(buildName, buildNumber, "", func(partial *buildinfocmd.Partial) {
+			partial.Timestamp, partial.VcsList = timestamp+1, vcs
+		}))
+		export, err := createBuildExport(buildConfiguration)
+		if assert.NoError(t, err) {
+			assert.Len(t, export.Partials, 2)
+			assert.NoError(t, writeTestBuildExport(filepath.Join(tmpDir, agent+".json"), export))
+		}
+		assert.NoError(t, utils.RemoveBuildDir(buildName, buildNumber, ""))
+	}
+	collectAndExport("linux", 1)
+	collectAndExport("windows", 10)
+
+	exports, err := readBuildExports(filepath.Join(tmpDir, "*.json"))
+	if !assert.NoError(t, err) || !assert.Len(t, exports, 2) {
+		return
+	}
+	earliest := exports[0].Started
+	if exports[1].Started.Before(earliest) {
+		earliest = exports[1].Started
+	}
+	assert.NoError(t, importBuildExports(buildConfiguration, exports))
+	// Importing the same exports again doesn't duplicate the build-info.
+	assert.NoError(t, importBuildExports(buildConfiguration, exports))
+
+	partials, err := readPartialFiles(buildConfiguration)
+	assert.NoError(t, err)
+	assert.Len(t, partials, 3)
+	buildInfo, err := aggregatePartials(buildConfiguration, partials, &buildinfocmd.Configuration{EnvInclude: "*", EnvExclude: ""})
+	assert.NoError(t, err)
+	assert.Equal(t, earliest.Format(buildinfocmd.TimeFormat), buildInfo.Started)
+	assert.Equal(t, vcs, buildInfo.VcsList)
+	var moduleIds []string
+	for _, module := range buildInfo.Modules {
+		moduleIds = append(moduleIds, module.Id)
+	}
+	assert.Equal(t, []string{"linux", buildName, "windows"}, moduleIds)
+}
+
+func TestReadBuildExportsErrors(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	_, err = readBuildExports(filepath.Join(tmpDir, "*.json"))
+	assert.EqualError(t, err, "no build export files match '"+filepath.Join(tmpDir, "*.json")+"'")
+
+	exportPath := filepath.Join(tmpDir, "export.json")
+	assert.NoError(t, ioutil.WriteFile(exportPath, []byte(`{"version":2,"name":"app","number":"1"}`), 0600))
+	_, err = readBuildExports(exportPath)
+	assert.EqualError(t, err, "the build export file "+exportPath+" is of unsupported version 2")
+}
+
+func writeTestBuildExport(path string, export *buildExport) error {
+	content, err := json.Marshal(export)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, content, 0600)
+}
+
+func TestBuildImportCmdArguments(t *testing.T) {
+	context, _ := createContext([]string{"file=build-export.json"}, []string{"myname"})
+	context.Command.Name = "build-import"
+	// A build name without a build number is rejected, rather than importing to the build of the exported files.
+	assert.EqualError(t, buildImportCmd(context), "Wrong number of arguments.")
+}
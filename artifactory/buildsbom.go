@@ -0,0 +1,85 @@
+package artifactory
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/sbom"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func buildSbomCmd(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	format := c.String("sbom")
+	if format == "" {
+		format = sbom.CycloneDx
+	}
+	if err := sbom.ValidateFormat(format); err != nil {
+		return err
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	return writeBuildSbom(rtDetails, retries, c.Args().Get(0), c.Args().Get(1), c.String("project"), format, c.String("sbom-file"))
+}
+
+// Generates the SBOM of a published build, and writes it to the file, or prints it if no file is provided.
+func writeBuildSbom(rtDetails *config.ServerDetails, retries int, buildName, buildNumber, projectKey, format, sbomFile string) error {
+	serviceManager, err := utils.CreateServiceManager(rtDetails, retries, false)
+	if err != nil {
+		return err
+	}
+	buildInfo, err := getPublishedBuildInfo(serviceManager, buildName, buildNumber, projectKey)
+	if err != nil {
+		return err
+	}
+	content, err := sbom.Generate(buildInfo, format, rtDetails.ArtifactoryUrl)
+	if err != nil {
+		return err
+	}
+	if sbomFile == "" {
+		log.Output(string(content))
+		return nil
+	}
+	if err = ioutil.WriteFile(sbomFile, append(content, '\n'), 0644); errorutils.CheckError(err) != nil {
+		return err
+	}
+	log.Info("The SBOM of build " + buildName + "/" + buildNumber + " was written to " + sbomFile)
+	return nil
+}
+
+// Checks that the SBOM file can be written, without changing an existing file or leaving a new one.
+func validateSbomFile(sbomFile string) error {
+	if info, err := os.Stat(sbomFile); err == nil {
+		if info.IsDir() {
+			return errorutils.CheckError(errors.New("the SBOM file " + sbomFile + " is a directory"))
+		}
+		file, err := os.OpenFile(sbomFile, os.O_WRONLY, 0)
+		if err != nil {
+			return errorutils.CheckError(errors.New("cannot write the SBOM file " + sbomFile + ": " + err.Error()))
+		}
+		return errorutils.CheckError(file.Close())
+	}
+	tempFile, err := ioutil.TempFile(filepath.Dir(sbomFile), filepath.Base(sbomFile)+".*.tmp")
+	if err != nil {
+		return errorutils.CheckError(errors.New("cannot write the SBOM file " + sbomFile + ": " + err.Error()))
+	}
+	if err = tempFile.Close(); errorutils.CheckError(err) != nil {
+		return err
+	}
+	return errorutils.CheckError(os.Remove(tempFile.Name()))
+}
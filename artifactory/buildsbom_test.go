@@ -0,0 +1,36 @@
+package artifactory
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSbomFile(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+
+	// Validating a new file doesn't leave it, or any other file, behind.
+	assert.NoError(t, validateSbomFile(filepath.Join(tmpDir, "build.cdx.json")))
+	files, err := ioutil.ReadDir(tmpDir)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+
+	// Validating an existing file doesn't change it.
+	existingFile := filepath.Join(tmpDir, "existing.cdx.json")
+	assert.NoError(t, ioutil.WriteFile(existingFile, []byte("content"), 0644))
+	assert.NoError(t, validateSbomFile(existingFile))
+	content, err := ioutil.ReadFile(existingFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+
+	assert.Error(t, validateSbomFile(tmpDir))
+	assert.Error(t, validateSbomFile(filepath.Join(tmpDir, "missing", "build.cdx.json")))
+}
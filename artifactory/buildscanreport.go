@@ -0,0 +1,101 @@
+package artifactory
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/commands/buildinfo"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/report"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// A build-scan command which adds each violation found by Xray to the report.
+// The scan results of the core build-scan command are not exposed, so the scan is run here.
+type reportedBuildScanCommand struct {
+	*buildinfo.BuildScanCommand
+	serverDetails      *config.ServerDetails
+	buildConfiguration *utils.BuildConfiguration
+	failBuild          bool
+	report             *report.Report
+}
+
+// The parts of the Xray build scan result needed for the report.
+type buildScanResult struct {
+	Summary struct {
+		FailBuild bool `json:"fail_build,omitempty"`
+	} `json:"summary,omitempty"`
+	Alerts []struct {
+		WatchName string `json:"watch_name,omitempty"`
+		Issues    []struct {
+			Severity          string `json:"severity,omitempty"`
+			Type              string `json:"type,omitempty"`
+			Summary           string `json:"summary,omitempty"`
+			ImpactedArtifacts []struct {
+				DisplayName string `json:"display_name,omitempty"`
+			} `json:"impacted_artifacts,omitempty"`
+		} `json:"issues,omitempty"`
+	} `json:"alerts,omitempty"`
+}
+
+func (rbsc *reportedBuildScanCommand) Run() error {
+	buildCase := "build " + rbsc.buildConfiguration.BuildName + "/" + rbsc.buildConfiguration.BuildNumber
+	result, err := rbsc.scan()
+	if err != nil {
+		rbsc.report.AddCase(buildCase, err.Error())
+		return err
+	}
+	scanResult := new(buildScanResult)
+	if err = json.Unmarshal(result, scanResult); errorutils.CheckError(err) != nil {
+		rbsc.report.AddCase(buildCase, err.Error())
+		return err
+	}
+	log.Info("Xray scan completed.")
+	log.Output(clientutils.IndentJson(result))
+
+	if !addBuildScanViolations(rbsc.report, scanResult) {
+		rbsc.report.AddCase(buildCase, "")
+	}
+	if rbsc.failBuild && scanResult.Summary.FailBuild {
+		return errorutils.CheckError(utils.GetBuildScanError())
+	}
+	return nil
+}
+
+func (rbsc *reportedBuildScanCommand) scan() ([]byte, error) {
+	log.Info("Triggered Xray build scan... The scan may take a few minutes.")
+	servicesManager, err := utils.CreateServiceManager(rbsc.serverDetails, -1, false)
+	if err != nil {
+		return nil, err
+	}
+	xrayScanParams := services.NewXrayScanParams()
+	xrayScanParams.BuildName = rbsc.buildConfiguration.BuildName
+	xrayScanParams.BuildNumber = rbsc.buildConfiguration.BuildNumber
+	xrayScanParams.ProjectKey = rbsc.buildConfiguration.Project
+	return servicesManager.XrayScanBuild(xrayScanParams)
+}
+
+// Adds a failing case for each violation in the scan result. Returns false if no violations were found.
+func addBuildScanViolations(commandReport *report.Report, scanResult *buildScanResult) bool {
+	found := false
+	for _, alert := range scanResult.Alerts {
+		for _, issue := range alert.Issues {
+			var impacted []string
+			for _, artifact := range issue.ImpactedArtifacts {
+				impacted = append(impacted, artifact.DisplayName)
+			}
+			failure := fmt.Sprintf("%s severity %s violation of watch '%s'", issue.Severity, issue.Type, alert.WatchName)
+			if len(impacted) > 0 {
+				failure += ", impacting: " + strings.Join(impacted, ", ")
+			}
+			commandReport.AddCase(issue.Summary, failure)
+			found = true
+		}
+	}
+	return found
+}
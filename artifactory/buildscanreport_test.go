@@ -0,0 +1,34 @@
+package artifactory
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddBuildScanViolations(t *testing.T) {
+	scanResult := new(buildScanResult)
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"summary": {"total_alerts": 2, "fail_build": true},
+		"alerts": [{
+			"watch_name": "prod-watch",
+			"issues": [
+				{"severity": "High", "type": "security", "summary": "CVE-2021-1234", "impacted_artifacts": [{"display_name": "app:1.0"}, {"display_name": "lib:2.0"}]},
+				{"severity": "Minor", "type": "license", "summary": "GPL-3.0 license"}
+			]
+		}]
+	}`), scanResult))
+
+	commandReport := report.New("rt build-scan", "", report.Junit)
+	assert.True(t, addBuildScanViolations(commandReport, scanResult))
+	assert.Equal(t, []*report.Case{
+		{Name: "CVE-2021-1234", Failure: "High severity security violation of watch 'prod-watch', impacting: app:1.0, lib:2.0"},
+		{Name: "GPL-3.0 license", Failure: "Minor severity license violation of watch 'prod-watch'"},
+	}, commandReport.Cases)
+
+	commandReport = report.New("rt build-scan", "", report.Junit)
+	assert.False(t, addBuildScanViolations(commandReport, &buildScanResult{}))
+	assert.Empty(t, commandReport.Cases)
+}
@@ -0,0 +1,230 @@
+package artifactory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The name of the directory, under the build directory, in which the partial build-info files are saved.
+const partialsDirName = "partials"
+
+// A partial build-info, saved by one of the build commands, and the file it is saved in.
+type partialFile struct {
+	path    string
+	partial *buildinfocmd.Partial
+}
+
+func buildShowCmd(c *cli.Context) error {
+	if c.NArg() > 2 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	buildConfiguration := createBuildConfiguration(c)
+	if err := validateBuildConfiguration(c, buildConfiguration); err != nil {
+		return err
+	}
+	partials, err := readPartialFiles(buildConfiguration)
+	if err != nil {
+		return err
+	}
+	buildInfo, err := aggregatePartials(buildConfiguration, partials, createBuildInfoConfiguration(c))
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(buildInfo)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	log.Output(clientutils.IndentJson(content))
+	return nil
+}
+
+// Reads the partial build-info files of a build which wasn't published yet, ordered by the time they were saved.
+func readPartialFiles(buildConfiguration *utils.BuildConfiguration) ([]*partialFile, error) {
+	buildName, buildNumber, projectKey := buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project
+	buildDir, err := utils.GetBuildDir(buildName, buildNumber, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	partialsDir := filepath.Join(buildDir, partialsDirName)
+	exists, err := fileutils.IsFileExists(filepath.Join(partialsDir, utils.BuildInfoDetails), false)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		// GetBuildDir creates the build directory, which is not needed if nothing was collected.
+		if err = utils.RemoveBuildDir(buildName, buildNumber, projectKey); err != nil {
+			return nil, err
+		}
+		return nil, errorutils.CheckError(errors.New("no build-info was collected for build " + buildName + "/" + buildNumber))
+	}
+	paths, err := fileutils.ListFiles(partialsDir, false)
+	if err != nil {
+		return nil, err
+	}
+	var partials []*partialFile
+	for _, path := range paths {
+		if filepath.Base(path) == utils.BuildInfoDetails {
+			continue
+		}
+		isDir, err := fileutils.IsDirExists(path, false)
+		if err != nil {
+			return nil, err
+		}
+		if isDir {
+			continue
+		}
+		content, err := ioutil.ReadFile(path)
+		if errorutils.CheckError(err) != nil {
+			return nil, err
+		}
+		partial := new(buildinfocmd.Partial)
+		if err = json.Unmarshal(content, partial); err != nil {
+			return nil, errorutils.CheckError(fmt.Errorf("failed to parse the partial build-info file %s: %s", path, err.Error()))
+		}
+		partials = append(partials, &partialFile{path: path, partial: partial})
+	}
+	sort.SliceStable(partials, func(i, j int) bool {
+		return partials[i].partial.Timestamp < partials[j].partial.Timestamp
+	})
+	return partials, nil
+}
+
+// Aggregates the partial build-infos into the build-info 'build-publish' would publish.
+// The aggregation of 'build-publish' isn't exposed by jfrog-cli-core, so it is repeated here, and should be kept in line with it.
+// It differs from 'build-publish' only in the following:
+// - The modules, artifacts, dependencies and issues are kept in the order they were collected, while 'build-publish' doesn't keep any order.
+// - The Artifactory user, which 'build-publish' adds as the principal of the build, isn't added.
+func aggregatePartials(buildConfiguration *utils.BuildConfiguration, partials []*partialFile, config *buildinfocmd.Configuration) (*buildinfocmd.BuildInfo, error) {
+	buildName, buildNumber, projectKey := buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project
+	generalDetails, err := utils.ReadBuildInfoGeneralDetails(buildName, buildNumber, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	buildInfo := buildinfocmd.New()
+	buildInfo.SetAgentName(coreutils.GetCliUserAgentName())
+	buildInfo.SetAgentVersion(coreutils.GetCliUserAgentVersion())
+	buildInfo.SetBuildAgentVersion(coreutils.GetClientAgentVersion())
+	buildInfo.Name, buildInfo.Number = buildName, buildNumber
+	buildInfo.Started = generalDetails.Timestamp.Format(buildinfocmd.TimeFormat)
+	buildInfo.BuildUrl = config.BuildUrl
+
+	var modules []*buildinfocmd.Module
+	modulesById := map[string]*buildinfocmd.Module{}
+	artifactIndexes, dependencyIndexes := map[string]int{}, map[string]int{}
+	env := buildinfocmd.Env{}
+	issues := &buildinfocmd.Issues{}
+	issueIndexes := map[string]int{}
+	for _, partialFile := range partials {
+		partial := partialFile.partial
+		// As in 'build-publish', the partials are merged by their module IDs before the missing IDs are replaced by the build name.
+		// Partials without a module ID are therefore not merged with partials of a module named after the build.
+		moduleId := partial.ModuleId
+		module := modulesById[moduleId]
+		if module == nil {
+			module = &buildinfocmd.Module{Id: moduleId, Type: partial.ModuleType, Properties: map[string][]string{}, Artifacts: []buildinfocmd.Artifact{}, Dependencies: []buildinfocmd.Dependency{}}
+			modulesById[moduleId] = module
+			modules = append(modules, module)
+		}
+		switch {
+		case partial.Artifacts != nil:
+			// Artifacts and dependencies collected more than once are kept once, as in 'build-publish'.
+			for _, artifact := range partial.Artifacts {
+				key := fmt.Sprintf("%s-%s-%s-%s", moduleId, artifact.Name, getSha1(artifact.Checksum), getMd5(artifact.Checksum))
+				if index, exists := artifactIndexes[key]; exists {
+					module.Artifacts[index] = artifact
+					continue
+				}
+				artifactIndexes[key] = len(module.Artifacts)
+				module.Artifacts = append(module.Artifacts, artifact)
+			}
+		case partial.Dependencies != nil:
+			for _, dependency := range partial.Dependencies {
+				key := fmt.Sprintf("%s-%s-%s-%s-%s", moduleId, dependency.Id, getSha1(dependency.Checksum), getMd5(dependency.Checksum), dependency.Scopes)
+				if index, exists := dependencyIndexes[key]; exists {
+					module.Dependencies[index] = dependency
+					continue
+				}
+				dependencyIndexes[key] = len(module.Dependencies)
+				module.Dependencies = append(module.Dependencies, dependency)
+			}
+		case partial.VcsList != nil:
+			buildInfo.VcsList = append(buildInfo.VcsList, partial.VcsList...)
+			if partial.Issues == nil {
+				continue
+			}
+			// The tracker is of the latest partial, and the affected issues of all partials are kept once by their keys.
+			issues.Tracker, issues.AggregateBuildIssues, issues.AggregationBuildStatus = partial.Issues.Tracker, partial.Issues.AggregateBuildIssues, partial.Issues.AggregationBuildStatus
+			for _, issue := range partial.Issues.AffectedIssues {
+				if index, exists := issueIndexes[issue.Key]; exists {
+					issues.AffectedIssues[index] = issue
+					continue
+				}
+				issueIndexes[issue.Key] = len(issues.AffectedIssues)
+				issues.AffectedIssues = append(issues.AffectedIssues, issue)
+			}
+		case partial.Env != nil:
+			filteredEnv, err := config.IncludeFilter()(partial.Env)
+			if errorutils.CheckError(err) != nil {
+				return nil, err
+			}
+			if filteredEnv, err = config.ExcludeFilter()(filteredEnv); errorutils.CheckError(err) != nil {
+				return nil, err
+			}
+			for key, value := range filteredEnv {
+				env[key] = value
+			}
+		case partial.ModuleType == buildinfocmd.Build:
+			module.Checksum = partial.Checksum
+		}
+	}
+	for _, module := range modules {
+		if module.Id == "" {
+			module.Id = buildName
+		}
+		buildInfo.Modules = append(buildInfo.Modules, *module)
+	}
+	if len(env) > 0 {
+		buildInfo.Properties = env
+	}
+	if issues.Tracker != nil && issues.Tracker.Name != "" {
+		buildInfo.Issues = issues
+	}
+	// The build-info files generated by the build tools, such as docker, are added as in 'build-publish'.
+	generatedBuildsInfo, err := utils.GetGeneratedBuildsInfo(buildName, buildNumber, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	for _, generatedBuildInfo := range generatedBuildsInfo {
+		buildInfo.Append(generatedBuildInfo)
+	}
+	return buildInfo, nil
+}
+
+// Partials which don't belong to a module, such as the collected environment variables, belong to the module named after the build.
+func getPartialModuleId(buildName string, partial *buildinfocmd.Partial) string {
+	if partial.ModuleId == "" {
+		return buildName
+	}
+	return partial.ModuleId
+}
+
+func getMd5(checksum *buildinfocmd.Checksum) string {
+	if checksum == nil {
+		return ""
+	}
+	return checksum.Md5
+}
@@ -0,0 +1,137 @@
+package artifactory
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShowAndEditCollectedBuildInfo(t *testing.T) {
+	buildConfiguration := &utils.BuildConfiguration{BuildName: "build-show-test-" + strconv.FormatInt(time.Now().UnixNano(), 10), BuildNumber: "1"}
+	buildName, buildNumber := buildConfiguration.BuildName, buildConfiguration.BuildNumber
+	defer utils.RemoveBuildDir(buildName, buildNumber, "")
+
+	// Nothing was collected yet.
+	_, err := readPartialFiles(buildConfiguration)
+	assert.EqualError(t, err, "no build-info was collected for build "+buildName+"/1")
+
+	assert.NoError(t, utils.SaveBuildGeneralDetails(buildName, buildNumber, ""))
+	savePartial := func(timestamp int64, populate func(partial *buildinfocmd.Partial)) {
+		assert.NoError(t, utils.SavePartialBuildInfo(buildName, buildNumber, "", func(partial *buildinfocmd.Partial) {
+			partial.Timestamp = timestamp
+			populate(partial)
+		}))
+	}
+	savePartial(1, func(partial *buildinfocmd.Partial) {
+		partial.ModuleId, partial.ModuleType = "app", buildinfocmd.Generic
+		partial.Artifacts = []buildinfocmd.Artifact{{Name: "app.zip", Checksum: &buildinfocmd.Checksum{Sha1: "a1"}}}
+	})
+	savePartial(2, func(partial *buildinfocmd.Partial) {
+		partial.ModuleId, partial.ModuleType = "app", buildinfocmd.Generic
+		partial.Dependencies = []buildinfocmd.Dependency{{Id: "lib.zip", Checksum: &buildinfocmd.Checksum{Sha1: "l1"}}}
+	})
+	savePartial(3, func(partial *buildinfocmd.Partial) {
+		partial.ModuleId, partial.ModuleType = "broken", buildinfocmd.Generic
+		partial.Artifacts = []buildinfocmd.Artifact{{Name: "broken.zip", Checksum: &buildinfocmd.Checksum{Sha1: "b1"}}}
+	})
+	// The same artifact, uploaded again, is kept once.
+	savePartial(4, func(partial *buildinfocmd.Partial) {
+		partial.ModuleId, partial.ModuleType = "app", buildinfocmd.Generic
+		partial.Artifacts = []buildinfocmd.Artifact{{Name: "app.zip", Checksum: &buildinfocmd.Checksum{Sha1: "a1"}}}
+	})
+	savePartial(5, func(partial *buildinfocmd.Partial) {
+		partial.Env = buildinfocmd.Env{"buildInfo.env.BRANCH": "feature", "buildInfo.env.API_TOKEN": "secret"}
+	})
+	savePartial(6, func(partial *buildinfocmd.Partial) {
+		partial.VcsList = []buildinfocmd.Vcs{{Url: "https://github.com/acme/app.git", Revision: "abc"}}
+	})
+
+	config := &buildinfocmd.Configuration{EnvInclude: "*", EnvExclude: "*token*"}
+	partials, err := readPartialFiles(buildConfiguration)
+	assert.NoError(t, err)
+	buildInfo, err := aggregatePartials(buildConfiguration, partials, config)
+	assert.NoError(t, err)
+	assert.Equal(t, buildName, buildInfo.Name)
+	assert.NotEmpty(t, buildInfo.Started)
+	if assert.Len(t, buildInfo.Modules, 3) {
+		assert.Equal(t, "app", buildInfo.Modules[0].Id)
+		assert.Len(t, buildInfo.Modules[0].Artifacts, 1)
+		assert.Len(t, buildInfo.Modules[0].Dependencies, 1)
+		assert.Equal(t, "broken", buildInfo.Modules[1].Id)
+		// The environment variables and VCS details don't belong to a module, as in 'build-publish'.
+		assert.Equal(t, buildName, buildInfo.Modules[2].Id)
+	}
+	assert.Equal(t, buildinfocmd.Env{"buildInfo.env.BRANCH": "feature"}, buildInfo.Properties)
+	assert.Equal(t, []buildinfocmd.Vcs{{Url: "https://github.com/acme/app.git", Revision: "abc"}}, buildInfo.VcsList)
+
+	// Remove a module and override a property.
+	assert.NoError(t, removeModulePartials(buildConfiguration, partials, "broken"))
+	assert.EqualError(t, removeModulePartials(buildConfiguration, partials[:0], "missing"), "module 'missing' was not found in the build-info of build "+buildName+"/1")
+	props, err := parseBuildProperties("BRANCH=main;buildInfo.env.RELEASE=true")
+	assert.NoError(t, err)
+	savePartial(7, func(partial *buildinfocmd.Partial) {
+		partial.Env = props
+	})
+
+	partials, err = readPartialFiles(buildConfiguration)
+	assert.NoError(t, err)
+	buildInfo, err = aggregatePartials(buildConfiguration, partials, config)
+	assert.NoError(t, err)
+	if assert.Len(t, buildInfo.Modules, 2) {
+		assert.Equal(t, "app", buildInfo.Modules[0].Id)
+		assert.Equal(t, buildName, buildInfo.Modules[1].Id)
+	}
+	assert.Equal(t, buildinfocmd.Env{"buildInfo.env.BRANCH": "main", "buildInfo.env.RELEASE": "true"}, buildInfo.Properties)
+}
+
+func TestAggregatePartialsModuleIds(t *testing.T) {
+	buildConfiguration := &utils.BuildConfiguration{BuildName: "build-show-test-" + strconv.FormatInt(time.Now().UnixNano(), 10), BuildNumber: "1"}
+	buildName, buildNumber := buildConfiguration.BuildName, buildConfiguration.BuildNumber
+	defer utils.RemoveBuildDir(buildName, buildNumber, "")
+	assert.NoError(t, utils.SaveBuildGeneralDetails(buildName, buildNumber, ""))
+	assert.NoError(t, utils.SavePartialBuildInfo(buildName, buildNumber, "", func(partial *buildinfocmd.Partial) {
+		partial.Timestamp, partial.ModuleId, partial.ModuleType = 1, buildName, buildinfocmd.Generic
+		partial.Artifacts = []buildinfocmd.Artifact{{Name: "app.zip", Checksum: &buildinfocmd.Checksum{Sha1: "a1"}}}
+	}))
+	assert.NoError(t, utils.SavePartialBuildInfo(buildName, buildNumber, "", func(partial *buildinfocmd.Partial) {
+		partial.Timestamp = 2
+		partial.Env = buildinfocmd.Env{"buildInfo.env.BRANCH": "main"}
+	}))
+
+	// As in 'build-publish', a module named after the build is not merged with the partials which have no module.
+	partials, err := readPartialFiles(buildConfiguration)
+	assert.NoError(t, err)
+	buildInfo, err := aggregatePartials(buildConfiguration, partials, &buildinfocmd.Configuration{EnvInclude: "*"})
+	assert.NoError(t, err)
+	if assert.Len(t, buildInfo.Modules, 2) {
+		assert.Equal(t, buildName, buildInfo.Modules[0].Id)
+		assert.Len(t, buildInfo.Modules[0].Artifacts, 1)
+		assert.Equal(t, buildName, buildInfo.Modules[1].Id)
+		assert.Empty(t, buildInfo.Modules[1].Artifacts)
+	}
+}
+
+func TestGetExcludedBuildProperties(t *testing.T) {
+	props, err := parseBuildProperties("release.key=1;Api_Token=x;branch=main")
+	assert.NoError(t, err)
+	excludedProps, err := getExcludedBuildProperties(props, defaultEnvExclude)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Api_Token", "release.key"}, excludedProps)
+
+	excludedProps, err = getExcludedBuildProperties(props, "*secret*")
+	assert.NoError(t, err)
+	assert.Empty(t, excludedProps)
+}
+
+func TestParseBuildProperties(t *testing.T) {
+	props, err := parseBuildProperties("a=1;b=x=y;;")
+	assert.NoError(t, err)
+	assert.Equal(t, buildinfocmd.Env{"buildInfo.env.a": "1", "buildInfo.env.b": "x=y"}, props)
+
+	_, err = parseBuildProperties("a=1;b")
+	assert.EqualError(t, err, "invalid property 'b'. Properties are expected in the form of key1=value1;key2=value2")
+}
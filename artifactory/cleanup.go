@@ -0,0 +1,309 @@
+package artifactory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+// A cleanup policy file, holding the rules which select the files to delete.
+type cleanupPolicy struct {
+	Rules []*cleanupRule `yaml:"rules"`
+}
+
+// A rule selects the files of the pattern which match all of its conditions.
+// Files with the exclude properties, such as 'keep=true', are never selected.
+type cleanupRule struct {
+	Name         string `yaml:"name"`
+	Repo         string `yaml:"repo"`
+	Pattern      string `yaml:"pattern"`
+	Props        string `yaml:"props"`
+	ExcludeProps string `yaml:"excludeProps"`
+	// Selects the files of all versions of each package, except for the last versions.
+	// The folder of a file is its version, and the parent of this folder is its package.
+	KeepLastVersions int `yaml:"keepLastVersions"`
+	// Selects the files which were not downloaded in the last days, or which were never downloaded and created before them.
+	NotDownloadedDays int `yaml:"notDownloadedDays"`
+}
+
+type cleanupReport struct {
+	summary.Summary
+	DryRun bool `json:"dryRun"`
+	// The total size of the selected files, counting each file once.
+	ReclaimedSize int64                `json:"reclaimedSize"`
+	Rules         []*cleanupRuleReport `json:"rules"`
+}
+
+type cleanupRuleReport struct {
+	Name  string `json:"name"`
+	Files int    `json:"files"`
+	Size  int64  `json:"size"`
+}
+
+func readCleanupPolicy(policyPath string) (*cleanupPolicy, error) {
+	policyContent, err := ioutil.ReadFile(policyPath)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	policy := new(cleanupPolicy)
+	if err = yaml.UnmarshalStrict(policyContent, policy); err != nil {
+		return nil, errorutils.CheckError(errors.New("failed parsing the cleanup policy " + policyPath + ": " + err.Error()))
+	}
+	if len(policy.Rules) == 0 {
+		return nil, errorutils.CheckError(errors.New("the cleanup policy " + policyPath + " has no rules"))
+	}
+	for i, rule := range policy.Rules {
+		if rule.Name == "" {
+			rule.Name = "rule-" + strconv.Itoa(i+1)
+		}
+		if err = rule.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return policy, nil
+}
+
+func (rule *cleanupRule) validate() error {
+	var err error
+	switch {
+	case rule.Repo == "" || strings.Contains(rule.Repo, "/"):
+		err = errors.New("the 'repo' of the cleanup rule '" + rule.Name + "' should be a repository name")
+	case rule.KeepLastVersions < 0 || rule.NotDownloadedDays < 0:
+		err = errors.New("the conditions of the cleanup rule '" + rule.Name + "' cannot be negative")
+	// Without conditions, a rule would delete all of the files of its pattern.
+	case rule.KeepLastVersions == 0 && rule.NotDownloadedDays == 0:
+		err = errors.New("the cleanup rule '" + rule.Name + "' has no conditions. Set 'keepLastVersions', 'notDownloadedDays' or both")
+	}
+	return errorutils.CheckError(err)
+}
+
+func (rule *cleanupRule) getSpec() *spec.SpecFiles {
+	pattern := strings.TrimPrefix(rule.Pattern, "/")
+	if pattern == "" {
+		pattern = "*"
+	}
+	return spec.NewBuilder().Pattern(rule.Repo + "/" + pattern).Props(rule.Props).ExcludeProps(rule.ExcludeProps).Recursive(true).BuildSpec()
+}
+
+func cleanupCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if c.String("policy") == "" {
+		return cliutils.PrintHelpAndReturnError("The --policy option is mandatory.", c)
+	}
+	policy, err := readCleanupPolicy(c.String("policy"))
+	if err != nil {
+		return err
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	threads, err := getThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	dryRun := c.Bool("dry-run")
+	serviceManager, err := utils.CreateServiceManagerWithThreads(rtDetails, dryRun, threads, retries)
+	if err != nil {
+		return err
+	}
+
+	report := &cleanupReport{Summary: *summary.GetSummaryReport(0, 0, nil), DryRun: dryRun}
+	files := map[string]*serviceutils.ResultItem{}
+	now := time.Now()
+	for _, rule := range policy.Rules {
+		log.Info("Evaluating the cleanup rule '" + rule.Name + "'...")
+		selected, err := evaluateCleanupRule(serviceManager, rule, now)
+		if err != nil {
+			return err
+		}
+		ruleReport := &cleanupRuleReport{Name: rule.Name, Files: len(selected)}
+		for _, item := range selected {
+			ruleReport.Size += item.Size
+			files[item.GetItemRelativePath()] = item
+		}
+		report.Rules = append(report.Rules, ruleReport)
+	}
+	for _, item := range files {
+		report.ReclaimedSize += item.Size
+	}
+
+	if len(files) > 0 && !dryRun && !cliutils.GetQuietValue(c) &&
+		!coreutils.AskYesNo(fmt.Sprintf("The cleanup policy permanently deletes %d files, of %d bytes. Are you sure you want to continue?\n"+
+			"You can avoid this confirmation message by adding --quiet to the command.", len(files), report.ReclaimedSize), false) {
+		return nil
+	}
+	success, err := deleteCleanupFiles(serviceManager, files)
+	report.Totals.Success, report.Totals.Failure = success, len(files)-success
+	if err != nil || report.Totals.Failure > 0 {
+		report.Status = summary.Failure
+	}
+	reportContent, mErr := json.Marshal(report)
+	if errorutils.CheckError(mErr) != nil {
+		return mErr
+	}
+	log.Output(clientutils.IndentJson(reportContent))
+	return cliutils.GetCliError(err, report.Totals.Success, report.Totals.Failure, isFailNoOp(c))
+}
+
+// Returns the files selected by the rule.
+func evaluateCleanupRule(serviceManager artifactory.ArtifactoryServicesManager, rule *cleanupRule, now time.Time) ([]*serviceutils.ResultItem, error) {
+	items, err := searchCleanupRuleFiles(serviceManager, rule)
+	if err != nil {
+		return nil, err
+	}
+	if rule.KeepLastVersions > 0 {
+		items = filterKeptVersions(items, rule.KeepLastVersions)
+	}
+	if rule.NotDownloadedDays > 0 && len(items) > 0 {
+		var paths []string
+		for _, item := range items {
+			paths = append(paths, item.GetItemRelativePath())
+		}
+		stats, err := getAqlItemsByPaths(serviceManager, paths, "stat.downloaded")
+		if err != nil {
+			return nil, err
+		}
+		items = filterRecentlyDownloaded(items, stats, now.AddDate(0, 0, -rule.NotDownloadedDays))
+	}
+	return items, nil
+}
+
+func searchCleanupRuleFiles(serviceManager artifactory.ArtifactoryServicesManager, rule *cleanupRule) ([]*serviceutils.ResultItem, error) {
+	params, err := rule.getSpec().Get(0).ToArtifactoryCommonParams()
+	if err != nil {
+		return nil, err
+	}
+	params.Recursive = true
+	reader, err := serviceManager.SearchFiles(services.SearchParams{ArtifactoryCommonParams: params})
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	var items []*serviceutils.ResultItem
+	for item := new(serviceutils.ResultItem); reader.NextRecord(item) == nil; item = new(serviceutils.ResultItem) {
+		if item.Type != "folder" {
+			items = append(items, item)
+		}
+	}
+	return items, reader.GetError()
+}
+
+// Removes the files of the last versions of each package.
+// Versions are ordered by the creation time of their newest file.
+// Files which are directly under the repository have no version, and are kept.
+func filterKeptVersions(items []*serviceutils.ResultItem, keepLastVersions int) []*serviceutils.ResultItem {
+	type version struct {
+		dir     string
+		created time.Time
+	}
+	versions := map[string]*version{}
+	packages := map[string][]*version{}
+	for _, item := range items {
+		if item.Path == "." {
+			continue
+		}
+		versionDir := item.Repo + "/" + item.Path
+		created, _ := time.Parse(time.RFC3339, item.Created)
+		itemVersion, exists := versions[versionDir]
+		if !exists {
+			itemVersion = &version{dir: versionDir}
+			versions[versionDir] = itemVersion
+			packageDir := versionDir[:strings.LastIndex(versionDir, "/")]
+			packages[packageDir] = append(packages[packageDir], itemVersion)
+		}
+		if created.After(itemVersion.created) {
+			itemVersion.created = created
+		}
+	}
+	keptVersions := map[string]bool{}
+	for _, packageVersions := range packages {
+		sort.Slice(packageVersions, func(i, j int) bool {
+			if !packageVersions[i].created.Equal(packageVersions[j].created) {
+				return packageVersions[i].created.After(packageVersions[j].created)
+			}
+			return packageVersions[i].dir > packageVersions[j].dir
+		})
+		for i := 0; i < keepLastVersions && i < len(packageVersions); i++ {
+			keptVersions[packageVersions[i].dir] = true
+		}
+	}
+	var selected []*serviceutils.ResultItem
+	for _, item := range items {
+		if item.Path != "." && !keptVersions[item.Repo+"/"+item.Path] {
+			selected = append(selected, item)
+		}
+	}
+	return selected
+}
+
+// Removes the files which were downloaded or created after the given time.
+func filterRecentlyDownloaded(items []*serviceutils.ResultItem, stats map[string]*aqlItem, notDownloadedSince time.Time) []*serviceutils.ResultItem {
+	var selected []*serviceutils.ResultItem
+	for _, item := range items {
+		lastUsed := item.Created
+		if stat, exists := stats[item.GetItemRelativePath()]; exists && len(stat.Stats) > 0 && stat.Stats[0].Downloaded != "" {
+			lastUsed = stat.Stats[0].Downloaded
+		}
+		lastUsedTime, err := time.Parse(time.RFC3339, lastUsed)
+		if err != nil {
+			log.Debug("Skipping " + item.GetItemRelativePath() + ", since its last usage time is unknown: " + err.Error())
+			continue
+		}
+		if lastUsedTime.Before(notDownloadedSince) {
+			selected = append(selected, item)
+		}
+	}
+	return selected
+}
+
+// Deletes the files, or only logs them in a dry run. Returns the number of deleted files.
+func deleteCleanupFiles(serviceManager artifactory.ArtifactoryServicesManager, files map[string]*serviceutils.ResultItem) (int, error) {
+	if len(files) == 0 {
+		return 0, nil
+	}
+	paths := make([]string, 0, len(files))
+	for itemPath := range files {
+		paths = append(paths, itemPath)
+	}
+	sort.Strings(paths)
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return 0, err
+	}
+	for _, itemPath := range paths {
+		writer.Write(*files[itemPath])
+	}
+	if err = writer.Close(); err != nil {
+		return 0, err
+	}
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer reader.Close()
+	return serviceManager.DeleteFiles(reader)
+}
@@ -0,0 +1,99 @@
+package artifactory
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadCleanupPolicy(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	tests := []struct {
+		name          string
+		policy        string
+		expectedError string
+	}{
+		{"valid", "rules:\n  - repo: libs\n    keepLastVersions: 3\n  - name: unused\n    repo: snapshots\n    pattern: a/*\n    notDownloadedDays: 90\n", ""},
+		{"noRules", "rules: []\n", "has no rules"},
+		{"unknownField", "rules:\n  - repo: libs\n    keepLast: 3\n", "failed parsing the cleanup policy"},
+		{"noRepo", "rules:\n  - keepLastVersions: 3\n", "the 'repo' of the cleanup rule 'rule-1' should be a repository name"},
+		{"noConditions", "rules:\n  - repo: libs\n", "the cleanup rule 'rule-1' has no conditions. Set 'keepLastVersions', 'notDownloadedDays' or both"},
+		{"negative", "rules:\n  - repo: libs\n    notDownloadedDays: -1\n", "the conditions of the cleanup rule 'rule-1' cannot be negative"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policyPath := filepath.Join(tmpDir, test.name+".yaml")
+			assert.NoError(t, ioutil.WriteFile(policyPath, []byte(test.policy), 0600))
+			policy, err := readCleanupPolicy(policyPath)
+			if test.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), test.expectedError)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, []*cleanupRule{
+				{Name: "rule-1", Repo: "libs", KeepLastVersions: 3},
+				{Name: "unused", Repo: "snapshots", Pattern: "a/*", NotDownloadedDays: 90},
+			}, policy.Rules)
+			assert.Equal(t, "libs/*", policy.Rules[0].getSpec().Get(0).Pattern)
+			assert.Equal(t, "snapshots/a/*", policy.Rules[1].getSpec().Get(0).Pattern)
+		})
+	}
+}
+
+func TestFilterKeptVersions(t *testing.T) {
+	items := []*serviceutils.ResultItem{
+		{Repo: "libs", Path: "org/app/1.0", Name: "app-1.0.jar", Created: "2021-01-01T10:00:00.000Z"},
+		{Repo: "libs", Path: "org/app/1.0", Name: "app-1.0.pom", Created: "2021-01-01T10:00:00.000Z"},
+		// The newest file of the version determines its time.
+		{Repo: "libs", Path: "org/app/1.1", Name: "app-1.1.jar", Created: "2021-02-01T10:00:00.000Z"},
+		{Repo: "libs", Path: "org/app/1.1", Name: "app-1.1.pom", Created: "2021-04-01T10:00:00.000Z"},
+		{Repo: "libs", Path: "org/app/1.2", Name: "app-1.2.jar", Created: "2021-03-01T10:00:00.000+02:00"},
+		{Repo: "libs", Path: "org/lib/1.0", Name: "lib-1.0.jar", Created: "2020-01-01T10:00:00.000Z"},
+		{Repo: "libs", Path: ".", Name: "index.html", Created: "2020-01-01T10:00:00.000Z"},
+	}
+	var paths []string
+	for _, item := range filterKeptVersions(items, 2) {
+		paths = append(paths, item.GetItemRelativePath())
+	}
+	assert.Equal(t, []string{"libs/org/app/1.0/app-1.0.jar", "libs/org/app/1.0/app-1.0.pom"}, paths)
+	assert.Empty(t, filterKeptVersions(items, 3))
+}
+
+func TestFilterRecentlyDownloaded(t *testing.T) {
+	items := []*serviceutils.ResultItem{
+		{Repo: "libs", Path: "a", Name: "downloaded-recently", Created: "2020-01-01T10:00:00.000Z"},
+		{Repo: "libs", Path: "a", Name: "downloaded-long-ago", Created: "2020-01-01T10:00:00.000Z"},
+		{Repo: "libs", Path: "a", Name: "never-downloaded-old", Created: "2020-01-01T10:00:00.000Z"},
+		{Repo: "libs", Path: "a", Name: "never-downloaded-new", Created: "2021-03-25T10:00:00.000Z"},
+	}
+	stats := map[string]*aqlItem{
+		"libs/a/downloaded-recently":  {Stats: []aqlItemStat{{Downloaded: "2021-03-20T10:00:00.000Z"}}},
+		"libs/a/downloaded-long-ago":  {Stats: []aqlItemStat{{Downloaded: "2020-06-01T10:00:00.000Z"}}},
+		"libs/a/never-downloaded-old": {},
+	}
+	notDownloadedSince, err := time.Parse(time.RFC3339, "2021-03-01T00:00:00Z")
+	assert.NoError(t, err)
+	var names []string
+	for _, item := range filterRecentlyDownloaded(items, stats, notDownloadedSince) {
+		names = append(names, item.Name)
+	}
+	assert.Equal(t, []string{"downloaded-long-ago", "never-downloaded-old"}, names)
+}
+
+func TestCreateAqlItemsQuery(t *testing.T) {
+	query, err := createAqlItemsQuery([]string{"repo/file", "repo/a/b/file"}, []string{"stat.downloaded"})
+	assert.NoError(t, err)
+	assert.Equal(t, `items.find({"$or":[{"name":"file","path":".","repo":"repo"},{"name":"file","path":"a/b","repo":"repo"}]}).include("repo","path","name","stat.downloaded")`, query)
+}
@@ -35,11 +35,14 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/permissiontargetupdate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/podmanpull"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/podmanpush"
+	syncdocs "github.com/jfrog/jfrog-cli/docs/artifactory/sync"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/usercreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/userscreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/usersdelete"
+	"github.com/jfrog/jfrog-cli/utils/attestation"
 	logUtils "github.com/jfrog/jfrog-cli/utils/log"
 	"github.com/jfrog/jfrog-cli/utils/progressbar"
+	"github.com/jfrog/jfrog-cli/utils/sbom"
 	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
 	"github.com/jszwec/csvutil"
 
@@ -68,16 +71,24 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildappend"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildclean"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildcollectenv"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/builddiff"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/builddiscard"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/builddistribute"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/buildedit"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/buildexport"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/buildimport"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildpromote"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildpublish"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/buildsbom"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildscan"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/buildshow"
+	cleanupdocs "github.com/jfrog/jfrog-cli/docs/artifactory/cleanup"
 	configdocs "github.com/jfrog/jfrog-cli/docs/artifactory/config"
 	copydocs "github.com/jfrog/jfrog-cli/docs/artifactory/copy"
 	curldocs "github.com/jfrog/jfrog-cli/docs/artifactory/curl"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/delete"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/deleteprops"
+	diffdocs "github.com/jfrog/jfrog-cli/docs/artifactory/diff"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/dockerpromote"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/dockerpull"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/dockerpush"
@@ -112,18 +123,24 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/repodelete"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/repotemplate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/repoupdate"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/restore"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/search"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/setprops"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/trashpurge"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/upload"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/use"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/downloadcache"
+	"github.com/jfrog/jfrog-cli/utils/report"
 	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	distributionServices "github.com/jfrog/jfrog-client-go/distribution/services"
 	distributionServicesUtils "github.com/jfrog/jfrog-client-go/distribution/services/utils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
@@ -218,6 +235,59 @@ func GetCommands() []cli.Command {
 				return deleteCmd(c)
 			},
 		},
+		{
+			Name:         "restore",
+			Flags:        cliutils.GetCommandFlags(cliutils.Restore),
+			Description:  restore.Description,
+			HelpName:     corecommon.CreateUsage("rt restore", restore.Description, restore.Usage),
+			UsageText:    restore.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return restoreCmd(c)
+			},
+		},
+		{
+			Name:        "trash",
+			Description: "Trash commands.",
+			Subcommands: []cli.Command{
+				{
+					Name:         "purge",
+					Flags:        cliutils.GetCommandFlags(cliutils.TrashPurge),
+					Description:  trashpurge.Description,
+					HelpName:     corecommon.CreateUsage("rt trash purge", trashpurge.Description, trashpurge.Usage),
+					ArgsUsage:    common.CreateEnvVars(),
+					BashComplete: corecommon.CreateBashCompletionFunc(),
+					Action: func(c *cli.Context) error {
+						return trashPurgeCmd(c)
+					},
+				},
+			},
+		},
+		{
+			Name:         "diff",
+			Flags:        cliutils.GetCommandFlags(cliutils.Diff),
+			Description:  diffdocs.Description,
+			HelpName:     corecommon.CreateUsage("rt diff", diffdocs.Description, diffdocs.Usage),
+			UsageText:    diffdocs.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return diffCmd(c)
+			},
+		},
+		{
+			Name:         "sync",
+			Flags:        cliutils.GetCommandFlags(cliutils.Sync),
+			Description:  syncdocs.Description,
+			HelpName:     corecommon.CreateUsage("rt sync", syncdocs.Description, syncdocs.Usage),
+			UsageText:    syncdocs.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return syncCmd(c)
+			},
+		},
 		{
 			Name:         "search",
 			Flags:        cliutils.GetCommandFlags(cliutils.Search),
@@ -296,6 +366,78 @@ func GetCommands() []cli.Command {
 				return buildAppendCmd(c)
 			},
 		},
+		{
+			Name:         "build-diff",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildDiff),
+			Description:  builddiff.Description,
+			HelpName:     corecommon.CreateUsage("rt build-diff", builddiff.Description, builddiff.Usage),
+			UsageText:    builddiff.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return buildDiffCmd(c)
+			},
+		},
+		{
+			Name:         "build-sbom",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildSbom),
+			Description:  buildsbom.Description,
+			HelpName:     corecommon.CreateUsage("rt build-sbom", buildsbom.Description, buildsbom.Usage),
+			UsageText:    buildsbom.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return buildSbomCmd(c)
+			},
+		},
+		{
+			Name:         "build-show",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildShow),
+			Description:  buildshow.Description,
+			HelpName:     corecommon.CreateUsage("rt build-show", buildshow.Description, buildshow.Usage),
+			UsageText:    buildshow.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return buildShowCmd(c)
+			},
+		},
+		{
+			Name:         "build-edit",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildEdit),
+			Description:  buildedit.Description,
+			HelpName:     corecommon.CreateUsage("rt build-edit", buildedit.Description, buildedit.Usage),
+			UsageText:    buildedit.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return buildEditCmd(c)
+			},
+		},
+		{
+			Name:         "build-export",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildExport),
+			Description:  buildexport.Description,
+			HelpName:     corecommon.CreateUsage("rt build-export", buildexport.Description, buildexport.Usage),
+			UsageText:    buildexport.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return buildExportCmd(c)
+			},
+		},
+		{
+			Name:         "build-import",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildImport),
+			Description:  buildimport.Description,
+			HelpName:     corecommon.CreateUsage("rt build-import", buildimport.Description, buildimport.Usage),
+			UsageText:    buildimport.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return buildImportCmd(c)
+			},
+		},
 		{
 			Name:         "build-add-dependencies",
 			Flags:        cliutils.GetCommandFlags(cliutils.BuildAddDependencies),
@@ -399,6 +541,18 @@ func GetCommands() []cli.Command {
 				return gitLfsCleanCmd(c)
 			},
 		},
+		{
+			Name:         "cleanup",
+			Flags:        cliutils.GetCommandFlags(cliutils.Cleanup),
+			Description:  cleanupdocs.Description,
+			HelpName:     corecommon.CreateUsage("rt cleanup", cleanupdocs.Description, cleanupdocs.Usage),
+			UsageText:    cleanupdocs.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return cleanupCmd(c)
+			},
+		},
 		{
 			Name:         "mvn-config",
 			Aliases:      []string{"mvnc"},
@@ -2082,16 +2236,57 @@ func downloadCmd(c *cli.Context) error {
 		return err
 	}
 	downloadCommand := generic.NewDownloadCommand()
-	downloadCommand.SetConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(downloadSpec).SetServerDetails(serverDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(c.Bool("detailed-summary")).SetRetries(retries)
+	commandReport, err := createReport(c, "rt download")
+	if err != nil {
+		return err
+	}
+	downloadCommand.SetConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(downloadSpec).SetServerDetails(serverDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(c.Bool("detailed-summary") || commandReport != nil).SetRetries(retries)
 
 	if downloadCommand.ShouldPrompt() && !coreutils.AskYesNo("Sync-deletes may delete some files in your local file system. Are you sure you want to continue?\n"+
 		"You can avoid this confirmation message by adding --quiet to the command.", false) {
 		return nil
 	}
 
+	var cache *downloadcache.Cache
+	resume := false
+	if !c.Bool("dry-run") {
+		cache = downloadcache.GetCache()
+		resume = c.Bool("resume")
+	}
+	var uncachedFiles []*uncachedFile
+	if cache != nil || resume {
+		if resume {
+			if err = validateResumableDownload(configuration); err != nil {
+				return err
+			}
+		}
+		serviceManager, err := utils.CreateServiceManager(serverDetails, retries, false)
+		if err != nil {
+			return err
+		}
+		// The files are searched once for both the download cache and the resumable download.
+		downloadedFiles, err := searchDownloadedFiles(serviceManager, downloadSpec)
+		if err != nil {
+			return err
+		}
+		if cache != nil {
+			if uncachedFiles, err = restoreFromDownloadCache(cache, downloadedFiles); err != nil {
+				return err
+			}
+		}
+		if resume {
+			if err = runResumableSplitDownloads(serviceManager, downloadedFiles, configuration); err != nil {
+				return err
+			}
+		}
+	}
 	err = execWithProgress(downloadCommand)
+	if cache != nil {
+		storeInDownloadCache(cache, uncachedFiles)
+	}
 	result := downloadCommand.Result()
-	err = cliutils.PrintDetailedSummaryReport(result.SuccessCount(), result.FailCount(), result.Reader(), false, err)
+	err = writeTransferReport(commandReport, result, err)
+	err = cliutils.PrintDetailedSummaryReport(result.SuccessCount(), result.FailCount(), getDetailedSummaryReader(c, result), false, err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
@@ -2131,24 +2326,160 @@ func uploadCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	uploadCmd := generic.NewUploadCommand()
 	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
 	if err != nil {
 		return err
 	}
-	uploadCmd.SetUploadConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(uploadSpec).SetServerDetails(rtDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(c.Bool("detailed-summary")).SetRetries(retries)
-
-	if uploadCmd.ShouldPrompt() && !coreutils.AskYesNo("Sync-deletes may delete some artifacts in Artifactory. Are you sure you want to continue?\n"+
-		"You can avoid this confirmation message by adding --quiet to the command.", false) {
-		return nil
+	commandReport, err := createReport(c, "rt upload")
+	if err != nil {
+		return err
+	}
+	newUploadCommand := func(uploadSpec *spec.SpecFiles) *generic.UploadCommand {
+		uploadCmd := generic.NewUploadCommand()
+		uploadCmd.SetUploadConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(uploadSpec).SetServerDetails(rtDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(c.Bool("detailed-summary") || commandReport != nil).SetRetries(retries)
+		return uploadCmd
 	}
-	err = execWithProgress(uploadCmd)
-	result := uploadCmd.Result()
-	err = cliutils.PrintDetailedSummaryReport(result.SuccessCount(), result.FailCount(), result.Reader(), true, err)
+	var result *commandsutils.Result
+	if c.Bool("resume") && !c.Bool("dry-run") {
+		if c.String("sync-deletes") != "" {
+			return cliutils.PrintHelpAndReturnError("The --resume option cannot be used with the --sync-deletes option.", c)
+		}
+		result, err = runResumableUpload(uploadSpec, rtDetails, buildConfiguration, newUploadCommand)
+		if result == nil {
+			return err
+		}
+	} else {
+		uploadCmd := newUploadCommand(uploadSpec)
+		if uploadCmd.ShouldPrompt() && !coreutils.AskYesNo("Sync-deletes may delete some artifacts in Artifactory. Are you sure you want to continue?\n"+
+			"You can avoid this confirmation message by adding --quiet to the command.", false) {
+			return nil
+		}
+		err = execWithProgress(uploadCmd)
+		result = uploadCmd.Result()
+	}
+	err = writeTransferReport(commandReport, result, err)
+	err = cliutils.PrintDetailedSummaryReport(result.SuccessCount(), result.FailCount(), getDetailedSummaryReader(c, result), true, err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
 
+// Returns the report requested by the --report-file option, or nil if no report was requested.
+func createReport(c *cli.Context, command string) (*report.Report, error) {
+	if c.String("report-file") == "" {
+		return nil, nil
+	}
+	format, err := report.GetFormat(c.String("report-format"))
+	if err != nil {
+		return nil, err
+	}
+	return report.New(command, c.String("report-file"), format), nil
+}
+
+// Writes the transferred files of the upload or download command to the report, if a report was requested.
+func writeTransferReport(commandReport *report.Report, result *commandsutils.Result, originalErr error) error {
+	if commandReport == nil {
+		return originalErr
+	}
+	if err := commandReport.AddTransferDetails(result.Reader()); err != nil {
+		log.Error(err)
+	}
+	return writeReport(commandReport, result.FailCount(), originalErr)
+}
+
+// Writes the report with the command's failures.
+// Failing to write the report fails the command, unless the command has already failed.
+func writeReport(commandReport *report.Report, failed int, originalErr error) error {
+	commandReport.AddFailures(failed, originalErr)
+	return writeReportFile(commandReport, originalErr)
+}
+
+// Writes the report, whose cases already include the command's failures.
+func writeReportFile(commandReport *report.Report, originalErr error) error {
+	err := commandReport.Write()
+	if originalErr != nil {
+		if err != nil {
+			log.Error(err)
+		}
+		return originalErr
+	}
+	return err
+}
+
+// Returns the reader of the transferred files, if they should be included in the command summary.
+// The reader may exist only because a report was requested, in which case it is closed here.
+func getDetailedSummaryReader(c *cli.Context, result *commandsutils.Result) *content.ContentReader {
+	if c.Bool("detailed-summary") || result.Reader() == nil {
+		return result.Reader()
+	}
+	if err := result.Reader().Close(); err != nil {
+		log.Warn(err)
+	}
+	return nil
+}
+
+// A delete command which adds the status of each artifact to the report.
+type reportedDeleteCommand struct {
+	*generic.DeleteCommand
+	report *report.Report
+	// Set once the status of each artifact was added to the report.
+	filesReported bool
+}
+
+func (rdc *reportedDeleteCommand) Run() error {
+	reader, err := rdc.GetPathsToDelete()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if !rdc.Quiet() {
+		allowDelete, err := utils.ConfirmDelete(reader)
+		if err != nil || !allowDelete {
+			return err
+		}
+	}
+	success, failed, err := rdc.DeleteFiles(reader)
+	result := rdc.Result()
+	result.SetFailCount(failed)
+	result.SetSuccessCount(success)
+	if err != nil {
+		return err
+	}
+	// The delete results do not include the failed artifacts, so they are the artifacts which can still be found.
+	notDeleted := map[string]bool{}
+	if failed > 0 {
+		if notDeleted, err = rdc.getExistingPaths(); err != nil {
+			return err
+		}
+	}
+	reader.Reset()
+	for resultItem := new(serviceutils.ResultItem); reader.NextRecord(resultItem) == nil; resultItem = new(serviceutils.ResultItem) {
+		failure := ""
+		if notDeleted[resultItem.GetItemRelativePath()] {
+			failure = "failed deleting " + resultItem.GetItemRelativePath()
+		}
+		rdc.report.AddFileCase(resultItem.GetItemRelativePath(), failure)
+	}
+	if err = reader.GetError(); err != nil {
+		return err
+	}
+	rdc.filesReported = true
+	return nil
+}
+
+// Searches the paths to delete again, and returns the paths which were found.
+func (rdc *reportedDeleteCommand) getExistingPaths() (map[string]bool, error) {
+	reader, err := rdc.GetPathsToDelete()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	paths := map[string]bool{}
+	for resultItem := new(serviceutils.ResultItem); reader.NextRecord(resultItem) == nil; resultItem = new(serviceutils.ResultItem) {
+		paths[resultItem.GetItemRelativePath()] = true
+	}
+	return paths, reader.GetError()
+}
+
 type CommandWithProgress interface {
 	commands.Command
 	SetProgress(ioUtils.ProgressMgr)
@@ -2211,6 +2542,10 @@ func moveCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.Bool("atomic") && !c.Bool("dry-run") {
+		success, failed, err := runAtomicMoveCopy(services.MOVE, moveSpec, rtDetails, retries)
+		return cliutils.GetCliError(err, success, failed, isFailNoOp(c))
+	}
 	moveCmd.SetThreads(threads).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(moveSpec).SetRetries(retries)
 	err = commands.Exec(moveCmd)
 	result := moveCmd.Result()
@@ -2238,6 +2573,10 @@ func copyCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.Bool("atomic") && !c.Bool("dry-run") {
+		success, failed, err := runAtomicMoveCopy(services.COPY, copySpec, rtDetails, retries)
+		return cliutils.GetCliError(err, success, failed, isFailNoOp(c))
+	}
 	copyCommand.SetThreads(threads).SetSpec(copySpec).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetRetries(retries)
 	err = commands.Exec(copyCommand)
 	result := copyCommand.Result()
@@ -2276,6 +2615,12 @@ func deleteCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.Bool("soft") {
+		if c.String("report-file") != "" {
+			return cliutils.PrintHelpAndReturnError("The --soft option cannot be used together with the --report-file option.", c)
+		}
+		return softDeleteCmd(c, deleteSpec)
+	}
 
 	deleteCommand := generic.NewDeleteCommand()
 	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
@@ -2291,8 +2636,22 @@ func deleteCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	commandReport, err := createReport(c, "rt delete")
+	if err != nil {
+		return err
+	}
 	deleteCommand.SetThreads(threads).SetQuiet(cliutils.GetQuietValue(c)).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(deleteSpec).SetRetries(retries)
-	err = commands.Exec(deleteCommand)
+	if commandReport != nil {
+		reportedCommand := &reportedDeleteCommand{DeleteCommand: deleteCommand, report: commandReport}
+		err = commands.Exec(reportedCommand)
+		if reportedCommand.filesReported {
+			err = writeReportFile(commandReport, err)
+		} else {
+			err = writeReport(commandReport, deleteCommand.Result().FailCount(), err)
+		}
+	} else {
+		err = commands.Exec(deleteCommand)
+	}
 	result := deleteCommand.Result()
 	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
 
@@ -2337,6 +2696,17 @@ func searchCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	var aggregator *searchAggregator
+	if c.IsSet("group-by") {
+		if c.Bool("count") || c.IsSet("fields") || (c.IsSet("search-format") && c.String("search-format") != searchFormatJson) {
+			return cliutils.PrintHelpAndReturnError("The --group-by option cannot be used with the --count, --fields or --search-format options.", c)
+		}
+		if aggregator, err = newSearchAggregator(c.String("group-by"), c.String("aggregate")); err != nil {
+			return err
+		}
+	} else if c.IsSet("aggregate") {
+		return cliutils.PrintHelpAndReturnError("The --aggregate option can be used only with the --group-by option.", c)
+	}
 	searchCmd := generic.NewSearchCommand()
 	searchCmd.SetServerDetails(artDetails).SetSpec(searchSpec).SetRetries(retries)
 	err = commands.Exec(searchCmd)
@@ -2353,11 +2723,29 @@ func searchCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if !c.Bool("count") {
+	if c.Bool("count") {
+		log.Output(length)
+		return nil
+	}
+	if aggregator != nil {
+		return printSearchAggregates(reader, aggregator)
+	}
+	if c.String("search-format") == "" && c.String("fields") == "" {
 		return utils.PrintSearchResults(reader)
 	}
-	log.Output(length)
-	return nil
+	format := c.String("search-format")
+	if format == "" {
+		format = searchFormatJson
+	}
+	resultsWriter, err := newSearchResultsWriter(format, c.String("fields"), cliutils.GetOutputWriter())
+	if err != nil {
+		return err
+	}
+	serviceManager, err := utils.CreateServiceManager(artDetails, retries, false)
+	if err != nil {
+		return err
+	}
+	return writeSearchResults(reader, resultsWriter, serviceManager)
 }
 
 func preparePropsCmd(c *cli.Context) (*generic.PropsCommand, error) {
@@ -2451,6 +2839,39 @@ func buildPublishCmd(c *cli.Context) error {
 		return err
 	}
 	buildInfoConfiguration := createBuildInfoConfiguration(c)
+	sbomFormat, sbomFile := c.String("sbom"), c.String("sbom-file")
+	if sbomFormat != "" {
+		if buildInfoConfiguration.DryRun {
+			return cliutils.PrintHelpAndReturnError("The --sbom option cannot be used with the --dry-run option.", c)
+		}
+		if err := sbom.ValidateFormat(sbomFormat); err != nil {
+			return err
+		}
+		if sbomFile == "" {
+			sbomFile = buildConfiguration.BuildName + "-" + buildConfiguration.BuildNumber + sbom.GetFileExtension(sbomFormat)
+		}
+		// The SBOM file is checked before publishing, so that a wrong path doesn't fail the command after the build was published.
+		if err := validateSbomFile(sbomFile); err != nil {
+			return err
+		}
+	}
+	var signer *attestation.Signer
+	if c.Bool("attest") {
+		if buildInfoConfiguration.DryRun {
+			return cliutils.PrintHelpAndReturnError("The --attest option cannot be used with the --dry-run option.", c)
+		}
+		if c.String("attest-key") == "" || c.String("attest-repo") == "" {
+			return cliutils.PrintHelpAndReturnError("The --attest-key and --attest-repo options are mandatory when the --attest option is used.", c)
+		}
+		if buildInfoConfiguration.BuildUrl == "" {
+			return cliutils.PrintHelpAndReturnError("The --attest option requires the build URL, which identifies the builder of the build. Set it with the --build-url option or the JFROG_CLI_BUILD_URL environment variable.", c)
+		}
+		// The key is loaded before publishing, so that a wrong key doesn't leave a published build without its provenance.
+		var err error
+		if signer, err = attestation.LoadSigner(c.String("attest-key")); err != nil {
+			return err
+		}
+	}
 	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
 	if err != nil {
 		return err
@@ -2458,6 +2879,23 @@ func buildPublishCmd(c *cli.Context) error {
 	buildPublishCmd := buildinfo.NewBuildPublishCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetConfig(buildInfoConfiguration).SetDetailedSummary(c.Bool("detailed-summary"))
 
 	err = commands.Exec(buildPublishCmd)
+	if err == nil {
+		// The SBOM and the provenance of the published build are independent, so each of them is created even if the other one fails.
+		var errs []string
+		if sbomFormat != "" {
+			if sbomErr := writeBuildSbom(rtDetails, -1, buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project, sbomFormat, sbomFile); sbomErr != nil {
+				errs = append(errs, sbomErr.Error())
+			}
+		}
+		if signer != nil {
+			if provenanceErr := uploadBuildProvenance(rtDetails, buildConfiguration, buildInfoConfiguration.BuildUrl, signer, c.String("attest-repo")); provenanceErr != nil {
+				errs = append(errs, provenanceErr.Error())
+			}
+		}
+		if len(errs) > 0 {
+			err = errorutils.CheckError(errors.New(strings.Join(errs, "\n")))
+		}
+	}
 	if buildPublishCmd.IsDetailedSummary() {
 		if summary := buildPublishCmd.GetSummary(); summary != nil {
 			return cliutils.PrintBuildInfoSummaryReport(summary.IsSucceeded(), summary.GetSha256(), err)
@@ -2573,8 +3011,17 @@ func buildScanCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	commandReport, err := createReport(c, "rt build-scan")
+	if err != nil {
+		return err
+	}
 	buildScanCmd := buildinfo.NewBuildScanCommand().SetServerDetails(rtDetails).SetFailBuild(c.BoolT("fail")).SetBuildConfiguration(buildConfiguration)
-	err = commands.Exec(buildScanCmd)
+	if commandReport != nil {
+		err = commands.Exec(&reportedBuildScanCommand{BuildScanCommand: buildScanCmd, serverDetails: rtDetails, buildConfiguration: buildConfiguration, failBuild: c.BoolT("fail"), report: commandReport})
+		err = writeReportFile(commandReport, err)
+	} else {
+		err = commands.Exec(buildScanCmd)
+	}
 
 	return checkBuildScanError(err)
 }
@@ -3471,11 +3918,14 @@ func createBuildInfoConfiguration(c *cli.Context) *buildinfocmd.Configuration {
 	}
 	// Allow to use `env-exclude=""` and get no filters
 	if flags.EnvExclude == "" {
-		flags.EnvExclude = "*password*;*psw*;*secret*;*key*;*token*"
+		flags.EnvExclude = defaultEnvExclude
 	}
 	return flags
 }
 
+// The patterns of the environment variables which are excluded from the published build-info by default.
+const defaultEnvExclude = "*password*;*psw*;*secret*;*key*;*token*"
+
 func createBuildPromoteConfiguration(c *cli.Context) services.PromotionParams {
 	promotionParamsImpl := services.NewPromotionParams()
 	promotionParamsImpl.Comment = c.String("comment")
@@ -0,0 +1,203 @@
+package artifactory
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli-core/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/common/commands"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/dirsync"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The statuses of a file in the target path, compared with the source path.
+const (
+	diffAdded   = "added"
+	diffRemoved = "removed"
+	diffChanged = "changed"
+)
+
+// A file of one of the compared paths.
+type diffFile struct {
+	sha1 string
+	// Nil for local files.
+	props map[string][]string
+}
+
+// A file which is different in the compared paths.
+type fileDifference struct {
+	// The path of the file, relative to the compared paths.
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	// The sha1 checksums of the file in each path, if they are different.
+	Sha1 *sha1Difference `json:"sha1,omitempty"`
+	// The properties of the file which exist in one path only, in the form of key=value.
+	Props *propsDifference `json:"props,omitempty"`
+}
+
+type sha1Difference struct {
+	Source string `json:"source,omitempty"`
+	Target string `json:"target,omitempty"`
+}
+
+type propsDifference struct {
+	Source []string `json:"source,omitempty"`
+	Target []string `json:"target,omitempty"`
+}
+
+func diffCmd(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if (isLocalDiffPath(c.Args().Get(0)) || isLocalDiffPath(c.Args().Get(1))) && (c.String("props") != "" || c.String("exclude-props") != "" || c.String("exclusions") != "") {
+		return cliutils.PrintHelpAndReturnError("The --props, --exclude-props and --exclusions options filter files in Artifactory only, so they cannot be used to compare a local directory.", c)
+	}
+	source, err := getDiffFiles(c, c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	target, err := getDiffFiles(c, c.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(compareFiles(source, target))
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	log.Output(clientutils.IndentJson(content))
+	return nil
+}
+
+// A path is a local directory if such a directory exists. Other paths are repository paths.
+func isLocalDiffPath(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// Returns the files of the path, by their paths relative to it.
+func getDiffFiles(c *cli.Context, path string) (map[string]*diffFile, error) {
+	files := map[string]*diffFile{}
+	if isLocalDiffPath(path) {
+		localFiles, err := dirsync.ScanLocal(path)
+		if err != nil {
+			return nil, err
+		}
+		recursive := c.BoolT("recursive")
+		for relativePath, file := range localFiles {
+			if recursive || !strings.Contains(relativePath, "/") {
+				files[relativePath] = &diffFile{sha1: file.Sha1}
+			}
+		}
+		return files, nil
+	}
+
+	searchSpec, err := createDefaultSearchSpec(c)
+	if err != nil {
+		return nil, err
+	}
+	searchSpec.Get(0).Pattern = path
+	serverDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return nil, err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return nil, err
+	}
+	searchCmd := generic.NewSearchCommand()
+	searchCmd.SetServerDetails(serverDetails).SetSpec(searchSpec).SetRetries(retries)
+	if err = commands.Exec(searchCmd); err != nil {
+		return nil, err
+	}
+	reader := searchCmd.Result().Reader()
+	defer reader.Close()
+	base := getPatternBase(path)
+	for result := new(utils.SearchResult); reader.NextRecord(result) == nil; result = new(utils.SearchResult) {
+		if result.Type == "folder" {
+			continue
+		}
+		props := result.Props
+		if props == nil {
+			props = map[string][]string{}
+		}
+		files[strings.TrimPrefix(result.Path, base)] = &diffFile{sha1: result.Sha1, props: props}
+	}
+	return files, reader.GetError()
+}
+
+// Returns the part of the pattern before its first wildcard, up to the last slash.
+// The paths of the files matched by the pattern are relative to it.
+func getPatternBase(pattern string) string {
+	if !strings.Contains(pattern, "/") {
+		pattern += "/"
+	}
+	if wildcardIndex := strings.Index(pattern, "*"); wildcardIndex >= 0 {
+		pattern = pattern[:wildcardIndex]
+	}
+	return pattern[:strings.LastIndex(pattern, "/")+1]
+}
+
+// Returns the differences of the target files from the source files, sorted by their paths.
+// Properties are compared only if both files have properties, which local files don't.
+func compareFiles(source, target map[string]*diffFile) []*fileDifference {
+	differences := []*fileDifference{}
+	for path, sourceFile := range source {
+		targetFile, exists := target[path]
+		if !exists {
+			differences = append(differences, &fileDifference{Path: path, Status: diffRemoved, Sha1: &sha1Difference{Source: sourceFile.sha1}})
+			continue
+		}
+		difference := &fileDifference{Path: path, Status: diffChanged}
+		if sourceFile.sha1 != targetFile.sha1 {
+			difference.Sha1 = &sha1Difference{Source: sourceFile.sha1, Target: targetFile.sha1}
+		}
+		if sourceFile.props != nil && targetFile.props != nil {
+			removedProps, addedProps := getPropsDifference(sourceFile.props, targetFile.props), getPropsDifference(targetFile.props, sourceFile.props)
+			if len(removedProps) > 0 || len(addedProps) > 0 {
+				difference.Props = &propsDifference{Source: removedProps, Target: addedProps}
+			}
+		}
+		if difference.Sha1 != nil || difference.Props != nil {
+			differences = append(differences, difference)
+		}
+	}
+	for path, targetFile := range target {
+		if _, exists := source[path]; !exists {
+			differences = append(differences, &fileDifference{Path: path, Status: diffAdded, Sha1: &sha1Difference{Target: targetFile.sha1}})
+		}
+	}
+	sort.Slice(differences, func(i, j int) bool {
+		return differences[i].Path < differences[j].Path
+	})
+	return differences
+}
+
+// Returns the properties of the first map which are missing in the second map, in the form of key=value.
+func getPropsDifference(props, otherProps map[string][]string) []string {
+	var difference []string
+	for key, values := range props {
+		for _, value := range values {
+			if !containsString(otherProps[key], value) {
+				difference = append(difference, key+"="+value)
+			}
+		}
+	}
+	sort.Strings(difference)
+	return difference
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package artifactory
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPatternBase(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		expected string
+	}{
+		{"libs-release", "libs-release/"},
+		{"libs-release/app/1.2/", "libs-release/app/1.2/"},
+		{"libs-release/app/1.2/app.jar", "libs-release/app/1.2/"},
+		{"libs-release/app/*/app.jar", "libs-release/app/"},
+		{"libs-release/app/1.*", "libs-release/app/"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, getPatternBase(test.pattern), test.pattern)
+	}
+}
+
+func TestIsLocalDiffPath(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(tmpDir))
+	defer os.Chdir(wd)
+	assert.NoError(t, os.MkdirAll(filepath.Join("build", "libs"), 0755))
+	assert.NoError(t, ioutil.WriteFile("file.jar", []byte("content"), 0644))
+
+	assert.True(t, isLocalDiffPath("build/libs"))
+	assert.True(t, isLocalDiffPath("./build/libs"))
+	assert.True(t, isLocalDiffPath(tmpDir))
+	assert.True(t, isLocalDiffPath("."))
+	assert.False(t, isLocalDiffPath("file.jar"))
+	assert.False(t, isLocalDiffPath("libs-release/app/1.2/"))
+}
+
+func TestCompareFiles(t *testing.T) {
+	source := map[string]*diffFile{
+		"equal.jar":   {sha1: "a", props: map[string][]string{"k": {"v"}}},
+		"changed.jar": {sha1: "b", props: map[string][]string{}},
+		"props.jar":   {sha1: "c", props: map[string][]string{"k": {"v1", "v2"}, "status": {"staged"}}},
+		"removed.jar": {sha1: "d"},
+		"local.jar":   {sha1: "e"},
+	}
+	target := map[string]*diffFile{
+		"equal.jar":   {sha1: "a", props: map[string][]string{"k": {"v"}}},
+		"changed.jar": {sha1: "f", props: map[string][]string{}},
+		"props.jar":   {sha1: "c", props: map[string][]string{"k": {"v2"}, "status": {"released"}}},
+		"added.jar":   {sha1: "g"},
+		"local.jar":   {sha1: "e", props: map[string][]string{"k": {"v"}}},
+	}
+	assert.Equal(t, []*fileDifference{
+		{Path: "added.jar", Status: diffAdded, Sha1: &sha1Difference{Target: "g"}},
+		{Path: "changed.jar", Status: diffChanged, Sha1: &sha1Difference{Source: "b", Target: "f"}},
+		{Path: "props.jar", Status: diffChanged, Props: &propsDifference{Source: []string{"k=v1", "status=staged"}, Target: []string{"status=released"}}},
+		{Path: "removed.jar", Status: diffRemoved, Sha1: &sha1Difference{Source: "d"}},
+	}, compareFiles(source, target))
+}
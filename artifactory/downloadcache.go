@@ -0,0 +1,63 @@
+package artifactory
+
+import (
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli/utils/downloadcache"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// A downloaded file which is added to the download cache once it is downloaded.
+type uncachedFile struct {
+	localFilePath string
+	sha1          string
+}
+
+// Creates the downloaded files which are in the download cache, so that the download which follows skips them.
+// Returns the files which are not in the cache.
+func restoreFromDownloadCache(cache *downloadcache.Cache, downloadedFiles []*downloadedFile) ([]*uncachedFile, error) {
+	var uncachedFiles []*uncachedFile
+	restored := 0
+	for _, file := range downloadedFiles {
+		// Archives which are extracted are not restored, since the download extracts only the archives it downloads.
+		if file.explode || file.item.Actual_Sha1 == "" {
+			continue
+		}
+		isEqual, err := fileutils.IsEqualToLocalFile(file.localFilePath, file.item.Actual_Md5, file.item.Actual_Sha1)
+		if err != nil {
+			return uncachedFiles, err
+		}
+		if isEqual {
+			continue
+		}
+		isRestored, err := cache.Restore(file.item.Actual_Sha1, file.localFilePath)
+		if err != nil {
+			// The file is downloaded instead.
+			log.Warn("Couldn't restore " + file.localFilePath + " from the download cache: " + err.Error())
+		}
+		if isRestored {
+			log.Debug("Restored " + file.localFilePath + " from the download cache.")
+			restored++
+			continue
+		}
+		uncachedFiles = append(uncachedFiles, &uncachedFile{localFilePath: file.localFilePath, sha1: file.item.Actual_Sha1})
+	}
+	if restored > 0 {
+		log.Info("Restored " + strconv.Itoa(restored) + " files from the download cache.")
+	}
+	return uncachedFiles, nil
+}
+
+// Adds the files which were downloaded to the download cache.
+// Failing to add a file does not fail the download.
+func storeInDownloadCache(cache *downloadcache.Cache, files []*uncachedFile) {
+	for _, file := range files {
+		if !fileutils.IsPathExists(file.localFilePath, false) {
+			continue
+		}
+		if err := cache.Store(file.localFilePath, file.sha1); err != nil {
+			log.Warn("Couldn't add " + file.localFilePath + " to the download cache: " + err.Error())
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package artifactory
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	logUtils "github.com/jfrog/jfrog-cli/utils/log"
+	"github.com/jfrog/jfrog-cli/utils/progressbar"
+	"github.com/jfrog/jfrog-cli/utils/splitdownload"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Downloads the files which are split into ranges, keeping their downloaded parts if the download fails.
+// The download which follows skips these files, since they already exist locally.
+func runResumableSplitDownloads(serviceManager artifactory.ArtifactoryServicesManager, downloadedFiles []*downloadedFile, configuration *utils.DownloadConfiguration) error {
+	downloads, err := planSplitDownloads(serviceManager, downloadedFiles, configuration)
+	if err != nil || len(downloads) == 0 {
+		return err
+	}
+
+	progressBar, logFile, err := progressbar.InitProgressBarIfPossible()
+	if err != nil {
+		return err
+	}
+	if progressBar != nil {
+		defer logUtils.CloseLogFile(logFile)
+		defer progressBar.Quit()
+		progressBar.IncGeneralProgressTotalBy(int64(len(downloads)))
+	}
+	// The files are downloaded concurrently by the number of threads of the download, like the regular download.
+	threads := configuration.Threads
+	if threads < 1 {
+		threads = 1
+	}
+	downloadsChan := make(chan *splitdownload.DownloadDetails, len(downloads))
+	for _, details := range downloads {
+		downloadsChan <- details
+	}
+	close(downloadsChan)
+	var failed int32
+	var wg sync.WaitGroup
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+		go func(threadId int) {
+			defer wg.Done()
+			logMsgPrefix := clientutils.GetLogMsgPrefix(threadId, false)
+			for details := range downloadsChan {
+				if err := splitdownload.Download(serviceManager, details, progressBar, logMsgPrefix); err != nil {
+					log.Error(logMsgPrefix, err)
+					atomic.AddInt32(&failed, 1)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	if failed > 0 {
+		return errorutils.CheckError(errors.New(fmt.Sprintf("failed downloading %d files. Run the command again with the --resume option to download their missing parts", failed)))
+	}
+	return nil
+}
+
+// Returns an error if the downloads cannot be resumed by the configuration.
+func validateResumableDownload(configuration *utils.DownloadConfiguration) error {
+	if configuration.SplitCount == 0 || configuration.MinSplitSize < 0 {
+		return errorutils.CheckError(errors.New("the --resume option cannot be used when files are not split into ranges"))
+	}
+	return nil
+}
+
+// Lists the files which are downloaded in parts and do not exist locally.
+func planSplitDownloads(serviceManager artifactory.ArtifactoryServicesManager, downloadedFiles []*downloadedFile, configuration *utils.DownloadConfiguration) ([]*splitdownload.DownloadDetails, error) {
+	var downloads []*splitdownload.DownloadDetails
+	for _, file := range downloadedFiles {
+		if file.item.Size < int64(configuration.MinSplitSize)*1000 {
+			continue
+		}
+		details, err := getSplitDownloadDetails(serviceManager, file.item, file.localFilePath, configuration.SplitCount)
+		if err != nil {
+			return nil, err
+		}
+		if details != nil {
+			downloads = append(downloads, details)
+		}
+	}
+	return downloads, nil
+}
+
+// A file which the spec downloads, and the local path it is downloaded to.
+type downloadedFile struct {
+	item          *serviceutils.ResultItem
+	localFilePath string
+	// True if the file is extracted after it is downloaded.
+	explode bool
+}
+
+// Searches the files the spec downloads, so that the download cache and the resumable download share a single search.
+// Folders and symlinks are skipped, and files downloaded to the same local path by different groups are listed once.
+func searchDownloadedFiles(serviceManager artifactory.ArtifactoryServicesManager, downloadSpec *spec.SpecFiles) ([]*downloadedFile, error) {
+	var downloadedFiles []*downloadedFile
+	localFilePaths := map[string]bool{}
+	for i := range downloadSpec.Files {
+		file := downloadSpec.Get(i)
+		params, err := file.ToArtifactoryCommonParams()
+		if err != nil {
+			return nil, err
+		}
+		if params.Recursive, err = file.IsRecursive(true); err != nil {
+			return nil, err
+		}
+		flat, err := file.IsFlat(false)
+		if err != nil {
+			return nil, err
+		}
+		explode, err := file.IsExplode(false)
+		if err != nil {
+			return nil, err
+		}
+		reader, err := serviceManager.SearchFiles(services.SearchParams{ArtifactoryCommonParams: params})
+		if err != nil {
+			return nil, err
+		}
+		for item := new(serviceutils.ResultItem); reader.NextRecord(item) == nil; item = new(serviceutils.ResultItem) {
+			if item.Type == "folder" || isSymlinkItem(item) {
+				continue
+			}
+			target, err := clientutils.BuildTargetPath(params.GetPattern(), item.GetItemRelativePath(), params.GetTarget(), true)
+			if err != nil {
+				reader.Close()
+				return nil, err
+			}
+			localPath, localFileName := fileutils.GetLocalPathAndFile(item.Name, item.Path, target, flat)
+			localFilePath := filepath.Join(localPath, localFileName)
+			if localFilePaths[localFilePath] {
+				continue
+			}
+			localFilePaths[localFilePath] = true
+			downloadedFiles = append(downloadedFiles, &downloadedFile{item: item, localFilePath: localFilePath, explode: explode})
+		}
+		err = reader.GetError()
+		reader.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return downloadedFiles, nil
+}
+
+// Symlinks are created locally rather than downloaded.
+func isSymlinkItem(item *serviceutils.ResultItem) bool {
+	for _, property := range item.Properties {
+		if property.Key == serviceutils.ARTIFACTORY_SYMLINK {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the details of the item's split download, or nil if the item should be downloaded by the regular download.
+// Items which already exist locally, items which cannot be downloaded by ranges, and items without a sha256 checksum are left to the regular download.
+func getSplitDownloadDetails(serviceManager artifactory.ArtifactoryServicesManager, item *serviceutils.ResultItem, localFilePath string, splitCount int) (*splitdownload.DownloadDetails, error) {
+	isEqual, err := fileutils.IsEqualToLocalFile(localFilePath, item.Actual_Md5, item.Actual_Sha1)
+	if err != nil || isEqual {
+		return nil, err
+	}
+	downloadUrl, err := serviceutils.BuildArtifactoryUrl(serviceManager.GetConfig().GetServiceDetails().GetUrl(), item.GetItemRelativePath(), map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	httpClientDetails := serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, _, err := serviceManager.Client().SendHead(downloadUrl, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	sha256 := resp.Header.Get("X-Checksum-Sha256")
+	if resp.Header.Get("Accept-Ranges") != "bytes" || sha256 == "" {
+		log.Debug("The download of " + item.GetItemRelativePath() + " cannot be resumed.")
+		return nil, nil
+	}
+	return &splitdownload.DownloadDetails{
+		DownloadUrl:   downloadUrl,
+		RelativePath:  item.GetItemRelativePath(),
+		LocalFilePath: localFilePath,
+		Size:          item.Size,
+		Sha256:        sha256,
+		SplitCount:    splitCount,
+	}, nil
+}
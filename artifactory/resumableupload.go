@@ -0,0 +1,505 @@
+package artifactory
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/commands/generic"
+	commandsutils "github.com/jfrog/jfrog-cli-core/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/common/commands"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/journal"
+	logUtils "github.com/jfrog/jfrog-cli/utils/log"
+	"github.com/jfrog/jfrog-cli/utils/multipartupload"
+	"github.com/jfrog/jfrog-cli/utils/progressbar"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// A resumable upload is split into batches, and the journal is updated after each batch is uploaded.
+	// An interrupted upload therefore re-uploads at most one batch when it is resumed.
+	resumableUploadBatchFiles = 1000
+	resumableUploadBatchSize  = 1024 * 1024 * 1024
+
+	// Files of this size or larger are uploaded in parts if Artifactory supports it, so an interrupted upload of such a file
+	// continues from its missing parts.
+	multipartUploadMinSize  = 200 * 1024 * 1024
+	multipartUploadPartSize = 20 * 1024 * 1024
+	// The maximal number of parts of a file. The part size of larger files is increased accordingly.
+	multipartUploadMaxParts = 10000
+)
+
+// Creates an upload command for the provided spec, with the options the user provided.
+type uploadCommandCreator func(uploadSpec *spec.SpecFiles) *generic.UploadCommand
+
+// A file matched by the upload spec.
+type plannedUpload struct {
+	// The spec group which matched the file.
+	group      *spec.File
+	sourcePath string
+	targetPath string
+	size       int64
+}
+
+// Uploads the files of the spec in batches, recording the uploaded files in a journal.
+// Files which were recorded by a previous run of the same spec, and which did not change since, are not uploaded again.
+// Large files are uploaded in parts if Artifactory supports it, so their interrupted uploads continue from the missing parts.
+// The journal is removed once all the files are uploaded successfully.
+func runResumableUpload(uploadSpec *spec.SpecFiles, serverDetails *config.ServerDetails, buildConfiguration *utils.BuildConfiguration, newUploadCommand uploadCommandCreator) (*commandsutils.Result, error) {
+	if err := validateResumableUploadSpec(uploadSpec); err != nil {
+		return nil, err
+	}
+	rtUrl := serverDetails.ArtifactoryUrl
+	uploadJournal, err := journal.Open("upload", rtUrl, uploadSpec)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := planUpload(uploadSpec, rtUrl, newUploadCommand)
+	if err != nil {
+		return nil, err
+	}
+
+	// The transfer details of the skipped and uploaded files are collected, to create the detailed summary of the whole spec.
+	transferDetailsWriter, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return nil, err
+	}
+	serviceManager, err := utils.CreateServiceManager(serverDetails, newUploadCommand(nil).Retries(), false)
+	if err != nil {
+		transferDetailsWriter.Close()
+		return nil, err
+	}
+	pending, skipped, err := getPendingUploads(plan, uploadJournal, serviceManager, rtUrl, transferDetailsWriter)
+	if err != nil {
+		transferDetailsWriter.Close()
+		return nil, err
+	}
+	if skipped > 0 {
+		log.Info(fmt.Sprintf("Skipping %d files which were uploaded by a previous run, according to the journal %s.", skipped, uploadJournal.GetPath()))
+	}
+
+	// A single progress bar is displayed for all the batches.
+	progressBar, logFile, err := progressbar.InitProgressBarIfPossible()
+	if err != nil {
+		transferDetailsWriter.Close()
+		return nil, err
+	}
+	if progressBar != nil {
+		defer logUtils.CloseLogFile(logFile)
+		defer progressBar.Quit()
+	}
+	multipart := &multipartUploader{serverDetails: serverDetails, buildConfiguration: buildConfiguration, uploadConfiguration: newUploadCommand(nil).UploadConfiguration(),
+		serviceManager: serviceManager, progress: progressBar, vcsCache: clientutils.NewVcsDetals()}
+	var largeFiles []*plannedUpload
+	pending, largeFiles, err = multipart.splitLargeFiles(pending)
+
+	successCount, failCount := skipped, 0
+	for len(pending) > 0 && err == nil {
+		var batch []*plannedUpload
+		batch, pending = nextUploadBatch(pending)
+		var batchResult *commandsutils.Result
+		batchResult, err = uploadBatch(batch, newUploadCommand, progressBar)
+		successCount += batchResult.SuccessCount()
+		failCount += batchResult.FailCount()
+		// The build-info of a batch is saved only if all of its files were uploaded,
+		// so the files of a partially failed batch should be uploaded again to be included in the build-info.
+		journalErr := journalUploadBatch(uploadJournal, batchResult.Reader(), rtUrl, transferDetailsWriter, !multipart.isCollectBuildInfo() || batchResult.FailCount() == 0)
+		if err == nil {
+			err = journalErr
+		}
+	}
+	for _, file := range largeFiles {
+		if err != nil {
+			break
+		}
+		var transferDetails *clientutils.FileTransferDetails
+		if transferDetails, err = multipart.upload(file); err != nil {
+			// The failure of a single file does not stop the upload, like the failures of files in a batch.
+			log.Error(err)
+			failCount++
+			err = nil
+			continue
+		}
+		successCount++
+		transferDetailsWriter.Write(*transferDetails)
+		var entry *journal.Entry
+		if entry, err = journal.NewEntry(file.sourcePath, file.targetPath, transferDetails.Sha256, file.sourcePath); err == nil {
+			err = uploadJournal.Add(entry)
+		}
+	}
+	if closeErr := transferDetailsWriter.Close(); err == nil {
+		err = closeErr
+	}
+	result := new(commandsutils.Result)
+	result.SetSuccessCount(successCount)
+	result.SetFailCount(failCount)
+	result.SetReader(content.NewContentReader(transferDetailsWriter.GetFilePath(), content.DefaultKey))
+	if err != nil || failCount > 0 {
+		log.Info("Run the command again with the --resume option to upload the remaining files.")
+		return result, err
+	}
+	return result, uploadJournal.Remove()
+}
+
+// Returns the files which should be uploaded, and writes the transfer details of the files which were uploaded by a previous run.
+// A file is skipped if it is recorded in the journal, did not change locally since, and its copy in Artifactory still has the recorded checksum.
+func getPendingUploads(plan []*plannedUpload, uploadJournal *journal.Journal, serviceManager artifactory.ArtifactoryServicesManager, rtUrl string,
+	transferDetailsWriter *content.ContentWriter) (pending []*plannedUpload, skipped int, err error) {
+	var journaled []*plannedUpload
+	var entries []*journal.Entry
+	var targetPaths []string
+	for _, file := range plan {
+		entry := uploadJournal.GetVerifiedEntry(file.sourcePath, file.targetPath, file.sourcePath)
+		if entry == nil {
+			pending = append(pending, file)
+			continue
+		}
+		journaled = append(journaled, file)
+		entries = append(entries, entry)
+		targetPaths = append(targetPaths, entry.TargetPath)
+	}
+	if len(journaled) == 0 {
+		return
+	}
+	remoteItems, err := getAqlItemsByPaths(serviceManager, targetPaths, "sha256")
+	if err != nil {
+		return
+	}
+	for i, file := range journaled {
+		remoteItem := remoteItems[entries[i].TargetPath]
+		if remoteItem == nil || (entries[i].Sha256 != "" && remoteItem.Sha256 != entries[i].Sha256) {
+			log.Debug("The uploaded copy of " + file.sourcePath + " was removed or changed in Artifactory, so it is uploaded again.")
+			pending = append(pending, file)
+			continue
+		}
+		var targetUrl string
+		if targetUrl, err = serviceutils.BuildArtifactoryUrl(rtUrl, entries[i].TargetPath, map[string]string{}); err != nil {
+			return
+		}
+		transferDetailsWriter.Write(clientutils.FileTransferDetails{SourcePath: entries[i].SourcePath, TargetPath: targetUrl, Sha256: entries[i].Sha256})
+		skipped++
+	}
+	return
+}
+
+// Archives and directories are not uploaded file by file, so they cannot be resumed.
+func validateResumableUploadSpec(uploadSpec *spec.SpecFiles) error {
+	for _, file := range uploadSpec.Files {
+		includeDirs, err := file.IsIncludeDirs(false)
+		if err != nil {
+			return err
+		}
+		if file.Archive != "" || includeDirs {
+			return errorutils.CheckError(errors.New("the --resume option cannot be used to upload archives or directories"))
+		}
+	}
+	return nil
+}
+
+// Lists the files matched by each of the spec's groups and their targets, by running the upload in dry run mode.
+func planUpload(uploadSpec *spec.SpecFiles, rtUrl string, newUploadCommand uploadCommandCreator) ([]*plannedUpload, error) {
+	log.Info("Collecting the files to upload...")
+	// Avoid logging each of the files the dry run goes through.
+	if logLevel := log.Logger.GetLogLevel(); logLevel == log.INFO {
+		log.Logger.SetLogLevel(log.WARN)
+		defer log.Logger.SetLogLevel(logLevel)
+	}
+	var plan []*plannedUpload
+	for i := range uploadSpec.Files {
+		// The upload command modifies the spec's groups, so each dry run gets a copy.
+		group := uploadSpec.Files[i]
+		planCmd := newUploadCommand(&spec.SpecFiles{Files: []spec.File{group}})
+		planCmd.SetDryRun(true).SetDetailedSummary(true)
+		if err := commands.Exec(planCmd); err != nil {
+			return nil, err
+		}
+		reader := planCmd.Result().Reader()
+		if reader == nil {
+			continue
+		}
+		for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+			info, err := os.Stat(transferDetails.SourcePath)
+			if errorutils.CheckError(err) != nil {
+				reader.Close()
+				return nil, err
+			}
+			targetPath, err := getTargetPath(transferDetails.TargetPath, rtUrl)
+			if err != nil {
+				reader.Close()
+				return nil, err
+			}
+			if err = setExactPattern(new(spec.File), transferDetails.SourcePath, targetPath); err != nil {
+				reader.Close()
+				return nil, err
+			}
+			plan = append(plan, &plannedUpload{group: &uploadSpec.Files[i], sourcePath: transferDetails.SourcePath, targetPath: targetPath, size: info.Size()})
+		}
+		err := reader.GetError()
+		reader.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// Splits the next batch from the pending files.
+func nextUploadBatch(pending []*plannedUpload) (batch, rest []*plannedUpload) {
+	var batchSize int64
+	for i, file := range pending {
+		if i == resumableUploadBatchFiles || (i > 0 && batchSize+file.size > resumableUploadBatchSize) {
+			return pending[:i], pending[i:]
+		}
+		batchSize += file.size
+	}
+	return pending, nil
+}
+
+// Uploads the batch, with a spec group for each of its files.
+// Each group keeps the options of the group which matched the file, and uploads it to its exact target.
+func uploadBatch(batch []*plannedUpload, newUploadCommand uploadCommandCreator, progress ioUtils.ProgressMgr) (*commandsutils.Result, error) {
+	batchSpec := new(spec.SpecFiles)
+	for _, file := range batch {
+		group := *file.group
+		group.Exclusions = nil
+		group.ExcludePatterns = nil
+		group.Recursive = strconv.FormatBool(false)
+		if err := setExactPattern(&group, file.sourcePath, file.targetPath); err != nil {
+			return new(commandsutils.Result), err
+		}
+		batchSpec.Files = append(batchSpec.Files, group)
+	}
+	uploadCmd := newUploadCommand(batchSpec)
+	uploadCmd.SetDetailedSummary(true)
+	if progress != nil {
+		uploadCmd.SetProgress(progress)
+	}
+	err := commands.Exec(uploadCmd)
+	return uploadCmd.Result(), err
+}
+
+// Sets the pattern of the group to the path of a single file.
+// A pattern which leads to an existing file is uploaded as is, without matching it against the files of its directory.
+// The file's path therefore leads to it as long as none of its characters is taken as a wildcard:
+// regular expressions are used as is up to their first section with parentheses,
+// and wildcard patterns up to their first section with an asterisk or with parentheses which have a placeholder in the target.
+func setExactPattern(group *spec.File, sourcePath, targetPath string) error {
+	group.Pattern = sourcePath
+	group.Target = targetPath
+	group.Ant = strconv.FormatBool(false)
+	if !strings.Contains(sourcePath, "(") {
+		group.Regexp = strconv.FormatBool(true)
+		return nil
+	}
+	group.Regexp = strconv.FormatBool(false)
+	if strings.Contains(sourcePath, "*") || len(clientutils.NewParenthesesSlice(sourcePath, targetPath).Parentheses) > 0 {
+		return errorutils.CheckError(errors.New("the --resume option cannot be used to upload a file whose path contains both parentheses and an asterisk, " +
+			"or parentheses and their placeholder: " + sourcePath))
+	}
+	return nil
+}
+
+// Records the uploaded files in the journal and in the transfer details of the whole upload.
+func journalUploadBatch(uploadJournal *journal.Journal, reader *content.ContentReader, rtUrl string, transferDetailsWriter *content.ContentWriter, addToJournal bool) error {
+	if reader == nil {
+		return nil
+	}
+	defer reader.Close()
+	var entries []*journal.Entry
+	for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		transferDetailsWriter.Write(*transferDetails)
+		if !addToJournal {
+			continue
+		}
+		targetPath, err := getTargetPath(transferDetails.TargetPath, rtUrl)
+		if err != nil {
+			return err
+		}
+		entry, err := journal.NewEntry(transferDetails.SourcePath, targetPath, transferDetails.Sha256, transferDetails.SourcePath)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+	if err := reader.GetError(); err != nil {
+		return err
+	}
+	return uploadJournal.Add(entries...)
+}
+
+// Returns the path in Artifactory of the URL of a transferred file.
+func getTargetPath(targetUrl, rtUrl string) (string, error) {
+	parsedTargetUrl, err := url.Parse(targetUrl)
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	parsedRtUrl, err := url.Parse(clientutils.AddTrailingSlashIfNeeded(rtUrl))
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(parsedTargetUrl.Path, parsedRtUrl.Path) {
+		return "", errorutils.CheckError(errors.New("unexpected URL of a transferred file: " + targetUrl))
+	}
+	return strings.TrimPrefix(parsedTargetUrl.Path, parsedRtUrl.Path), nil
+}
+
+// Uploads large files in parts, with the options the upload command applies to the files it uploads.
+type multipartUploader struct {
+	serverDetails       *config.ServerDetails
+	buildConfiguration  *utils.BuildConfiguration
+	uploadConfiguration *utils.UploadConfiguration
+	serviceManager      artifactory.ArtifactoryServicesManager
+	progress            ioUtils.ProgressMgr
+	vcsCache            *clientutils.VcsCache
+}
+
+func (mu *multipartUploader) isCollectBuildInfo() bool {
+	return mu.buildConfiguration != nil && mu.buildConfiguration.BuildName != "" && mu.buildConfiguration.BuildNumber != ""
+}
+
+// Splits the files which should be uploaded in parts from the pending files.
+// Symlinks, archives to explode and Debian packages are left to the upload command, since they are not uploaded as plain files.
+func (mu *multipartUploader) splitLargeFiles(pending []*plannedUpload) (rest, largeFiles []*plannedUpload, err error) {
+	for _, file := range pending {
+		isLarge := file.size >= multipartUploadMinSize && mu.uploadConfiguration.Deb == ""
+		if isLarge {
+			if isLarge, err = isPlainFileUpload(file); err != nil {
+				return
+			}
+		}
+		if isLarge {
+			largeFiles = append(largeFiles, file)
+		} else {
+			rest = append(rest, file)
+		}
+	}
+	if len(largeFiles) == 0 {
+		return
+	}
+	supported, err := multipartupload.IsSupported(mu.serviceManager)
+	if err != nil || !supported {
+		log.Debug("Artifactory does not support multipart uploads, so large files are uploaded as a whole.")
+		return pending, nil, err
+	}
+	return
+}
+
+func isPlainFileUpload(file *plannedUpload) (bool, error) {
+	explode, err := file.group.IsExplode(false)
+	if err != nil || explode {
+		return false, err
+	}
+	symlinks, err := file.group.IsSymlinks(false)
+	if err != nil || !symlinks {
+		return !symlinks, err
+	}
+	return !fileutils.IsPathSymlink(file.sourcePath), nil
+}
+
+// Uploads the file in parts, sets its properties and adds it to the build-info.
+func (mu *multipartUploader) upload(file *plannedUpload) (*clientutils.FileTransferDetails, error) {
+	fileDetails, err := fileutils.GetFileDetails(file.sourcePath)
+	if err != nil {
+		return nil, err
+	}
+	partSize := int64(multipartUploadPartSize)
+	if minPartSize := (file.size + multipartUploadMaxParts - 1) / multipartUploadMaxParts; minPartSize > partSize {
+		// Part sizes are in whole megabytes.
+		partSize = (minPartSize + 1024*1024 - 1) / (1024 * 1024) * 1024 * 1024
+	}
+	uploadDetails := &multipartupload.UploadDetails{
+		LocalFilePath: file.sourcePath,
+		TargetPath:    file.targetPath,
+		Size:          file.size,
+		Sha1:          fileDetails.Checksum.Sha1,
+		PartSize:      partSize,
+		Threads:       mu.uploadConfiguration.Threads,
+	}
+	if err = multipartupload.Upload(mu.serviceManager, uploadDetails, mu.progress, ""); err != nil {
+		return nil, err
+	}
+	if err = mu.setProps(file); err != nil {
+		return nil, err
+	}
+	if mu.isCollectBuildInfo() {
+		artifactDetails := serviceutils.ArtifactDetails{
+			ArtifactoryPath: file.targetPath,
+			Checksums:       serviceutils.Checksums{Sha256: fileDetails.Checksum.Sha256, Sha1: fileDetails.Checksum.Sha1, Md5: fileDetails.Checksum.Md5},
+		}
+		populateFunc := func(partial *buildinfo.Partial) {
+			partial.Artifacts = []buildinfo.Artifact{artifactDetails.ToBuildInfoArtifact()}
+			partial.ModuleId = mu.buildConfiguration.Module
+			partial.ModuleType = buildinfo.Generic
+		}
+		if err = utils.SavePartialBuildInfo(mu.buildConfiguration.BuildName, mu.buildConfiguration.BuildNumber, mu.buildConfiguration.Project, populateFunc); err != nil {
+			return nil, err
+		}
+	}
+	targetUrl, err := serviceutils.BuildArtifactoryUrl(mu.serverDetails.ArtifactoryUrl, file.targetPath, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	return &clientutils.FileTransferDetails{SourcePath: file.sourcePath, TargetPath: targetUrl, Sha256: fileDetails.Checksum.Sha256}, nil
+}
+
+// Sets the properties the upload command sets on the files it uploads:
+// the properties of the spec's group, and when collecting build-info, the build and VCS properties.
+func (mu *multipartUploader) setProps(file *plannedUpload) error {
+	props := clientutils.AddProps(file.group.TargetProps, file.group.Props)
+	if mu.isCollectBuildInfo() {
+		if err := utils.SaveBuildGeneralDetails(mu.buildConfiguration.BuildName, mu.buildConfiguration.BuildNumber, mu.buildConfiguration.Project); err != nil {
+			return err
+		}
+		buildProps, err := utils.CreateBuildProperties(mu.buildConfiguration.BuildName, mu.buildConfiguration.BuildNumber, mu.buildConfiguration.Project)
+		if err != nil {
+			return err
+		}
+		props = clientutils.AddProps(props, buildProps)
+		absSourcePath, err := filepath.Abs(file.sourcePath)
+		if errorutils.CheckError(err) != nil {
+			return err
+		}
+		revision, vcsUrl, branch, err := mu.vcsCache.GetVcsDetails(filepath.Dir(absSourcePath))
+		if errorutils.CheckError(err) != nil {
+			return err
+		}
+		for _, vcsProp := range [][2]string{{"vcs.revision", revision}, {"vcs.url", vcsUrl}, {"vcs.branch", branch}} {
+			if vcsProp[1] != "" {
+				props = clientutils.AddProps(props, vcsProp[0]+"="+vcsProp[1])
+			}
+		}
+	}
+	if props == "" {
+		return nil
+	}
+	repoAndPath := strings.SplitN(file.targetPath, "/", 2)
+	itemsWriter, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return err
+	}
+	itemsWriter.Write(serviceutils.ResultItem{Repo: repoAndPath[0], Path: path.Dir(repoAndPath[1]), Name: path.Base(repoAndPath[1]), Type: "file"})
+	if err = itemsWriter.Close(); err != nil {
+		return err
+	}
+	reader := content.NewContentReader(itemsWriter.GetFilePath(), content.DefaultKey)
+	defer reader.Close()
+	_, err = mu.serviceManager.SetProps(services.PropsParams{Reader: reader, Props: props})
+	return err
+}
@@ -0,0 +1,262 @@
+package artifactory
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/journal"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResumableUpload(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	oldHome := os.Getenv(coreutils.HomeDir)
+	assert.NoError(t, os.Setenv(coreutils.HomeDir, filepath.Join(tmpDir, "home")))
+	defer os.Setenv(coreutils.HomeDir, oldHome)
+
+	sourceDir := filepath.Join(tmpDir, "source")
+	for _, name := range []string{"a.txt", "b.txt", filepath.Join("c", "fail.txt")} {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(sourceDir, name)), 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(sourceDir, name), []byte(name), 0644))
+	}
+
+	// A fake Artifactory, which fails the upload of 'fail.txt' while 'failing' is set.
+	// Its AQL queries return all the stored files.
+	var mutex sync.Mutex
+	var uploaded []string
+	stored := map[string]bool{}
+	failing := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if r.Method == http.MethodPost && r.URL.Path == "/api/search/aql" {
+			var results []string
+			for path := range stored {
+				repo, dir, name := splitSearchResultPath(strings.TrimPrefix(path, "/"))
+				results = append(results, `{"repo":"`+repo+`","path":"`+dir+`","name":"`+name+`","sha256":"sha256-of-`+name+`"}`)
+			}
+			w.Write([]byte(`{"results":[` + strings.Join(results, ",") + `]}`))
+			return
+		}
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		path := strings.Split(r.URL.Path, ";")[0]
+		if failing && strings.HasSuffix(path, "fail.txt") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		uploaded = append(uploaded, path)
+		stored[path] = true
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"checksums":{"sha256":"sha256-of-` + filepath.Base(path) + `"}}`))
+	}))
+	defer server.Close()
+
+	rtUrl := server.URL + "/"
+	uploadSpec := spec.NewBuilder().Pattern(filepath.ToSlash(sourceDir) + "/(*)").Target("repo/{1}").Recursive(true).Flat(true).BuildSpec()
+	newUploadCommand := func(uploadSpec *spec.SpecFiles) *generic.UploadCommand {
+		uploadCmd := generic.NewUploadCommand()
+		uploadCmd.SetUploadConfiguration(&utils.UploadConfiguration{Threads: 1}).SetSpec(uploadSpec).SetServerDetails(&config.ServerDetails{ArtifactoryUrl: rtUrl}).SetRetries(0)
+		return uploadCmd
+	}
+
+	// The first run fails uploading one of the files, so the uploaded files remain in the journal.
+	result, err := runResumableUpload(uploadSpec, &config.ServerDetails{ArtifactoryUrl: rtUrl}, nil, newUploadCommand)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.SuccessCount())
+	assert.Equal(t, 1, result.FailCount())
+	result.Reader().Close()
+	sort.Strings(uploaded)
+	assert.Equal(t, []string{"/repo/a.txt", "/repo/b.txt"}, uploaded)
+	uploadJournal, err := journal.Open("upload", rtUrl, uploadSpec)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, uploadJournal.Len())
+
+	// A modified file should be uploaded again.
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(sourceDir, "b.txt"), []byte("modified"), 0644))
+
+	// The second run uploads only the remaining and modified files, and removes the journal once done.
+	failing = false
+	uploaded = nil
+	result, err = runResumableUpload(uploadSpec, &config.ServerDetails{ArtifactoryUrl: rtUrl}, nil, newUploadCommand)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.SuccessCount())
+	assert.Equal(t, 0, result.FailCount())
+	length, err := result.Reader().Length()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, length)
+	result.Reader().Close()
+	sort.Strings(uploaded)
+	assert.Equal(t, []string{"/repo/b.txt", "/repo/c/fail.txt"}, uploaded)
+	exists, err := fileutils.IsFileExists(uploadJournal.GetPath(), false)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestResumableUploadRemovedRemoteFile(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	oldHome := os.Getenv(coreutils.HomeDir)
+	assert.NoError(t, os.Setenv(coreutils.HomeDir, filepath.Join(tmpDir, "home")))
+	defer os.Setenv(coreutils.HomeDir, oldHome)
+
+	sourceDir := filepath.Join(tmpDir, "source")
+	assert.NoError(t, os.MkdirAll(sourceDir, 0755))
+	for _, name := range []string{"a.txt", "b.txt"} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(sourceDir, name), []byte(name), 0644))
+	}
+	// A fake Artifactory, in which 'a.txt' was removed after it was uploaded.
+	var mutex sync.Mutex
+	var uploaded []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if r.Method == http.MethodPost && r.URL.Path == "/api/search/aql" {
+			w.Write([]byte(`{"results":[{"repo":"repo","path":".","name":"b.txt","sha256":"sha256-of-b.txt"}]}`))
+			return
+		}
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		uploaded = append(uploaded, r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	rtUrl := server.URL + "/"
+	uploadSpec := spec.NewBuilder().Pattern(filepath.ToSlash(sourceDir) + "/*").Target("repo/").Flat(true).BuildSpec()
+	uploadJournal, err := journal.Open("upload", rtUrl, uploadSpec)
+	assert.NoError(t, err)
+	for _, name := range []string{"a.txt", "b.txt"} {
+		entry, err := journal.NewEntry(filepath.Join(sourceDir, name), "repo/"+name, "sha256-of-"+name, filepath.Join(sourceDir, name))
+		assert.NoError(t, err)
+		assert.NoError(t, uploadJournal.Add(entry))
+	}
+	newUploadCommand := func(uploadSpec *spec.SpecFiles) *generic.UploadCommand {
+		uploadCmd := generic.NewUploadCommand()
+		uploadCmd.SetUploadConfiguration(&utils.UploadConfiguration{Threads: 1}).SetSpec(uploadSpec).SetServerDetails(&config.ServerDetails{ArtifactoryUrl: rtUrl}).SetRetries(0)
+		return uploadCmd
+	}
+
+	// Only the file which was removed from Artifactory is uploaded again.
+	result, err := runResumableUpload(uploadSpec, &config.ServerDetails{ArtifactoryUrl: rtUrl}, nil, newUploadCommand)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.SuccessCount())
+	result.Reader().Close()
+	assert.Equal(t, []string{"/repo/a.txt"}, uploaded)
+	exists, err := fileutils.IsFileExists(uploadJournal.GetPath(), false)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestResumableUploadSpecialCharacters(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	oldHome := os.Getenv(coreutils.HomeDir)
+	assert.NoError(t, os.Setenv(coreutils.HomeDir, filepath.Join(tmpDir, "home")))
+	defer os.Setenv(coreutils.HomeDir, oldHome)
+
+	// Each of the files should be uploaded once, even though their names are also patterns matching the other files.
+	sourceDir := filepath.Join(tmpDir, "source")
+	assert.NoError(t, os.MkdirAll(sourceDir, 0755))
+	names := []string{"a(1).txt", "a1.txt", "b{1}.txt", "c*.txt", "c.txt", "cc.txt"}
+	for _, name := range names {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(sourceDir, name), []byte(name), 0644))
+	}
+	var mutex sync.Mutex
+	var uploaded []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		mutex.Lock()
+		defer mutex.Unlock()
+		uploaded = append(uploaded, strings.TrimPrefix(strings.Split(r.URL.Path, ";")[0], "/repo/"))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	rtUrl := server.URL + "/"
+	uploadSpec := spec.NewBuilder().Pattern(filepath.ToSlash(sourceDir) + "/*").Target("repo/").Flat(true).BuildSpec()
+	newUploadCommand := func(uploadSpec *spec.SpecFiles) *generic.UploadCommand {
+		uploadCmd := generic.NewUploadCommand()
+		uploadCmd.SetUploadConfiguration(&utils.UploadConfiguration{Threads: 1}).SetSpec(uploadSpec).SetServerDetails(&config.ServerDetails{ArtifactoryUrl: rtUrl}).SetRetries(0)
+		return uploadCmd
+	}
+	result, err := runResumableUpload(uploadSpec, &config.ServerDetails{ArtifactoryUrl: rtUrl}, nil, newUploadCommand)
+	assert.NoError(t, err)
+	assert.Equal(t, len(names), result.SuccessCount())
+	result.Reader().Close()
+	sort.Strings(uploaded)
+	assert.Equal(t, names, uploaded)
+}
+
+func TestSetExactPattern(t *testing.T) {
+	group := new(spec.File)
+	assert.NoError(t, setExactPattern(group, "dir/c*.txt", "repo/c*.txt"))
+	assert.Equal(t, "true", group.Regexp)
+	assert.NoError(t, setExactPattern(group, "dir/a(1).txt", "repo/a(1).txt"))
+	assert.Equal(t, "false", group.Regexp)
+	assert.Equal(t, "dir/a(1).txt", group.Pattern)
+	assert.Equal(t, "repo/a(1).txt", group.Target)
+	assert.Error(t, setExactPattern(group, "dir/a(1)*.txt", "repo/a(1)*.txt"))
+	assert.Error(t, setExactPattern(group, "dir/a(1){1}.txt", "repo/a(1){1}.txt"))
+}
+
+func TestNextUploadBatch(t *testing.T) {
+	var pending []*plannedUpload
+	for i := 0; i < resumableUploadBatchFiles+1; i++ {
+		pending = append(pending, &plannedUpload{size: 1})
+	}
+	batch, rest := nextUploadBatch(pending)
+	assert.Len(t, batch, resumableUploadBatchFiles)
+	assert.Len(t, rest, 1)
+
+	// A file larger than the batch size is uploaded in a batch of its own.
+	pending = []*plannedUpload{{size: 1}, {size: resumableUploadBatchSize}, {size: 1}}
+	batch, rest = nextUploadBatch(pending)
+	assert.Len(t, batch, 1)
+	batch, rest = nextUploadBatch(rest)
+	assert.Len(t, batch, 1)
+	batch, rest = nextUploadBatch(rest)
+	assert.Len(t, batch, 1)
+	assert.Empty(t, rest)
+}
+
+func TestGetTargetPath(t *testing.T) {
+	targetPath, err := getTargetPath("http://localhost:8081/artifactory/repo/a%20b/c.zip", "http://localhost:8081/artifactory/")
+	assert.NoError(t, err)
+	assert.Equal(t, "repo/a b/c.zip", targetPath)
+	_, err = getTargetPath("http://localhost:8081/other/repo/c.zip", "http://localhost:8081/artifactory")
+	assert.Error(t, err)
+}
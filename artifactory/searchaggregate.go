@@ -0,0 +1,162 @@
+package artifactory
+
+import (
+	"encoding/json"
+	"errors"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The aggregates of the search command's --aggregate option.
+const (
+	searchAggregateSize  = "size"
+	searchAggregateCount = "count"
+)
+
+// The groupings of the search command's --group-by option.
+const (
+	searchGroupByRepo     = "repo"
+	searchGroupByDirDepth = "dir-depth="
+	searchGroupByProp     = "prop:"
+)
+
+const defaultSearchAggregates = searchAggregateSize + "," + searchAggregateCount
+
+// The aggregates of the files of a single group.
+// Only the requested aggregates are included.
+type searchGroup struct {
+	Group string `json:"group"`
+	Count *int64 `json:"count,omitempty"`
+	Size  *int64 `json:"size,omitempty"`
+}
+
+// Summarizes the search results by groups, instead of listing the files.
+type searchAggregator struct {
+	dirDepth int
+	propKey  string
+	count    bool
+	size     bool
+	groups   map[string]*searchGroup
+}
+
+// Returns the aggregator of the group-by and aggregate options, or an error if they are not supported.
+func newSearchAggregator(groupBy, aggregates string) (*searchAggregator, error) {
+	aggregator := &searchAggregator{groups: map[string]*searchGroup{}}
+	switch {
+	case groupBy == searchGroupByRepo:
+	case strings.HasPrefix(groupBy, searchGroupByDirDepth):
+		depth, err := strconv.Atoi(strings.TrimPrefix(groupBy, searchGroupByDirDepth))
+		if err != nil || depth < 1 {
+			return nil, errorutils.CheckError(errors.New("the directories depth of '" + groupBy + "' should be a positive number"))
+		}
+		aggregator.dirDepth = depth
+	case strings.HasPrefix(groupBy, searchGroupByProp) && len(groupBy) > len(searchGroupByProp):
+		aggregator.propKey = strings.TrimPrefix(groupBy, searchGroupByProp)
+	default:
+		return nil, errorutils.CheckError(errors.New("unsupported group-by value '" + groupBy + "'. Supported values are: repo, dir-depth=<N> and prop:<key>"))
+	}
+
+	if aggregates == "" {
+		aggregates = defaultSearchAggregates
+	}
+	for _, aggregate := range strings.Split(aggregates, ",") {
+		switch strings.TrimSpace(aggregate) {
+		case searchAggregateCount:
+			aggregator.count = true
+		case searchAggregateSize:
+			aggregator.size = true
+		default:
+			return nil, errorutils.CheckError(errors.New("unsupported aggregate '" + aggregate + "'. Supported aggregates are: size and count"))
+		}
+	}
+	return aggregator, nil
+}
+
+// Returns the groups the file belongs to.
+// A file belongs to a group for each of the values of the grouping property, and to a group with an empty name if it has none.
+func (sa *searchAggregator) getGroups(result *utils.SearchResult) []string {
+	switch {
+	case sa.dirDepth > 0:
+		dirs := strings.Split(path.Dir(result.Path), "/")
+		// The first element is the repository.
+		if len(dirs) > sa.dirDepth+1 {
+			dirs = dirs[:sa.dirDepth+1]
+		}
+		return []string{strings.Join(dirs, "/")}
+	case sa.propKey != "":
+		if values := result.Props[sa.propKey]; len(values) > 0 {
+			return values
+		}
+		return []string{""}
+	}
+	return []string{strings.SplitN(result.Path, "/", 2)[0]}
+}
+
+func (sa *searchAggregator) add(result *utils.SearchResult) {
+	// Folders have no size, and are not counted as files.
+	if result.Type == "folder" {
+		return
+	}
+	for _, groupName := range sa.getGroups(result) {
+		group, exists := sa.groups[groupName]
+		if !exists {
+			group = &searchGroup{Group: groupName}
+			if sa.count {
+				group.Count = new(int64)
+			}
+			if sa.size {
+				group.Size = new(int64)
+			}
+			sa.groups[groupName] = group
+		}
+		if sa.count {
+			*group.Count++
+		}
+		if sa.size {
+			*group.Size += result.Size
+		}
+	}
+}
+
+// Returns the groups, from the largest to the smallest.
+// Groups are compared by their size, or by their count if the size isn't aggregated.
+func (sa *searchAggregator) getSortedGroups() []*searchGroup {
+	groups := make([]*searchGroup, 0, len(sa.groups))
+	for _, group := range sa.groups {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if sa.size && *groups[i].Size != *groups[j].Size {
+			return *groups[i].Size > *groups[j].Size
+		}
+		if sa.count && *groups[i].Count != *groups[j].Count {
+			return *groups[i].Count > *groups[j].Count
+		}
+		return groups[i].Group < groups[j].Group
+	})
+	return groups
+}
+
+// Reads the search results and prints their aggregates by groups.
+func printSearchAggregates(reader *content.ContentReader, aggregator *searchAggregator) error {
+	for result := new(utils.SearchResult); reader.NextRecord(result) == nil; result = new(utils.SearchResult) {
+		aggregator.add(result)
+	}
+	if err := reader.GetError(); err != nil {
+		return err
+	}
+	content, err := json.Marshal(aggregator.getSortedGroups())
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	log.Output(clientutils.IndentJson(content))
+	return nil
+}
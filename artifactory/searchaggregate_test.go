@@ -0,0 +1,77 @@
+package artifactory
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSearchAggregator(t *testing.T) {
+	for _, groupBy := range []string{"owner", "dir-depth=0", "dir-depth=a", "prop:"} {
+		_, err := newSearchAggregator(groupBy, "")
+		assert.Error(t, err, groupBy)
+	}
+	_, err := newSearchAggregator("repo", "size,avg")
+	assert.EqualError(t, err, "unsupported aggregate 'avg'. Supported aggregates are: size and count")
+
+	aggregator, err := newSearchAggregator("dir-depth=2", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, aggregator.dirDepth)
+	assert.True(t, aggregator.count && aggregator.size)
+	aggregator, err = newSearchAggregator("prop:build.name", "count")
+	assert.NoError(t, err)
+	assert.Equal(t, "build.name", aggregator.propKey)
+	assert.True(t, aggregator.count && !aggregator.size)
+}
+
+func TestSearchAggregatorGetGroups(t *testing.T) {
+	result := &utils.SearchResult{Path: "repo/a/b/c/file.jar", Props: map[string][]string{"build.name": {"b1", "b2"}}}
+	tests := []struct {
+		groupBy  string
+		result   *utils.SearchResult
+		expected []string
+	}{
+		{"repo", result, []string{"repo"}},
+		{"dir-depth=1", result, []string{"repo/a"}},
+		{"dir-depth=2", result, []string{"repo/a/b"}},
+		{"dir-depth=5", result, []string{"repo/a/b/c"}},
+		{"dir-depth=1", &utils.SearchResult{Path: "repo/file.jar"}, []string{"repo"}},
+		{"prop:build.name", result, []string{"b1", "b2"}},
+		{"prop:build.number", result, []string{""}},
+	}
+	for _, test := range tests {
+		aggregator, err := newSearchAggregator(test.groupBy, "")
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, aggregator.getGroups(test.result), test.groupBy)
+	}
+}
+
+func TestSearchAggregatorGetSortedGroups(t *testing.T) {
+	results := []*utils.SearchResult{
+		{Path: "repo1/a/file1", Type: "file", Size: 10},
+		{Path: "repo1/b/file2", Type: "file", Size: 10},
+		{Path: "repo1/b", Type: "folder"},
+		{Path: "repo2/file3", Type: "file", Size: 30},
+		{Path: "repo3/file4", Type: "file", Size: 5},
+	}
+	toGroups := func(aggregator *searchAggregator) []searchGroup {
+		for _, result := range results {
+			aggregator.add(result)
+		}
+		var groups []searchGroup
+		for _, group := range aggregator.getSortedGroups() {
+			groups = append(groups, *group)
+		}
+		return groups
+	}
+	int64Ptr := func(value int64) *int64 { return &value }
+
+	aggregator, err := newSearchAggregator("repo", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []searchGroup{{"repo2", int64Ptr(1), int64Ptr(30)}, {"repo1", int64Ptr(2), int64Ptr(20)}, {"repo3", int64Ptr(1), int64Ptr(5)}}, toGroups(aggregator))
+
+	aggregator, err = newSearchAggregator("repo", "count")
+	assert.NoError(t, err)
+	assert.Equal(t, []searchGroup{{"repo1", int64Ptr(2), nil}, {"repo2", int64Ptr(1), nil}, {"repo3", int64Ptr(1), nil}}, toGroups(aggregator))
+}
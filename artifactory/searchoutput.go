@@ -0,0 +1,280 @@
+package artifactory
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+)
+
+// The output formats of the search command's --search-format option.
+const (
+	searchFormatJson   = "json"
+	searchFormatCsv    = "csv"
+	searchFormatNdjson = "ndjson"
+)
+
+// The fields of the search results. Properties are selected by 'props.<key>'.
+const (
+	searchFieldPath        = "path"
+	searchFieldType        = "type"
+	searchFieldSize        = "size"
+	searchFieldCreated     = "created"
+	searchFieldModified    = "modified"
+	searchFieldSha1        = "sha1"
+	searchFieldSha256      = "sha256"
+	searchFieldMd5         = "md5"
+	searchFieldPropsPrefix = "props."
+)
+
+var searchFields = []string{searchFieldPath, searchFieldType, searchFieldSize, searchFieldCreated, searchFieldModified, searchFieldSha1, searchFieldSha256, searchFieldMd5}
+
+// The fields written to CSV if the --fields option is not used.
+var defaultCsvSearchFields = []string{searchFieldPath, searchFieldType, searchFieldSize, searchFieldCreated, searchFieldModified, searchFieldSha1, searchFieldMd5}
+
+// Writes the search results in one of the search output formats, one result at a time, so that the results are never held in memory.
+type searchResultsWriter struct {
+	format    string
+	fields    []string
+	writer    io.Writer
+	csvWriter *csv.Writer
+	written   int
+}
+
+// Returns the writer of the format and fields, or an error if they are not supported.
+// If no fields are provided, CSV includes the basic fields, and JSON and NDJSON include all of the fields returned by the search.
+func newSearchResultsWriter(format, fields string, writer io.Writer) (*searchResultsWriter, error) {
+	resultsWriter := &searchResultsWriter{format: strings.ToLower(format), writer: writer}
+	switch resultsWriter.format {
+	case searchFormatJson, searchFormatNdjson:
+	case searchFormatCsv:
+		resultsWriter.fields = defaultCsvSearchFields
+		resultsWriter.csvWriter = csv.NewWriter(writer)
+	default:
+		return nil, errorutils.CheckError(errors.New("unsupported search format '" + format + "'. Supported formats are: json, csv and ndjson"))
+	}
+	if fields != "" {
+		resultsWriter.fields = nil
+		for _, field := range strings.Split(fields, ",") {
+			field = strings.TrimSpace(field)
+			if !isSearchField(field) {
+				return nil, errorutils.CheckError(errors.New("unsupported search field '" + field + "'. Supported fields are: " + strings.Join(searchFields, ", ") + " and props.<key>"))
+			}
+			resultsWriter.fields = append(resultsWriter.fields, field)
+		}
+	}
+	return resultsWriter, nil
+}
+
+func isSearchField(field string) bool {
+	if strings.HasPrefix(field, searchFieldPropsPrefix) {
+		return len(field) > len(searchFieldPropsPrefix)
+	}
+	for _, searchField := range searchFields {
+		if field == searchField {
+			return true
+		}
+	}
+	return false
+}
+
+func (srw *searchResultsWriter) hasField(field string) bool {
+	for _, selected := range srw.fields {
+		if selected == field {
+			return true
+		}
+	}
+	return false
+}
+
+func (srw *searchResultsWriter) writeHeader() error {
+	switch srw.format {
+	case searchFormatCsv:
+		return srw.writeCsvRecord(srw.fields)
+	case searchFormatJson:
+		_, err := io.WriteString(srw.writer, "[")
+		return errorutils.CheckError(err)
+	}
+	return nil
+}
+
+func (srw *searchResultsWriter) write(result *utils.SearchResult, sha256 string) error {
+	defer func() { srw.written++ }()
+	if srw.format == searchFormatCsv {
+		var record []string
+		for _, field := range srw.fields {
+			record = append(record, formatSearchFieldValue(getSearchFieldValue(result, sha256, field)))
+		}
+		return srw.writeCsvRecord(record)
+	}
+
+	content, err := srw.marshalResult(result, sha256)
+	if err != nil {
+		return err
+	}
+	if srw.format == searchFormatJson {
+		separator := "\n  "
+		if srw.written > 0 {
+			separator = ",\n  "
+		}
+		content = append([]byte(separator), content...)
+	} else {
+		content = append(content, '\n')
+	}
+	_, err = srw.writer.Write(content)
+	return errorutils.CheckError(err)
+}
+
+// Marshals the selected fields of the result as a JSON object, keeping the order of the fields.
+func (srw *searchResultsWriter) marshalResult(result *utils.SearchResult, sha256 string) ([]byte, error) {
+	if srw.fields == nil {
+		return marshalSearchValue(result, srw.format)
+	}
+	separator := ":"
+	if srw.format == searchFormatJson {
+		separator = ": "
+	}
+	var members []string
+	for _, field := range srw.fields {
+		key, err := json.Marshal(field)
+		if errorutils.CheckError(err) != nil {
+			return nil, err
+		}
+		value, err := marshalSearchValue(getSearchFieldValue(result, sha256, field), srw.format)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, string(key)+separator+string(value))
+	}
+	if srw.format == searchFormatJson {
+		return []byte("{\n    " + strings.Join(members, ",\n    ") + "\n  }"), nil
+	}
+	return []byte("{" + strings.Join(members, ",") + "}"), nil
+}
+
+// JSON results are indented like the rest of the commands output, while each NDJSON result is written in a single line.
+func marshalSearchValue(value interface{}, format string) ([]byte, error) {
+	var content []byte
+	var err error
+	if format == searchFormatJson {
+		content, err = json.MarshalIndent(value, "  ", "  ")
+	} else {
+		content, err = json.Marshal(value)
+	}
+	return content, errorutils.CheckError(err)
+}
+
+func (srw *searchResultsWriter) writeCsvRecord(record []string) error {
+	if err := srw.csvWriter.Write(record); err != nil {
+		return errorutils.CheckError(err)
+	}
+	srw.csvWriter.Flush()
+	return errorutils.CheckError(srw.csvWriter.Error())
+}
+
+func (srw *searchResultsWriter) close() error {
+	if srw.format != searchFormatJson {
+		return nil
+	}
+	footer := "]\n"
+	if srw.written > 0 {
+		footer = "\n]\n"
+	}
+	_, err := io.WriteString(srw.writer, footer)
+	return errorutils.CheckError(err)
+}
+
+// Returns the value of the field, which is a string, a number or a list of property values.
+func getSearchFieldValue(result *utils.SearchResult, sha256, field string) interface{} {
+	switch field {
+	case searchFieldPath:
+		return result.Path
+	case searchFieldType:
+		return result.Type
+	case searchFieldSize:
+		return result.Size
+	case searchFieldCreated:
+		return result.Created
+	case searchFieldModified:
+		return result.Modified
+	case searchFieldSha1:
+		return result.Sha1
+	case searchFieldSha256:
+		return sha256
+	case searchFieldMd5:
+		return result.Md5
+	}
+	values := result.Props[strings.TrimPrefix(field, searchFieldPropsPrefix)]
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// Property values are joined by commas, like in the properties of the file spec.
+func formatSearchFieldValue(value interface{}) string {
+	switch typedValue := value.(type) {
+	case int64:
+		return strconv.FormatInt(typedValue, 10)
+	case []string:
+		return strings.Join(typedValue, ",")
+	}
+	return value.(string)
+}
+
+// Writes the results of the reader, fetching their sha256 checksums if the sha256 field is selected.
+func writeSearchResults(reader *content.ContentReader, resultsWriter *searchResultsWriter, serviceManager artifactory.ArtifactoryServicesManager) error {
+	if err := resultsWriter.writeHeader(); err != nil {
+		return err
+	}
+	var batch []*utils.SearchResult
+	writeBatch := func() error {
+		// The search does not return the sha256 checksums of the files, so they are fetched by an AQL query.
+		items := map[string]*aqlItem{}
+		if resultsWriter.hasField(searchFieldSha256) {
+			var paths []string
+			for _, result := range batch {
+				if result.Type != "folder" {
+					paths = append(paths, result.Path)
+				}
+			}
+			var err error
+			if items, err = getAqlItemsByPaths(serviceManager, paths, "sha256"); err != nil {
+				return err
+			}
+		}
+		for _, result := range batch {
+			sha256 := ""
+			if item, exists := items[result.Path]; exists {
+				sha256 = item.Sha256
+			}
+			if err := resultsWriter.write(result, sha256); err != nil {
+				return err
+			}
+		}
+		batch = nil
+		return nil
+	}
+	for result := new(utils.SearchResult); reader.NextRecord(result) == nil; result = new(utils.SearchResult) {
+		batch = append(batch, result)
+		if len(batch) == aqlItemsBatchSize {
+			if err := writeBatch(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := reader.GetError(); err != nil {
+		return err
+	}
+	if err := writeBatch(); err != nil {
+		return err
+	}
+	return resultsWriter.close()
+}
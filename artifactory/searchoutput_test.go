@@ -0,0 +1,105 @@
+package artifactory
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+)
+
+var testSearchResults = `{"results":[
+{"path":"repo/a/file.jar","type":"file","size":10,"created":"c1","modified":"m1","sha1":"s1","md5":"d1","props":{"build.name":["b1"],"tags":["x","y"]}},
+{"path":"repo/file, \"quoted\".txt","type":"file","size":20,"created":"c2","modified":"m2","sha1":"s2","md5":"d2"}
+]}`
+
+func TestNewSearchResultsWriter(t *testing.T) {
+	_, err := newSearchResultsWriter("xml", "", nil)
+	assert.EqualError(t, err, "unsupported search format 'xml'. Supported formats are: json, csv and ndjson")
+	_, err = newSearchResultsWriter("csv", "path,owner", nil)
+	assert.EqualError(t, err, "unsupported search field 'owner'. Supported fields are: path, type, size, created, modified, sha1, sha256, md5 and props.<key>")
+	_, err = newSearchResultsWriter("csv", "path,props.", nil)
+	assert.Error(t, err)
+
+	resultsWriter, err := newSearchResultsWriter("CSV", "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultCsvSearchFields, resultsWriter.fields)
+	resultsWriter, err = newSearchResultsWriter("ndjson", "size, props.build.name", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"size", "props.build.name"}, resultsWriter.fields)
+	assert.False(t, resultsWriter.hasField(searchFieldSha256))
+}
+
+func TestWriteSearchResults(t *testing.T) {
+	tests := []struct {
+		format   string
+		fields   string
+		expected string
+	}{
+		{"csv", "", "path,type,size,created,modified,sha1,md5\n" +
+			"repo/a/file.jar,file,10,c1,m1,s1,d1\n" +
+			"\"repo/file, \"\"quoted\"\".txt\",file,20,c2,m2,s2,d2\n"},
+		{"csv", "size,props.tags,props.build.name", "size,props.tags,props.build.name\n10,\"x,y\",b1\n20,,\n"},
+		{"ndjson", "path,props.tags", "{\"path\":\"repo/a/file.jar\",\"props.tags\":[\"x\",\"y\"]}\n" +
+			"{\"path\":\"repo/file, \\\"quoted\\\".txt\",\"props.tags\":[]}\n"},
+		{"json", "size", "[\n  {\n    \"size\": 10\n  },\n  {\n    \"size\": 20\n  }\n]\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.format+"_"+test.fields, func(t *testing.T) {
+			output := new(bytes.Buffer)
+			resultsWriter, err := newSearchResultsWriter(test.format, test.fields, output)
+			assert.NoError(t, err)
+			reader := createTestSearchReader(t, testSearchResults)
+			defer reader.Close()
+			assert.NoError(t, writeSearchResults(reader, resultsWriter, nil))
+			assert.Equal(t, test.expected, output.String())
+		})
+	}
+}
+
+func TestWriteSearchResultsNdjsonAllFields(t *testing.T) {
+	output := new(bytes.Buffer)
+	resultsWriter, err := newSearchResultsWriter("ndjson", "", output)
+	assert.NoError(t, err)
+	reader := createTestSearchReader(t, testSearchResults)
+	defer reader.Close()
+	assert.NoError(t, writeSearchResults(reader, resultsWriter, nil))
+	lines := bytes.Split(bytes.TrimSuffix(output.Bytes(), []byte("\n")), []byte("\n"))
+	if assert.Len(t, lines, 2) {
+		assert.Equal(t, `{"path":"repo/a/file.jar","type":"file","size":10,"created":"c1","modified":"m1","sha1":"s1","md5":"d1","props":{"build.name":["b1"],"tags":["x","y"]}}`, string(lines[0]))
+	}
+}
+
+func TestWriteSearchResultsEmpty(t *testing.T) {
+	output := new(bytes.Buffer)
+	resultsWriter, err := newSearchResultsWriter("json", "path", output)
+	assert.NoError(t, err)
+	reader := createTestSearchReader(t, `{"results":[]}`)
+	defer reader.Close()
+	assert.NoError(t, writeSearchResults(reader, resultsWriter, nil))
+	assert.Equal(t, "[]\n", output.String())
+}
+
+func TestSplitSearchResultPath(t *testing.T) {
+	tests := []struct {
+		path, repo, itemPath, name string
+	}{
+		{"repo/file.jar", "repo", ".", "file.jar"},
+		{"repo/a/b/file.jar", "repo", "a/b", "file.jar"},
+	}
+	for _, test := range tests {
+		repo, itemPath, name := splitSearchResultPath(test.path)
+		assert.Equal(t, []string{test.repo, test.itemPath, test.name}, []string{repo, itemPath, name})
+	}
+}
+
+func createTestSearchReader(t *testing.T, results string) *content.ContentReader {
+	// The file is removed when the reader is closed.
+	resultsFile, err := ioutil.TempFile("", "search-results")
+	assert.NoError(t, err)
+	_, err = resultsFile.WriteString(results)
+	assert.NoError(t, err)
+	assert.NoError(t, resultsFile.Close())
+	return content.NewContentReader(resultsFile.Name(), content.DefaultKey)
+}
@@ -0,0 +1,257 @@
+package artifactory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli-core/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/common/commands"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/dirsync"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func syncCmd(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	localDir := c.Args().Get(0)
+	remotePath := strings.Trim(c.Args().Get(1), "/")
+	if info, err := os.Stat(localDir); err != nil || !info.IsDir() {
+		return cliutils.PrintHelpAndReturnError("The local directory "+localDir+" does not exist.", c)
+	}
+	if remotePath == "" {
+		return cliutils.PrintHelpAndReturnError("The repository path is missing.", c)
+	}
+	policy := string(dirsync.Fail)
+	if c.String("conflict-policy") != "" {
+		policy = c.String("conflict-policy")
+	}
+	conflictPolicy, err := dirsync.GetConflictPolicy(policy)
+	if err != nil {
+		return err
+	}
+	serverDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	threads, err := getThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	serviceManager, err := utils.CreateServiceManager(serverDetails, retries, false)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Comparing " + localDir + " with " + remotePath + "...")
+	local, err := dirsync.ScanLocal(localDir)
+	if err != nil {
+		return err
+	}
+	remote, err := dirsync.ScanRemote(serviceManager, remotePath)
+	if err != nil {
+		return err
+	}
+	lastSync, err := dirsync.LoadState(serverDetails.ArtifactoryUrl, localDir, remotePath)
+	if err != nil {
+		return err
+	}
+	plan, err := dirsync.CreatePlan(local, remote, lastSync, conflictPolicy)
+	if err != nil {
+		return err
+	}
+	if c.Bool("dry-run") {
+		content, err := json.Marshal(plan)
+		if errorutils.CheckError(err) != nil {
+			return err
+		}
+		log.Output(clientutils.IndentJson(content))
+		return nil
+	}
+	if len(plan.Actions) == 0 {
+		log.Info("The local directory and the repository path are in sync.")
+		return dirsync.SaveState(serverDetails.ArtifactoryUrl, localDir, remotePath, dirsync.GetSyncedFiles(local, remote, lastSync, plan, true))
+	}
+	if hasDeletions(plan) && !cliutils.GetQuietValue(c) && !coreutils.AskYesNo("The sync deletes some files. Are you sure you want to continue?\n"+
+		"You can avoid this confirmation message by adding --quiet to the command.", false) {
+		return nil
+	}
+
+	syncer := &planSyncer{serverDetails: serverDetails, localDir: localDir, remotePath: remotePath, threads: threads, retries: retries}
+	err = syncer.apply(plan)
+	stateErr := dirsync.SaveState(serverDetails.ArtifactoryUrl, localDir, remotePath, dirsync.GetSyncedFiles(local, remote, lastSync, plan, err == nil && syncer.failed == 0))
+	if err == nil {
+		err = stateErr
+	}
+	err = cliutils.PrintSummaryReport(syncer.success, syncer.failed, err)
+	return cliutils.GetCliError(err, syncer.success, syncer.failed, false)
+}
+
+func hasDeletions(plan *dirsync.Plan) bool {
+	for _, action := range plan.Actions {
+		if action.Change == dirsync.Delete {
+			return true
+		}
+	}
+	return false
+}
+
+// Applies a sync plan using the upload, download and delete commands.
+type planSyncer struct {
+	serverDetails *config.ServerDetails
+	localDir      string
+	remotePath    string
+	threads       int
+	retries       int
+	success       int
+	failed        int
+}
+
+func (ps *planSyncer) apply(plan *dirsync.Plan) error {
+	uploadSpec := new(spec.SpecFiles)
+	var remoteDeletions, localDeletions []string
+	// The files to download, by the local directory they are downloaded to.
+	downloads := map[string][]string{}
+	for _, action := range plan.Actions {
+		log.Info(fmt.Sprintf("Sync plan: %s %s on the %s side.", action.Change, action.Path, action.Side))
+		remoteFilePath := ps.remotePath + "/" + action.Path
+		localFilePath := filepath.Join(ps.localDir, filepath.FromSlash(action.Path))
+		switch {
+		case action.Side == dirsync.Remote && action.Change == dirsync.Delete:
+			remoteDeletions = append(remoteDeletions, remoteFilePath)
+		case action.Side == dirsync.Remote:
+			group := spec.File{Flat: strconv.FormatBool(true), Recursive: strconv.FormatBool(false)}
+			if err := setExactPattern(&group, toSpecLocalPath(localFilePath), remoteFilePath); err != nil {
+				log.Error(err)
+				ps.failed++
+				continue
+			}
+			uploadSpec.Files = append(uploadSpec.Files, group)
+		case action.Change == dirsync.Delete:
+			localDeletions = append(localDeletions, localFilePath)
+		default:
+			localFileDir := toSpecLocalPath(filepath.Dir(localFilePath)) + "/"
+			downloads[localFileDir] = append(downloads[localFileDir], remoteFilePath)
+		}
+	}
+	// The remote files are matched by AQL queries of their exact paths, so that each batch of files is found by a single search.
+	deleteSpec, err := createExactPathsSpec(remoteDeletions, "")
+	if err != nil {
+		return err
+	}
+	downloadSpec := new(spec.SpecFiles)
+	var localFileDirs []string
+	for localFileDir := range downloads {
+		localFileDirs = append(localFileDirs, localFileDir)
+	}
+	sort.Strings(localFileDirs)
+	for _, localFileDir := range localFileDirs {
+		dirSpec, err := createExactPathsSpec(downloads[localFileDir], localFileDir)
+		if err != nil {
+			return err
+		}
+		downloadSpec.Files = append(downloadSpec.Files, dirSpec.Files...)
+	}
+
+	// A failure of one of the commands does not stop the others, so that as many files as possible are synced.
+	var errs []string
+	for _, step := range []struct {
+		files *spec.SpecFiles
+		run   func(*spec.SpecFiles) error
+	}{{uploadSpec, ps.upload}, {downloadSpec, ps.download}, {deleteSpec, ps.delete}} {
+		if len(step.files.Files) == 0 {
+			continue
+		}
+		if err := step.run(step.files); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	for _, localFilePath := range localDeletions {
+		log.Info("Deleting", localFilePath)
+		if err := os.Remove(localFilePath); err != nil {
+			log.Error(err)
+			ps.failed++
+			continue
+		}
+		ps.success++
+	}
+	if len(errs) > 0 {
+		return errorutils.CheckError(errors.New(strings.Join(errs, "\n")))
+	}
+	return nil
+}
+
+// Returns a spec which matches the files by their exact paths in Artifactory, with an AQL query for each batch of files.
+// The files are downloaded to the target without their directories.
+func createExactPathsSpec(remotePaths []string, target string) (*spec.SpecFiles, error) {
+	pathsSpec := new(spec.SpecFiles)
+	for start := 0; start < len(remotePaths); start += aqlItemsBatchSize {
+		end := start + aqlItemsBatchSize
+		if end > len(remotePaths) {
+			end = len(remotePaths)
+		}
+		condition, err := createAqlItemsCondition(remotePaths[start:end])
+		if err != nil {
+			return nil, err
+		}
+		pathsSpec.Files = append(pathsSpec.Files, spec.File{Aql: serviceutils.Aql{ItemsFind: condition}, Target: target, Flat: strconv.FormatBool(true)})
+	}
+	return pathsSpec, nil
+}
+
+// The local paths of the spec are in the format of the paths provided as arguments.
+func toSpecLocalPath(localPath string) string {
+	localPath = filepath.ToSlash(localPath)
+	if coreutils.IsWindows() {
+		return fixWinPathBySource(localPath, false)
+	}
+	return localPath
+}
+
+func (ps *planSyncer) upload(uploadSpec *spec.SpecFiles) error {
+	uploadCmd := generic.NewUploadCommand()
+	uploadCmd.SetUploadConfiguration(&utils.UploadConfiguration{Threads: ps.threads}).SetSpec(uploadSpec).SetServerDetails(ps.serverDetails).SetRetries(ps.retries)
+	err := execWithProgress(uploadCmd)
+	ps.success += uploadCmd.Result().SuccessCount()
+	ps.failed += uploadCmd.Result().FailCount()
+	return err
+}
+
+func (ps *planSyncer) download(downloadSpec *spec.SpecFiles) error {
+	downloadCmd := generic.NewDownloadCommand()
+	configuration := &utils.DownloadConfiguration{Threads: ps.threads, SplitCount: cliutils.DownloadSplitCount, MinSplitSize: cliutils.DownloadMinSplitKb, Symlink: true}
+	downloadCmd.SetConfiguration(configuration).SetBuildConfiguration(new(utils.BuildConfiguration)).SetSpec(downloadSpec).SetServerDetails(ps.serverDetails).SetRetries(ps.retries)
+	err := execWithProgress(downloadCmd)
+	ps.success += downloadCmd.Result().SuccessCount()
+	ps.failed += downloadCmd.Result().FailCount()
+	return err
+}
+
+func (ps *planSyncer) delete(deleteSpec *spec.SpecFiles) error {
+	deleteCmd := generic.NewDeleteCommand()
+	// The deletions were already confirmed.
+	deleteCmd.SetThreads(ps.threads).SetQuiet(true).SetServerDetails(ps.serverDetails).SetSpec(deleteSpec).SetRetries(ps.retries)
+	err := commands.Exec(deleteCmd)
+	ps.success += deleteCmd.Result().SuccessCount()
+	ps.failed += deleteCmd.Result().FailCount()
+	return err
+}
@@ -0,0 +1,35 @@
+package artifactory
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateExactPathsSpec(t *testing.T) {
+	var remotePaths []string
+	for i := 0; i < aqlItemsBatchSize+1; i++ {
+		remotePaths = append(remotePaths, "repo/dir/file-"+strconv.Itoa(i))
+	}
+	pathsSpec, err := createExactPathsSpec(remotePaths, "local/dir/")
+	assert.NoError(t, err)
+	// The files are matched by a single query for each batch.
+	assert.Len(t, pathsSpec.Files, 2)
+	assert.Equal(t, "local/dir/", pathsSpec.Files[0].Target)
+	assert.Equal(t, "true", pathsSpec.Files[0].Flat)
+
+	// The names are matched as they are, rather than as patterns.
+	pathsSpec, err = createExactPathsSpec([]string{"repo/file(1)*.txt", "repo/a/b/{1}.txt"}, "")
+	assert.NoError(t, err)
+	assert.Len(t, pathsSpec.Files, 1)
+	condition := &struct {
+		Or []map[string]string `json:"$or"`
+	}{}
+	assert.NoError(t, json.Unmarshal([]byte(pathsSpec.Files[0].Aql.ItemsFind), condition))
+	assert.Equal(t, []map[string]string{
+		{"repo": "repo", "path": ".", "name": "file(1)*.txt"},
+		{"repo": "repo", "path": "a/b", "name": "{1}.txt"},
+	}, condition.Or)
+}
@@ -0,0 +1,445 @@
+package artifactory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/audit"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The properties of the files in the trash.
+const (
+	trashOriginalPathProp = "trash.originalPath"
+	trashDeletedAtProp    = "trash.deletedAt"
+	trashDeletedByProp    = "trash.deletedBy"
+	// The keys of all the trash properties, in the form of a properties delete request.
+	trashPropKeys = trashOriginalPathProp + "," + trashDeletedAtProp + "," + trashDeletedByProp
+)
+
+// Each soft delete moves the files to a folder of the trash path, named by the time of the deletion.
+// Under this folder, the files keep their original paths.
+const trashFolderTimeFormat = "20060102T150405.000Z"
+
+// The format of the trash.deletedAt property. The deletion times are in UTC, so they are ordered as strings.
+const trashDeletedAtFormat = "2006-01-02T15:04:05.000Z"
+
+// Returns the trash path, from the --trash-path option or the JFROG_CLI_TRASH_PATH environment variable.
+func getTrashPath(c *cli.Context) (string, error) {
+	trashPath := c.String("trash-path")
+	if trashPath == "" {
+		trashPath = os.Getenv(cliutils.TrashPath)
+	}
+	trashPath = strings.Trim(trashPath, "/")
+	if trashPath == "" {
+		return "", errorutils.CheckError(errors.New("the trash path is missing. Use the --trash-path option or the " + cliutils.TrashPath + " environment variable to set it"))
+	}
+	return trashPath, nil
+}
+
+func getTrashFolderPath(trashPath string, deletedAt time.Time) string {
+	return trashPath + "/" + deletedAt.UTC().Format(trashFolderTimeFormat)
+}
+
+// Moves the files of the spec to the trash, instead of deleting them.
+func softDeleteCmd(c *cli.Context, deleteSpec *spec.SpecFiles) error {
+	trashPath, err := getTrashPath(c)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	serviceManager, err := utils.CreateServiceManager(rtDetails, retries, false)
+	if err != nil {
+		return err
+	}
+	items, err := searchSoftDeletedFiles(serviceManager, deleteSpec, trashPath)
+	if err != nil {
+		return err
+	}
+
+	deletedAt := time.Now()
+	trashFolderPath := getTrashFolderPath(trashPath, deletedAt)
+	trashProps := serviceutils.NewProperties()
+	trashProps.AddProperty(trashDeletedAtProp, deletedAt.UTC().Format(trashDeletedAtFormat))
+	if rtDetails.User != "" {
+		trashProps.AddProperty(trashDeletedByProp, rtDetails.User)
+	}
+	if len(items) > 0 && !c.Bool("dry-run") && !cliutils.GetQuietValue(c) &&
+		!coreutils.AskYesNo(fmt.Sprintf("Moving %d files to the trash path %s. Are you sure you want to continue?\n"+
+			"You can avoid this confirmation message by adding --quiet to the command.", len(items), trashPath), false) {
+		return cliutils.PrintSummaryReport(0, 0, nil)
+	}
+	client := &trashClient{artifactoryMoveCopyClient{serviceManager: serviceManager}}
+	success, failed := 0, 0
+	for _, item := range items {
+		originalPath := item.GetItemRelativePath()
+		trashItemPath := trashFolderPath + "/" + originalPath
+		if c.Bool("dry-run") {
+			log.Info("[Dry run] Moving " + originalPath + " to the trash: " + trashItemPath)
+			success++
+			continue
+		}
+		if err = softDeleteItem(client, originalPath, trashItemPath, trashProps); err != nil {
+			log.Error(err)
+			failed++
+			continue
+		}
+		success++
+	}
+	err = cliutils.PrintSummaryReport(success, failed, nil)
+	return cliutils.GetCliError(err, success, failed, isFailNoOp(c))
+}
+
+// Moves the file to the trash. The trash properties are set before the move, which keeps them,
+// so that every file in the trash can be restored.
+func softDeleteItem(client *trashClient, originalPath, trashItemPath string, trashProps *serviceutils.Properties) error {
+	props := serviceutils.NewProperties()
+	props.AddProperty(trashOriginalPathProp, originalPath)
+	if err := client.setProps(originalPath, props.ToEncodedString(true)+";"+trashProps.ToEncodedString(true)); err != nil {
+		return err
+	}
+	err := client.moveOrCopy(services.MOVE, originalPath, trashItemPath)
+	if err != nil {
+		// The file was not deleted, so it should not keep the trash properties.
+		if deleteErr := client.deleteProps(originalPath, trashPropKeys); deleteErr != nil {
+			log.Warn("Couldn't remove the trash properties of " + originalPath + ": " + deleteErr.Error())
+		}
+	}
+	return err
+}
+
+// Returns the files of the spec, except for files which are already in the trash.
+func searchSoftDeletedFiles(serviceManager artifactory.ArtifactoryServicesManager, deleteSpec *spec.SpecFiles, trashPath string) ([]*serviceutils.ResultItem, error) {
+	var items []*serviceutils.ResultItem
+	paths := map[string]bool{}
+	for i := range deleteSpec.Files {
+		file := deleteSpec.Get(i)
+		params, err := file.ToArtifactoryCommonParams()
+		if err != nil {
+			return nil, err
+		}
+		if params.Recursive, err = file.IsRecursive(true); err != nil {
+			return nil, err
+		}
+		reader, err := serviceManager.SearchFiles(services.SearchParams{ArtifactoryCommonParams: params})
+		if err != nil {
+			return nil, err
+		}
+		for item := new(serviceutils.ResultItem); reader.NextRecord(item) == nil; item = new(serviceutils.ResultItem) {
+			itemPath := item.GetItemRelativePath()
+			if item.Type == "folder" || paths[itemPath] || strings.HasPrefix(itemPath, trashPath+"/") {
+				continue
+			}
+			paths[itemPath] = true
+			items = append(items, item)
+		}
+		err = reader.GetError()
+		reader.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// A file in the trash.
+type trashItem struct {
+	path         string
+	originalPath string
+	deletedAt    string
+}
+
+func restoreCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	trashPath, err := getTrashPath(c)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	serviceManager, err := utils.CreateServiceManager(rtDetails, retries, false)
+	if err != nil {
+		return err
+	}
+	items, err := searchTrash(serviceManager, trashPath)
+	if err != nil {
+		return err
+	}
+	restoredItems, err := getRestoredItems(items, c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	client := &trashClient{artifactoryMoveCopyClient{serviceManager: serviceManager}}
+	success, failed := 0, 0
+	for _, item := range restoredItems {
+		if c.Bool("dry-run") {
+			log.Info("[Dry run] Restoring " + item.originalPath + " from the trash: " + item.path)
+			success++
+			continue
+		}
+		if err = restoreItem(client, item); err != nil {
+			log.Error(err)
+			failed++
+			continue
+		}
+		success++
+	}
+	err = cliutils.PrintSummaryReport(success, failed, nil)
+	return cliutils.GetCliError(err, success, failed, isFailNoOp(c))
+}
+
+// Moves the file back to its original path, unless a file was created in this path since it was deleted.
+func restoreItem(client *trashClient, item *trashItem) error {
+	exists, err := client.exists(item.originalPath)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errorutils.CheckError(errors.New("cannot restore " + item.originalPath + ", since a file already exists in this path"))
+	}
+	if err = client.moveOrCopy(services.MOVE, item.path, item.originalPath); err != nil {
+		return err
+	}
+	return client.deleteProps(item.originalPath, trashPropKeys)
+}
+
+// Returns the files in the trash.
+func searchTrash(serviceManager artifactory.ArtifactoryServicesManager, trashPath string) ([]*trashItem, error) {
+	params := services.NewSearchParams()
+	params.Pattern = trashPath + "/"
+	params.Recursive = true
+	reader, err := serviceManager.SearchFiles(params)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	var items []*trashItem
+	for item := new(serviceutils.ResultItem); reader.NextRecord(item) == nil; item = new(serviceutils.ResultItem) {
+		if item.Type == "folder" {
+			continue
+		}
+		trashed := &trashItem{path: item.GetItemRelativePath()}
+		for _, property := range item.Properties {
+			switch property.Key {
+			case trashOriginalPathProp:
+				trashed.originalPath = property.Value
+			case trashDeletedAtProp:
+				trashed.deletedAt = property.Value
+			}
+		}
+		if trashed.originalPath == "" {
+			log.Debug("Skipping " + trashed.path + ", which was not moved to the trash by a soft delete.")
+			continue
+		}
+		items = append(items, trashed)
+	}
+	return items, reader.GetError()
+}
+
+// Returns the files whose original paths match the wildcard pattern.
+// If a path was deleted more than once, its most recently deleted file is restored.
+func getRestoredItems(items []*trashItem, pattern string) ([]*trashItem, error) {
+	patternRegExp, err := regexp.Compile(clientutils.WildcardPathToRegExp(strings.TrimPrefix(pattern, "/")))
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	latestItems := map[string]*trashItem{}
+	for _, item := range items {
+		if !patternRegExp.MatchString(item.originalPath) {
+			continue
+		}
+		if latest, exists := latestItems[item.originalPath]; !exists || item.deletedAt > latest.deletedAt {
+			latestItems[item.originalPath] = item
+		}
+	}
+	var restoredItems []*trashItem
+	for _, item := range latestItems {
+		restoredItems = append(restoredItems, item)
+	}
+	sort.Slice(restoredItems, func(i, j int) bool {
+		return restoredItems[i].originalPath < restoredItems[j].originalPath
+	})
+	return restoredItems, nil
+}
+
+func trashPurgeCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if c.String("older-than") == "" {
+		return cliutils.PrintHelpAndReturnError("The --older-than option is mandatory.", c)
+	}
+	olderThan, err := audit.ParseSince(c.String("older-than"), time.Now())
+	if err != nil {
+		return err
+	}
+	trashPath, err := getTrashPath(c)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	serviceManager, err := utils.CreateServiceManager(rtDetails, retries, false)
+	if err != nil {
+		return err
+	}
+	client := &trashClient{artifactoryMoveCopyClient{serviceManager: serviceManager}}
+	folders, err := client.listFolders(trashPath)
+	if err != nil {
+		return err
+	}
+	purgedFolders := getPurgedTrashFolders(folders, olderThan)
+	if len(purgedFolders) > 0 && !c.Bool("dry-run") && !cliutils.GetQuietValue(c) &&
+		!coreutils.AskYesNo(fmt.Sprintf("Purging the trash permanently deletes the files deleted before %s. Are you sure you want to continue?\n"+
+			"You can avoid this confirmation message by adding --quiet to the command.", olderThan.Format(time.RFC3339)), false) {
+		return nil
+	}
+	success, failed := 0, 0
+	for _, folder := range purgedFolders {
+		folderPath := trashPath + "/" + folder
+		if c.Bool("dry-run") {
+			log.Info("[Dry run] Deleting " + folderPath)
+			success++
+			continue
+		}
+		log.Info("Deleting " + folderPath)
+		if err = client.delete(folderPath); err != nil {
+			log.Error(err)
+			failed++
+			continue
+		}
+		success++
+	}
+	err = cliutils.PrintSummaryReport(success, failed, nil)
+	return cliutils.GetCliError(err, success, failed, false)
+}
+
+// Returns the folders of the trash path which hold files deleted before the given time.
+func getPurgedTrashFolders(folders []string, olderThan time.Time) []string {
+	var purgedFolders []string
+	for _, folder := range folders {
+		deletedAt, err := time.Parse(trashFolderTimeFormat, folder)
+		if err != nil {
+			log.Debug("Skipping " + folder + ", which was not created by a soft delete.")
+			continue
+		}
+		if deletedAt.Before(olderThan) {
+			purgedFolders = append(purgedFolders, folder)
+		}
+	}
+	sort.Strings(purgedFolders)
+	return purgedFolders
+}
+
+// Adds the properties and folder operations of the trash to the client of the atomic move and copy.
+type trashClient struct {
+	artifactoryMoveCopyClient
+}
+
+// Sets the properties, which are encoded in the form of "key1=value1;key2=value2".
+func (tc *trashClient) setProps(itemPath, encodedProps string) error {
+	return tc.sendPropsRequest(http.MethodPut, itemPath, encodedProps)
+}
+
+// Deletes the properties, whose keys are in the form of "key1,key2".
+func (tc *trashClient) deleteProps(itemPath, keys string) error {
+	return tc.sendPropsRequest(http.MethodDelete, itemPath, keys)
+}
+
+func (tc *trashClient) sendPropsRequest(method, itemPath, encodedProps string) error {
+	requestUrl, err := serviceutils.BuildArtifactoryUrl(tc.getUrl(), path.Join("api", "storage", itemPath), map[string]string{})
+	if err != nil {
+		return err
+	}
+	requestUrl += "?properties=" + encodedProps + "&recursive=0"
+	httpClientDetails := tc.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	var resp *http.Response
+	var body []byte
+	if method == http.MethodDelete {
+		resp, body, err = tc.serviceManager.Client().SendDelete(requestUrl, nil, &httpClientDetails)
+	} else {
+		resp, body, err = tc.serviceManager.Client().SendPut(requestUrl, nil, &httpClientDetails)
+	}
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return errorutils.CheckError(errors.New("failed updating the properties of " + itemPath + ". Artifactory response: " + resp.Status + "\n" + clientutils.IndentJson(body)))
+	}
+	return nil
+}
+
+// Returns the names of the folders directly under the path, or an empty list if the path doesn't exist.
+func (tc *trashClient) listFolders(folderPath string) ([]string, error) {
+	requestUrl, err := serviceutils.BuildArtifactoryUrl(tc.getUrl(), path.Join("api", "storage", folderPath), map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	httpClientDetails := tc.serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, _, err := tc.serviceManager.Client().SendGet(requestUrl, true, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorutils.CheckError(errors.New("failed listing " + folderPath + ". Artifactory response: " + resp.Status + "\n" + clientutils.IndentJson(body)))
+	}
+	folderInfo := &struct {
+		Children []struct {
+			Uri    string `json:"uri"`
+			Folder bool   `json:"folder"`
+		} `json:"children"`
+	}{}
+	if err = json.Unmarshal(body, folderInfo); errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	var folders []string
+	for _, child := range folderInfo.Children {
+		if child.Folder {
+			folders = append(folders, strings.TrimPrefix(child.Uri, "/"))
+		}
+	}
+	return folders, nil
+}
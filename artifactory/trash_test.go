@@ -0,0 +1,71 @@
+package artifactory
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTrashFolderPath(t *testing.T) {
+	deletedAt := time.Date(2021, 1, 31, 10, 20, 30, 400000000, time.FixedZone("UTC+2", 2*60*60))
+	assert.Equal(t, "trash-repo/deleted/20210131T082030.400Z", getTrashFolderPath("trash-repo/deleted", deletedAt))
+}
+
+func TestGetRestoredItems(t *testing.T) {
+	items := []*trashItem{
+		{path: "trash/20210101T000000.000Z/repo/a/1.jar", originalPath: "repo/a/1.jar", deletedAt: "2021-01-01T00:00:00.000Z"},
+		{path: "trash/20210102T000000.000Z/repo/a/1.jar", originalPath: "repo/a/1.jar", deletedAt: "2021-01-02T00:00:00.000Z"},
+		{path: "trash/20210101T000000.000Z/repo/a/2.jar", originalPath: "repo/a/2.jar", deletedAt: "2021-01-01T00:00:00.000Z"},
+		{path: "trash/20210101T000000.000Z/repo/b/1.jar", originalPath: "repo/b/1.jar", deletedAt: "2021-01-01T00:00:00.000Z"},
+	}
+	restoredItems, err := getRestoredItems(items, "repo/a/")
+	assert.NoError(t, err)
+	assert.Equal(t, []*trashItem{items[1], items[2]}, restoredItems)
+
+	restoredItems, err = getRestoredItems(items, "repo/*/1.jar")
+	assert.NoError(t, err)
+	assert.Equal(t, []*trashItem{items[1], items[3]}, restoredItems)
+
+	restoredItems, err = getRestoredItems(items, "repo/c/*")
+	assert.NoError(t, err)
+	assert.Empty(t, restoredItems)
+}
+
+func TestGetPurgedTrashFolders(t *testing.T) {
+	folders := []string{"20210103T000000.000Z", "20210101T000000.000Z", "other", "20210102T120000.000Z"}
+	olderThan := time.Date(2021, 1, 2, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, []string{"20210101T000000.000Z"}, getPurgedTrashFolders(folders, olderThan))
+	assert.Equal(t, []string{"20210101T000000.000Z", "20210102T120000.000Z", "20210103T000000.000Z"}, getPurgedTrashFolders(folders, olderThan.AddDate(0, 0, 7)))
+}
+
+func TestSoftDeleteItem(t *testing.T) {
+	var requests []string
+	failMove := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if failMove && r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusConflict)
+		}
+	}))
+	defer server.Close()
+	serviceManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, 0, false)
+	assert.NoError(t, err)
+	client := &trashClient{artifactoryMoveCopyClient{serviceManager: serviceManager}}
+	trashProps := serviceutils.NewProperties()
+	trashProps.AddProperty(trashDeletedAtProp, "2021-01-01T00:00:00.000Z")
+
+	// The trash properties are set before the move, so that the file is never in the trash without them.
+	assert.NoError(t, softDeleteItem(client, "repo/a/1.jar", "trash/20210101T000000.000Z/repo/a/1.jar", trashProps))
+	assert.Equal(t, []string{"PUT /api/storage/repo/a/1.jar", "POST /api/move/repo/a/1.jar"}, requests)
+
+	// A file which is not moved doesn't keep the trash properties.
+	requests, failMove = nil, true
+	assert.Error(t, softDeleteItem(client, "repo/a/1.jar", "trash/20210101T000000.000Z/repo/a/1.jar", trashProps))
+	assert.Equal(t, []string{"PUT /api/storage/repo/a/1.jar", "POST /api/move/repo/a/1.jar", "DELETE /api/storage/repo/a/1.jar"}, requests)
+}
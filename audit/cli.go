@@ -0,0 +1,51 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/codegangsta/cli"
+	corecommon "github.com/jfrog/jfrog-cli-core/docs/common"
+	"github.com/jfrog/jfrog-cli/docs/audit/show"
+	"github.com/jfrog/jfrog-cli/utils/audit"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func GetCommands() []cli.Command {
+	return cliutils.GetSortedCommands(cli.CommandsByName{
+		{
+			Name:         "show",
+			Aliases:      []string{"s"},
+			Description:  show.Description,
+			Flags:        cliutils.GetCommandFlags(cliutils.AuditShow),
+			HelpName:     corecommon.CreateUsage("audit show", show.Description, show.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return showCmd(c)
+			},
+		},
+	})
+}
+
+func showCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	since, err := audit.ParseSince(c.String("since"), time.Now())
+	if err != nil {
+		return err
+	}
+	entries, err := audit.Read(since)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(entries)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	log.Output(clientutils.IndentJson(content))
+	return nil
+}
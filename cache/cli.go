@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/codegangsta/cli"
+	corecommon "github.com/jfrog/jfrog-cli-core/docs/common"
+	"github.com/jfrog/jfrog-cli/docs/cache/prune"
+	"github.com/jfrog/jfrog-cli/docs/cache/stats"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/downloadcache"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func GetCommands() []cli.Command {
+	return cliutils.GetSortedCommands(cli.CommandsByName{
+		{
+			Name:         "stats",
+			Description:  stats.Description,
+			HelpName:     corecommon.CreateUsage("cache stats", stats.Description, stats.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return statsCmd(c)
+			},
+		},
+		{
+			Name:         "prune",
+			Description:  prune.Description,
+			Flags:        cliutils.GetCommandFlags(cliutils.CachePrune),
+			HelpName:     corecommon.CreateUsage("cache prune", prune.Description, prune.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return pruneCmd(c)
+			},
+		},
+	})
+}
+
+func getCache() (*downloadcache.Cache, error) {
+	cache := downloadcache.GetCache()
+	if cache == nil {
+		return nil, errorutils.CheckError(errors.New("the download cache is not configured. Set the " + downloadcache.CacheDirEnv + " environment variable to the cache directory"))
+	}
+	return cache, nil
+}
+
+func statsCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	cache, err := getCache()
+	if err != nil {
+		return err
+	}
+	cacheStats, err := cache.Stats()
+	if err != nil {
+		return err
+	}
+	return printJson(cacheStats)
+}
+
+func pruneCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if c.String("max-size") == "" {
+		return cliutils.PrintHelpAndReturnError("The --max-size option is mandatory.", c)
+	}
+	maxSize, err := downloadcache.ParseSize(c.String("max-size"))
+	if err != nil {
+		return err
+	}
+	cache, err := getCache()
+	if err != nil {
+		return err
+	}
+	result, err := cache.Prune(maxSize)
+	if err != nil {
+		return err
+	}
+	return printJson(result)
+}
+
+func printJson(output interface{}) error {
+	content, err := json.Marshal(output)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	log.Output(clientutils.IndentJson(content))
+	return nil
+}
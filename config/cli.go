@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -8,12 +9,15 @@ import (
 	"github.com/codegangsta/cli"
 	"github.com/jfrog/jfrog-cli-core/common/commands"
 	corecommon "github.com/jfrog/jfrog-cli-core/docs/common"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
 	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
 	"github.com/jfrog/jfrog-cli/docs/config/add"
 	"github.com/jfrog/jfrog-cli/docs/config/edit"
 	"github.com/jfrog/jfrog-cli/docs/config/remove"
 	"github.com/jfrog/jfrog-cli/docs/config/use"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 
 	"github.com/jfrog/jfrog-cli/docs/config/exportcmd"
 	"github.com/jfrog/jfrog-cli/docs/config/importcmd"
@@ -159,9 +163,49 @@ func showCmd(c *cli.Context) error {
 	if c.NArg() == 1 {
 		serverId = c.Args()[0]
 	}
+	if cliutils.GetOutputFormat() != "" {
+		return printConfigs(serverId)
+	}
 	return commands.ShowConfig(serverId)
 }
 
+// Prints the servers configuration as JSON, to be rendered in the requested output format.
+func printConfigs(serverId string) error {
+	var configuration []*config.ServerDetails
+	if serverId != "" {
+		details, err := config.GetSpecificConfig(serverId, true, false)
+		if err != nil {
+			return err
+		}
+		configuration = []*config.ServerDetails{details}
+	} else {
+		var err error
+		configuration, err = config.GetAllServersConfigs()
+		if err != nil {
+			return err
+		}
+	}
+	for _, details := range configuration {
+		maskSecret(&details.Password)
+		maskSecret(&details.ApiKey)
+		maskSecret(&details.AccessToken)
+		maskSecret(&details.RefreshToken)
+		maskSecret(&details.SshPassphrase)
+	}
+	content, err := json.Marshal(configuration)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	log.Output(clientutils.IndentJson(content))
+	return nil
+}
+
+func maskSecret(secret *string) {
+	if *secret != "" {
+		*secret = "***"
+	}
+}
+
 func deleteCmd(c *cli.Context) error {
 	if c.NArg() > 1 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
@@ -0,0 +1,19 @@
+package builddiff
+
+const Description = "Compare two published build-infos of the same build."
+
+var Usage = []string{"jfrog rt build-diff [command options] <build name> <build number> <build number>"}
+
+const Arguments string = `	build name
+		Build name.
+
+	build number
+		The number of the first build to compare.
+
+	build number
+		The number of the second build to compare with the first.
+
+	The modules, artifacts and dependencies of the builds are compared by their IDs and sha1 checksums.
+	Dependencies are compared without their versions, so that a new version of a dependency is reported as a change of its version.
+	The environment variables and the VCS revisions and branches of the builds are also compared.
+	The differences are printed as JSON. Use the global --format option to print them as a table.`
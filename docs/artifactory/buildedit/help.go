@@ -0,0 +1,16 @@
+package buildedit
+
+const Description = "Edit the build-info collected for a build, before it is published."
+
+var Usage = []string{"jfrog rt build-edit [command options] <build name> <build number>"}
+
+const Arguments string = `	build name
+		Build name.
+
+	build number
+		Build number.
+
+	Use the --remove-module option to remove a module, with its artifacts and dependencies, from the collected build-info.
+	Use the --props option to set properties of the build. Properties are published as environment variables, so a property overrides a collected environment variable of the same name.
+	The build-publish command filters the properties by its --env-include and --env-exclude options, as it does the environment variables. By default, properties whose keys contain password, psw, secret, key or token are not published, and the command warns about them.
+	Run the build-show command to view the collected build-info.`
@@ -0,0 +1,14 @@
+package buildexport
+
+const Description = "Export the build-info collected for a build to a file, to be imported by the agent which publishes the build."
+
+var Usage = []string{"jfrog rt build-export [command options] <build name> <build number>"}
+
+const Arguments string = `	build name
+		Build name.
+
+	build number
+		Build number.
+
+	The partial build-info collected by the build commands, and the build-info generated by the build tools, are exported.
+	Use the build-import command to import the exported files of several agents, before publishing the build once.`
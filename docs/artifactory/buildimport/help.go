@@ -0,0 +1,16 @@
+package buildimport
+
+const Description = "Import the build-info exported by the build-export command."
+
+var Usage = []string{"jfrog rt build-import [command options]",
+	"jfrog rt build-import [command options] <build name> <build number>"}
+
+const Arguments string = `	build name
+		[Optional] Build name. If not provided, the build-info is imported to the build of the exported files.
+
+	build number
+		[Optional] Build number. If not provided, the build-info is imported to the build of the exported files.
+		The build name and build number are provided together, or not at all.
+
+	The build-info of all files is added to the build-info collected for the build. Build-info which was already collected or imported is skipped.
+	When the build is published, its modules are merged by their IDs, and duplicate artifacts and dependencies are published once.`
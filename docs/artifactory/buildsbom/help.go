@@ -0,0 +1,15 @@
+package buildsbom
+
+const Description = "Generate a CycloneDX or SPDX SBOM of a published build-info."
+
+var Usage = []string{"jfrog rt build-sbom [command options] <build name> <build number>"}
+
+const Arguments string = `	build name
+		Build name.
+
+	build number
+		Build number.
+
+	The modules of the build and their dependencies are the components of the SBOM, identified by package URLs (purls).
+	The purls are derived from the types of the modules, such as Maven, Gradle, npm, Go, pip, NuGet and Docker.
+	Other dependencies, such as files added by 'jfrog rt build-add-dependencies', are identified by generic purls with their sha1 checksums.`
@@ -0,0 +1,15 @@
+package buildshow
+
+const Description = "Show the build-info collected for a build, before it is published."
+
+var Usage = []string{"jfrog rt build-show [command options] <build name> <build number>"}
+
+const Arguments string = `	build name
+		Build name.
+
+	build number
+		Build number.
+
+	The partial build-info collected by the build commands is aggregated and printed as JSON, including the modules, artifacts, dependencies, environment variables and VCS details.
+	The environment variables are filtered by the --env-include and --env-exclude options, as they are by the build-publish command.
+	Unlike the build-publish command, the modules, artifacts and dependencies are shown in the order they were collected, and the Artifactory user who publishes the build is not shown.`
@@ -0,0 +1,25 @@
+package cleanup
+
+const Description = "Delete files from Artifactory according to the rules of a cleanup policy."
+
+var Usage = []string{"jfrog rt cleanup --policy=<policy file> [command options]"}
+
+const Arguments string = `	policy file
+		A YAML file with the cleanup rules, in the following format:
+
+		rules:
+		  - name: old-releases
+		    repo: libs-release-local
+		    pattern: "org/acme/*"
+		    excludeProps: "keep=true"
+		    keepLastVersions: 5
+		  - name: unused-snapshots
+		    repo: libs-snapshot-local
+		    notDownloadedDays: 90
+
+	Each rule selects the files of its repository pattern which match all of its conditions.
+	keepLastVersions selects the files of all of the versions of each package, except for its last versions.
+	The folder of a file is its version, and the parent folder of the version is its package.
+	notDownloadedDays selects the files which were not downloaded in the last days, or which were never downloaded and created before them.
+	Only files with the props properties are selected, and files with the excludeProps properties are never selected.
+	Use --dry-run to report the selected files and the reclaimed bytes without deleting them.`
@@ -0,0 +1,19 @@
+package diff
+
+const Description = "Compare the files of two repository paths, or of a repository path and a local directory."
+
+var Usage = []string{"jfrog rt diff [command options] <source path> <target path>"}
+
+const Arguments string = `	source path
+		Specifies a path in Artifactory, in the following format: <repository name>/<repository path>,
+		or a path to a local directory. You can use wildcards to specify multiple artifacts in Artifactory.
+
+	target path
+		The path to compare with the source path, in the same format.
+
+	A path of an existing local directory, such as 'build/libs', is compared as a local directory.
+	The --recursive option applies to both paths. The --props, --exclude-props and --exclusions options filter files in Artifactory only,
+	so they cannot be used to compare a local directory.
+	Files are compared by their paths relative to the compared paths, and by their sha1 checksums.
+	The properties of files are also compared, if both paths are in Artifactory.
+	The differences are printed as JSON. Use the global --format option to print them as a table.`
@@ -0,0 +1,11 @@
+package restore
+
+const Description = "Restore files which were moved to the trash by 'jfrog rt delete --soft'."
+
+var Usage = []string{"jfrog rt restore [command options] <restore pattern>"}
+
+const Arguments string = `	restore pattern
+		Specifies the original paths of the files to restore, in the following format: <repository name>/<repository path>.
+		You can use wildcards to specify multiple files.
+		Each file is moved back to its original path, unless a file was created in this path since it was deleted.
+		If a path was deleted more than once, the most recently deleted file is restored.`
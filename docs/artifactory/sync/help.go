@@ -0,0 +1,16 @@
+package sync
+
+const Description = "Synchronize a local directory with a path in Artifactory, in both directions."
+
+var Usage = []string{"jfrog rt sync [command options] <local directory> <repository path>"}
+
+const Arguments string = `	local directory
+		Path to a local directory to synchronize.
+
+	repository path
+		Specifies the path in Artifactory to synchronize with the local directory,
+		in the following format: <repository name>/<repository path>.
+
+	Files are compared by their sha1 checksums. Files which were added or updated on one side are copied to the other side.
+	Files which were deleted from one side since the last sync are deleted from the other side.
+	A file which changed on both sides since the last sync is a conflict, which is resolved according to the --conflict-policy option.`
@@ -0,0 +1,5 @@
+package trashpurge
+
+const Description = "Permanently delete the files which were moved to the trash by 'jfrog rt delete --soft' before a given time."
+
+var Usage = []string{"jfrog rt trash purge --older-than=<duration|date> [command options]"}
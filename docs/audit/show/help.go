@@ -0,0 +1,5 @@
+package show
+
+const Description string = `Shows the audit log, which records the commands that modified or deleted data, such as 'rt delete' and 'rt repo-delete'.`
+
+var Usage = []string{"jfrog audit show [command options]"}
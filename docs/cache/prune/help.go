@@ -0,0 +1,5 @@
+package prune
+
+const Description string = `Removes the least recently used files from the download cache, configured by the JFROG_CLI_CACHE_DIR environment variable, until it fits the maximum size.`
+
+var Usage = []string{"jfrog cache prune --max-size=<size>"}
@@ -0,0 +1,5 @@
+package stats
+
+const Description string = `Shows the number and total size of the files in the download cache, configured by the JFROG_CLI_CACHE_DIR environment variable.`
+
+var Usage = []string{"jfrog cache stats"}
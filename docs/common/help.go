@@ -37,6 +37,24 @@ const GlobalEnvVars string = `	JFROG_CLI_LOG_LEVEL
 		[Default: *password*;*psw*;*secret*;*key*;*token*] 
 		List of case insensitive patterns in the form of "value1;value2;...". Environment variables match those patterns will be excluded. This environment variable is used by the "jfrog rt build-publish" command, in case the --env-exclude command option is not sent.
 
+	JFROG_CLI_AUDIT_LOG
+		[Default: true]
+		If true, the commands which modify or delete data, such as "jfrog rt delete" and "jfrog rt repo-delete", are recorded in the audit.jsonl file under the JFrog CLI home directory.
+		Use the "jfrog audit show" command to view the recorded commands.
+
+	JFROG_CLI_CACHE_DIR
+		If set, the "jfrog rt download" command keeps the files it downloads in this directory, stored by their checksums,
+		and takes files from it instead of downloading them again. The directory can be shared by several jobs.
+		Use the "jfrog cache stats" and "jfrog cache prune" commands to manage the cached files.
+
+	JFROG_CLI_CACHE_HARDLINKS
+		[Default: false]
+		If true, the files taken from the download cache are hard-linked rather than copied. Hard-linked files must not be modified.
+
+	JFROG_CLI_TRASH_PATH
+		The trash path in Artifactory, in the form of <repository name>/<repository path>, used by the "jfrog rt delete --soft",
+		"jfrog rt restore" and "jfrog rt trash purge" commands, unless the --trash-path command option is sent.
+
 	CI
 		[Default: false]
 		If true, disables interactive prompts and progress bar.
@@ -2,18 +2,29 @@ package install
 
 const Description = "Install a JFrog CLI plugin."
 
-var Usage = []string{"jfrog plugin install <plugin name and version>"}
+var Usage = []string{"jfrog plugin install <plugin name and version>", "jfrog plugin install --from-manifest"}
 
 const Arguments string = `	plugin name and version
 		Specifies the name and version of the JFrog CLI Plugin you wish to install from the plugins registry.
 		The version should be specified after a '@' separator, such as: 'hello-frog@1.0.0'. 
-		To download the latest version, specify the plugin name only.`
+		To download the latest version, specify the plugin name only.
+		Plugins declared in the manifest must specify their versions, since they are locked by their versions.
+		If the plugin was not published for the architecture of this machine, a compatible architecture is installed instead,
+		such as the darwin/amd64 plugin on Apple Silicon machines.
+		Should not be provided when the --from-manifest option is used.`
 
 const EnvVar string = `	JFROG_CLI_PLUGINS_SERVER
 		[Default: Official JFrog CLI Plugins registry]
 		Configured Artifactory server ID from which to download JFrog CLI Plugins.
+		Ignored when the --from-manifest option is used. The server ID is then taken from the manifest.
 
 	JFROG_CLI_PLUGINS_REPO
 		[Default: 'jfrog-cli-plugins']
 		Can be optionally used with the JFROG_CLI_PLUGINS_SERVER environment variable.
-		Determines the name of the local repository to use.`
+		Determines the name of the local repository to use.
+		Ignored when the --from-manifest option is used. The repository is then taken from the manifest.
+
+	JFROG_CLI_PLUGINS_PUBLIC_KEY
+		[Optional]
+		Path to an ed25519 public key in PEM format.
+		If provided, the plugin is installed only if its name, version, architecture and checksum are signed by the matching private key.`
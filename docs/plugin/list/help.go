@@ -0,0 +1,5 @@
+package list
+
+const Description = "List the installed JFrog CLI plugins."
+
+var Usage = []string{"jfrog plugin list"}
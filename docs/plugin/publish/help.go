@@ -6,6 +6,8 @@ var Usage = []string{"jfrog plugin publish <plugin name> <plugin version>"}
 
 const Arguments string = `	plugin name
 		Specifies the name of the JFrog CLI Plugin you wish to publish. You should run this command from the plugin's directory.
+		The plugin is built for all supported architectures, unless the --prebuilt-dir option is provided.
+		The executables of all architectures are prepared before any of them is uploaded.
 
 	plugin version
 		Specifies the version of the JFrog CLI Plugin you wish to publish.`
@@ -18,4 +20,10 @@ const EnvVar string = `	JFROG_CLI_PLUGINS_SERVER
 	JFROG_CLI_PLUGINS_REPO
 		[Default: 'jfrog-cli-plugins']
 		Can be optionally used with the JFROG_CLI_PLUGINS_SERVER environment variable.
-		Determines the name of the local repository to use.`
+		Determines the name of the local repository to use.
+
+	JFROG_CLI_PLUGINS_SIGNING_KEY
+		[Optional]
+		Path to an ed25519 private key in PEM format (PKCS #8).
+		If provided, the plugin's name, version, architecture and sha256 checksum are signed with this key,
+		and the signature is published next to each plugin executable.`
@@ -0,0 +1,9 @@
+package update
+
+const Description = "Update an installed JFrog CLI plugin to its latest version."
+
+var Usage = []string{"jfrog plugin update <plugin name>", "jfrog plugin update --all"}
+
+const Arguments string = `	plugin name
+		Specifies the name of the installed JFrog CLI Plugin you wish to update.
+		The plugin is updated from the registry it was installed from, if a newer version exists.`
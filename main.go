@@ -15,9 +15,12 @@ import (
 
 	"github.com/codegangsta/cli"
 	"github.com/jfrog/jfrog-cli/artifactory"
+	"github.com/jfrog/jfrog-cli/audit"
 	"github.com/jfrog/jfrog-cli/bintray"
+	"github.com/jfrog/jfrog-cli/cache"
 	"github.com/jfrog/jfrog-cli/completion"
 	"github.com/jfrog/jfrog-cli/missioncontrol"
+	auditutils "github.com/jfrog/jfrog-cli/utils/audit"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-cli/xray"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
@@ -99,10 +102,17 @@ func execMain() error {
 	args := os.Args
 	app.EnableBashCompletion = true
 	app.Commands = getCommands()
+	app.Flags = cliutils.GetCommandFlags(cliutils.Global)
+	app.Before = func(c *cli.Context) error {
+		return cliutils.SetOutputFormat(c.GlobalString("format"))
+	}
 	cli.CommandHelpTemplate = commandHelpTemplate
 	cli.AppHelpTemplate = appHelpTemplate
 	cli.SubcommandHelpTemplate = subcommandHelpTemplate
 	err := app.Run(args)
+	if flushErr := cliutils.FlushOutput(); flushErr != nil && err == nil {
+		err = flushErr
+	}
 	return err
 }
 
@@ -144,6 +154,16 @@ func getCommands() []cli.Command {
 			Description: "Config commands",
 			Subcommands: config.GetCommands(),
 		},
+		{
+			Name:        cliutils.CmdAudit,
+			Description: "Audit log commands",
+			Subcommands: audit.GetCommands(),
+		},
+		{
+			Name:        cliutils.CmdCache,
+			Description: "Download cache commands",
+			Subcommands: cache.GetCommands(),
+		},
 
 		{
 			Name:         "ci-setup",
@@ -157,5 +177,5 @@ func getCommands() []cli.Command {
 			},
 		},
 	}
-	return append(cliNameSpaces, utils.GetPlugins()...)
+	return utils.AddPlugins(auditutils.AddAuditing(cliNameSpaces))
 }
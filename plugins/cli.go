@@ -5,8 +5,10 @@ import (
 	corecommon "github.com/jfrog/jfrog-cli-core/docs/common"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	installdocs "github.com/jfrog/jfrog-cli/docs/plugin/install"
+	listdocs "github.com/jfrog/jfrog-cli/docs/plugin/list"
 	publishdocs "github.com/jfrog/jfrog-cli/docs/plugin/publish"
 	uninstalldocs "github.com/jfrog/jfrog-cli/docs/plugin/uninstall"
+	updatedocs "github.com/jfrog/jfrog-cli/docs/plugin/update"
 	"github.com/jfrog/jfrog-cli/plugins/commands"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 )
@@ -16,6 +18,7 @@ func GetCommands() []cli.Command {
 		{
 			Name:         "install",
 			Aliases:      []string{"i"},
+			Flags:        cliutils.GetCommandFlags(cliutils.PluginInstall),
 			Description:  installdocs.Description,
 			HelpName:     corecommon.CreateUsage("plugin install", installdocs.Description, installdocs.Usage),
 			UsageText:    installdocs.Arguments,
@@ -40,6 +43,7 @@ func GetCommands() []cli.Command {
 		{
 			Name:         "publish",
 			Aliases:      []string{"p"},
+			Flags:        cliutils.GetCommandFlags(cliutils.PluginPublish),
 			Description:  publishdocs.Description,
 			HelpName:     corecommon.CreateUsage("plugin publish", publishdocs.Description, publishdocs.Usage),
 			UsageText:    publishdocs.Arguments,
@@ -49,5 +53,29 @@ func GetCommands() []cli.Command {
 				return commands.PublishCmd(c)
 			},
 		},
+		{
+			Name:         "list",
+			Aliases:      []string{"ls"},
+			Description:  listdocs.Description,
+			HelpName:     corecommon.CreateUsage("plugin list", listdocs.Description, listdocs.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return commands.ListCmd(c)
+			},
+		},
+		{
+			Name:         "update",
+			Aliases:      []string{"u"},
+			Flags:        cliutils.GetCommandFlags(cliutils.PluginUpdate),
+			Description:  updatedocs.Description,
+			HelpName:     corecommon.CreateUsage("plugin update", updatedocs.Description, updatedocs.Usage),
+			UsageText:    updatedocs.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return commands.UpdateCmd(c)
+			},
+		},
 	})
 }
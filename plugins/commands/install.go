@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/jfrog/jfrog-cli-core/utils/config"
 	commandsUtils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	pluginsutils "github.com/jfrog/jfrog-cli/plugins/utils"
 	clientUtils "github.com/jfrog/jfrog-client-go/utils"
 	"net/http"
 	"os"
@@ -23,6 +24,15 @@ import (
 )
 
 func InstallCmd(c *cli.Context) error {
+	if c.Bool("from-manifest") {
+		if c.NArg() != 0 {
+			return cliutils.PrintHelpAndReturnError("No arguments should be sent when the 'from-manifest' option is used.", c)
+		}
+		return runInstallFromManifestCmd(c.Bool("update-lock"), c.Bool("allow-server-checksum"))
+	}
+	if c.Bool("update-lock") {
+		return cliutils.PrintHelpAndReturnError("The 'update-lock' option can only be used with the 'from-manifest' option.", c)
+	}
 	if c.NArg() != 1 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
 	}
@@ -30,10 +40,10 @@ func InstallCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	return runInstallCmd(c.Args().Get(0))
+	return runInstallCmd(c.Args().Get(0), c.Bool("allow-server-checksum"))
 }
 
-func runInstallCmd(requestedPlugin string) error {
+func runInstallCmd(requestedPlugin string, allowServerChecksum bool) error {
 	pluginName, version, err := getNameAndVersion(requestedPlugin)
 	if err != nil {
 		return err
@@ -44,26 +54,105 @@ func runInstallCmd(requestedPlugin string) error {
 		return err
 	}
 
-	url, httpDetails, err := getServerDetails()
+	installDetails := getInstallDetailsFromEnv()
+	download, err := getPluginDownloadDetails(pluginName, version, installDetails, allowServerChecksum)
 	if err != nil {
 		return err
 	}
 
-	pluginRtPath, err := getRequiredPluginRtPath(pluginName, version)
+	should, err := shouldDownloadPlugin(pluginsDir, pluginName, download.url, download.httpDetails)
 	if err != nil {
 		return err
 	}
-	downloadUrl := clientUtils.AddTrailingSlashIfNeeded(url) + pluginRtPath
+	if !should {
+		return errorutils.CheckError(errors.New("the plugin with the requested version already exists locally"))
+	}
+
+	_, err = installPlugin(pluginsDir, download, installDetails, "")
+	return err
+}
+
+// A plugin to download from the registry, resolved for the architecture of this machine.
+type pluginDownload struct {
+	url         string
+	httpDetails httputils.HttpClientDetails
+	name        string
+	// The requested version, which may be 'latest'.
+	version string
+	// The architecture the plugin was published for, which may be a compatible architecture rather than the local one.
+	arc string
+	// Whether a plugin published without a checksum file may be verified against the checksum reported by the server.
+	allowServerChecksum bool
+}
 
-	should, err := shouldDownloadPlugin(pluginsDir, pluginName, downloadUrl, httpDetails)
+// Downloads the plugin and stores the registry it was installed from.
+// If lockedSha256 is provided, the downloaded plugin must match it.
+// Returns the sha256 of the installed plugin.
+func installPlugin(pluginsDir string, download *pluginDownload, installDetails *commandsUtils.InstallDetails, lockedSha256 string) (string, error) {
+	sha256, err := downloadPlugin(pluginsDir, download, lockedSha256)
 	if err != nil {
-		return err
+		return "", err
 	}
-	if !should {
-		return errorutils.CheckError(errors.New("the plugin with the requested version already exists locally"))
+	return sha256, commandsUtils.SaveInstallDetails(download.name, installDetails)
+}
+
+// The plugins registry is determined by the env vars at the time of the installation.
+func getInstallDetailsFromEnv() *commandsUtils.InstallDetails {
+	return &commandsUtils.InstallDetails{
+		ServerId: os.Getenv(commandsUtils.PluginsServerEnv),
+		Repo:     commandsUtils.GetPluginsRepo(),
 	}
+}
 
-	return downloadPlugin(pluginsDir, pluginName, downloadUrl, httpDetails)
+// Returns the URL from which the plugin should be downloaded, and the matching HTTP details.
+func getPluginDownloadDetails(pluginName, version string, installDetails *commandsUtils.InstallDetails, allowServerChecksum bool) (*pluginDownload, error) {
+	url, httpDetails, err := getServerDetails(installDetails.ServerId)
+	if err != nil {
+		return nil, err
+	}
+
+	arc, err := commandsUtils.GetLocalArchitecture()
+	if err != nil {
+		return nil, err
+	}
+	downloadUrl, arc, err := resolvePluginDownloadUrl(clientUtils.AddTrailingSlashIfNeeded(url), installDetails.Repo, pluginName, version, arc, httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	return &pluginDownload{url: downloadUrl, httpDetails: httpDetails, name: pluginName, version: version, arc: arc, allowServerChecksum: allowServerChecksum}, nil
+}
+
+// Returns the download URL of the plugin for the local architecture, and the architecture.
+// If the plugin was not published for the local architecture, the URL of the first compatible architecture it was published for is returned.
+func resolvePluginDownloadUrl(url, repo, pluginName, version, arc string, httpDetails httputils.HttpClientDetails) (string, string, error) {
+	arcs := commandsUtils.GetCompatibleArchitectures(arc)
+	if len(arcs) == 1 {
+		return url + commandsUtils.GetPluginPathInRepo(repo, pluginName, version, arc), arc, nil
+	}
+	client, err := httpclient.ClientBuilder().Build()
+	if err != nil {
+		return "", "", err
+	}
+	for _, candidate := range arcs {
+		downloadUrl := url + commandsUtils.GetPluginPathInRepo(repo, pluginName, version, candidate)
+		log.Debug("Checking if the plugin exists at: ", downloadUrl)
+		resp, _, err := client.SendHead(downloadUrl, httpDetails, "")
+		if err != nil {
+			return "", "", err
+		}
+		log.Debug("Artifactory response: ", resp.Status)
+		if resp.StatusCode == http.StatusOK {
+			if candidate != arc {
+				log.Info("The plugin '" + pluginName + "' was not published for the '" + arc + "' architecture. Installing the '" + candidate + "' plugin instead.")
+			}
+			return downloadUrl, candidate, nil
+		}
+		if err = errorutils.CheckResponseStatus(resp, http.StatusNotFound); err != nil {
+			return "", "", err
+		}
+	}
+	// Not found for any architecture. Use the local architecture's URL, so that the download fails with the expected error.
+	return url + commandsUtils.GetPluginPathInRepo(repo, pluginName, version, arc), arc, nil
 }
 
 // Assert repo env is not passed without server env.
@@ -99,8 +188,7 @@ func createPluginsDirIfNeeded() (string, error) {
 }
 
 // Use the server ID if provided, else use the official registry.
-func getServerDetails() (string, httputils.HttpClientDetails, error) {
-	serverId := os.Getenv(commandsUtils.PluginsServerEnv)
+func getServerDetails(serverId string) (string, httputils.HttpClientDetails, error) {
 	if serverId == "" {
 		return commandsUtils.PluginsOfficialRegistryUrl, httputils.HttpClientDetails{}, nil
 	}
@@ -114,7 +202,9 @@ func getServerDetails() (string, httputils.HttpClientDetails, error) {
 
 // Checks if the requested plugin exists in registry and does not exists locally.
 func shouldDownloadPlugin(pluginsDir, pluginName, downloadUrl string, httpDetails httputils.HttpClientDetails) (bool, error) {
-	exists, err := fileutils.IsFileExists(filepath.Join(pluginsDir, commandsUtils.GetLocalPluginExecutableName(pluginName)), false)
+	// On Windows, the plugin's executable has the .exe extension.
+	exePath := filepath.Join(pluginsDir, commandsUtils.GetLocalPluginExecutableName(pluginName))
+	exists, err := fileutils.IsFileExists(exePath, false)
 	if err != nil {
 		return false, err
 	}
@@ -137,42 +227,41 @@ func shouldDownloadPlugin(pluginsDir, pluginName, downloadUrl string, httpDetail
 	if err != nil {
 		return false, err
 	}
-	equal, err := fileutils.IsEqualToLocalFile(filepath.Join(pluginsDir, pluginName), details.Checksum.Md5, details.Checksum.Sha1)
+	equal, err := fileutils.IsEqualToLocalFile(exePath, details.Checksum.Md5, details.Checksum.Sha1)
 	return !equal, err
 }
 
-// Returns the path of the plugin's executable in registry, corresponding to the local architecture.
-func getRequiredPluginRtPath(pluginName, version string) (string, error) {
-	arc, err := commandsUtils.GetLocalArchitecture()
-	if err != nil {
-		return "", err
-	}
-	return commandsUtils.GetPluginPathInArtifactory(pluginName, version, arc), nil
-}
-
 func createPluginsDir(pluginsDir string) error {
 	return os.MkdirAll(pluginsDir, 0777)
 }
 
-func downloadPlugin(pluginsDir, pluginName, downloadUrl string, httpDetails httputils.HttpClientDetails) error {
+// Downloads the plugin to a temp dir, and moves it to the plugins dir only after it has been verified.
+func downloadPlugin(pluginsDir string, download *pluginDownload, lockedSha256 string) (string, error) {
+	pluginName, downloadUrl := download.name, download.url
 	exeName := commandsUtils.GetLocalPluginExecutableName(pluginName)
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return "", err
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+
 	log.Debug("Downloading plugin from: ", downloadUrl)
 	downloadDetails := &httpclient.DownloadFileDetails{
 		FileName:      pluginName,
 		DownloadPath:  downloadUrl,
-		LocalPath:     pluginsDir,
+		LocalPath:     tmpDir,
 		LocalFileName: exeName,
 		RelativePath:  exeName,
 	}
 
 	client, err := httpclient.ClientBuilder().Build()
 	if err != nil {
-		return err
+		return "", err
 	}
 	// Init progress bar.
 	progressMgr, logFile, err := progressbar.InitProgressBarIfPossible()
 	if err != nil {
-		return err
+		return "", err
 	}
 	if progressMgr != nil {
 		progressMgr.IncGeneralProgressTotalBy(1)
@@ -181,17 +270,114 @@ func downloadPlugin(pluginsDir, pluginName, downloadUrl string, httpDetails http
 	}
 	log.Info("Downloading plugin: " + pluginName)
 
-	resp, err := client.DownloadFileWithProgress(downloadDetails, "", httpDetails, false, progressMgr)
+	resp, err := client.DownloadFileWithProgress(downloadDetails, "", download.httpDetails, false, progressMgr)
 	if err != nil {
-		return err
+		return "", err
 	}
 	log.Debug("Artifactory response: ", resp.Status)
 	err = errorutils.CheckResponseStatus(resp, http.StatusOK)
 	if err != nil {
-		return err
+		return "", err
 	}
 	log.Debug("Plugin downloaded successfully.")
-	return os.Chmod(filepath.Join(pluginsDir, exeName), 0777)
+
+	tmpExePath := filepath.Join(tmpDir, exeName)
+	sha256, err := verifyPluginDownload(client, tmpExePath, download, resp)
+	if err != nil {
+		return "", err
+	}
+	if lockedSha256 != "" && sha256 != lockedSha256 {
+		return "", errorutils.CheckError(errors.New("the sha256 of the downloaded plugin '" + pluginName + "' does not match the locked sha256. " +
+			"Locked: '" + lockedSha256 + "', Actual: '" + sha256 + "'. To lock the plugin's current checksum, use the --update-lock option. Aborting installation"))
+	}
+	exePath := filepath.Join(pluginsDir, exeName)
+	err = fileutils.MoveFile(tmpExePath, exePath)
+	if err != nil {
+		return "", err
+	}
+	err = os.Chmod(exePath, 0777)
+	if err != nil {
+		return "", err
+	}
+	// Failing to cache the signature does not fail the installation, as it will be retrieved again when the CLI runs.
+	if err = pluginsutils.RefreshPluginSignature(pluginsDir, exeName); err != nil {
+		log.Warn("Failed caching the signature of plugin '" + pluginName + "': " + err.Error())
+	}
+	return sha256, nil
+}
+
+// Verifies the downloaded plugin against the checksum published next to it.
+// A plugin published without a checksum file is verified against the sha256 reported by Artifactory, only if explicitly allowed.
+// If a public key is provided, the plugin's details must also be signed by the matching private key.
+// Returns the verified sha256 of the plugin.
+func verifyPluginDownload(client *httpclient.HttpClient, localPath string, download *pluginDownload, downloadResp *http.Response) (string, error) {
+	log.Debug("Verifying the downloaded plugin...")
+	checksumContent, err := getPublishedSidecar(client, download.url+commandsUtils.ChecksumFileExtension, download.httpDetails)
+	if err != nil {
+		return "", err
+	}
+
+	var expectedSha256 string
+	if checksumContent != nil {
+		expectedSha256, err = commandsUtils.ParseChecksumFile(checksumContent)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		if !download.allowServerChecksum {
+			return "", errorutils.CheckError(errors.New("the plugin was published without a checksum file, so it could not be verified. " +
+				"To verify it using the checksum reported by the plugins server instead, use the --allow-server-checksum option. Aborting installation"))
+		}
+		expectedSha256 = strings.ToLower(downloadResp.Header.Get("X-Checksum-Sha256"))
+		if expectedSha256 == "" {
+			return "", errorutils.CheckError(errors.New("the plugin was published without a checksum and its checksum could not be retrieved from Artifactory. Aborting installation"))
+		}
+		log.Warn("The plugin was published without a checksum file. Verifying the plugin using the checksum reported by Artifactory.")
+	}
+
+	actualSha256, err := commandsUtils.CalcSha256(localPath)
+	if err != nil {
+		return "", err
+	}
+	if actualSha256 != expectedSha256 {
+		return "", errorutils.CheckError(errors.New("checksum verification of the downloaded plugin failed. " +
+			"Expected sha256: '" + expectedSha256 + "', Actual: '" + actualSha256 + "'. Aborting installation"))
+	}
+
+	publicKeyPath := os.Getenv(commandsUtils.PluginsPublicKeyEnv)
+	if publicKeyPath == "" {
+		return actualSha256, nil
+	}
+	signature, err := getPublishedSidecar(client, download.url+commandsUtils.SignatureFileExtension, download.httpDetails)
+	if err != nil {
+		return "", err
+	}
+	if signature == nil {
+		return "", errorutils.CheckError(errors.New(commandsUtils.PluginsPublicKeyEnv + " was provided, but the plugin was published without a signature. Aborting installation"))
+	}
+	expected := &commandsUtils.SignedPluginDetails{Name: download.name, Version: download.version, Arch: download.arc, Sha256: actualSha256}
+	err = commandsUtils.VerifySignatureFile(signature, expected, publicKeyPath)
+	if err != nil {
+		return "", err
+	}
+	return actualSha256, nil
+}
+
+// Returns the content of a file published next to the plugin's executable, or nil if it does not exist.
+func getPublishedSidecar(client *httpclient.HttpClient, url string, httpDetails httputils.HttpClientDetails) ([]byte, error) {
+	log.Debug("Fetching: ", url)
+	resp, body, _, err := client.SendGet(url, true, httpDetails, "")
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Artifactory response: ", resp.Status)
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return body, nil
 }
 
 func getNameAndVersion(requested string) (name, version string, err error) {
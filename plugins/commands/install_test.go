@@ -1,8 +1,18 @@
 package commands
 
 import (
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/hex"
 	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
 )
 
@@ -34,3 +44,99 @@ func TestGetNameAndVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestResolvePluginDownloadUrl(t *testing.T) {
+	// Mock a registry in which the plugin was published for darwin/amd64 only.
+	published := "/" + utils.GetPluginPathInRepo(utils.DefaultPluginsRepo, "hello-frog", "1.0.0", "mac-386")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != published {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	url := server.URL + "/"
+
+	tests := []struct {
+		name        string
+		arc         string
+		expectedArc string
+	}{
+		{"published", "mac-386", "mac-386"},
+		{"fallback", "mac-arm64", "mac-386"},
+		{"noFallback", "linux-amd64", "linux-amd64"},
+		{"fallbackNotPublished", "windows-arm64", "windows-arm64"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			downloadUrl, arc, err := resolvePluginDownloadUrl(url, utils.DefaultPluginsRepo, "hello-frog", "1.0.0", test.arc, httputils.HttpClientDetails{})
+			assert.NoError(t, err)
+			assert.Equal(t, url+utils.GetPluginPathInRepo(utils.DefaultPluginsRepo, "hello-frog", "1.0.0", test.expectedArc), downloadUrl)
+			assert.Equal(t, test.expectedArc, arc)
+		})
+	}
+}
+
+func TestVerifyPluginDownloadWithoutChecksumFile(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	pluginPath := filepath.Join(tmpDir, "hello-frog")
+	assert.NoError(t, ioutil.WriteFile(pluginPath, []byte("hello-frog"), 0644))
+	sha256, err := utils.CalcSha256(pluginPath)
+	assert.NoError(t, err)
+
+	// Mock a registry in which the plugin was published without a checksum file.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	client, err := httpclient.ClientBuilder().Build()
+	assert.NoError(t, err)
+	downloadResp := &http.Response{Header: http.Header{"X-Checksum-Sha256": []string{sha256}}}
+	download := &pluginDownload{url: server.URL + "/hello-frog", name: "hello-frog", version: "1.0.0", arc: "linux-amd64"}
+
+	// The checksum reported by the server is used only if explicitly allowed.
+	_, err = verifyPluginDownload(client, pluginPath, download, downloadResp)
+	assert.Error(t, err)
+	download.allowServerChecksum = true
+	verifiedSha256, err := verifyPluginDownload(client, pluginPath, download, downloadResp)
+	assert.NoError(t, err)
+	assert.Equal(t, sha256, verifiedSha256)
+}
+
+func TestGetCompatibleArchitectures(t *testing.T) {
+	assert.Equal(t, []string{"mac-arm64", "mac-386"}, utils.GetCompatibleArchitectures("mac-arm64"))
+	assert.Equal(t, []string{"linux-amd64"}, utils.GetCompatibleArchitectures("linux-amd64"))
+	localArc, err := utils.GetLocalArchitecture()
+	assert.NoError(t, err)
+	assert.Contains(t, utils.ArchitecturesMap, localArc)
+}
+
+func TestShouldDownloadPlugin(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	content := []byte("hello-frog")
+	md5Sum, sha1Sum := md5.Sum(content), sha1.Sum(content)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Checksum-Md5", hex.EncodeToString(md5Sum[:]))
+		w.Header().Set("X-Checksum-Sha1", hex.EncodeToString(sha1Sum[:]))
+	}))
+	defer server.Close()
+
+	should, err := shouldDownloadPlugin(tmpDir, "hello-frog", server.URL+"/hello-frog", httputils.HttpClientDetails{})
+	assert.NoError(t, err)
+	assert.True(t, should)
+
+	// The installed plugin is compared by its executable, which has the .exe extension on Windows.
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(tmpDir, utils.GetLocalPluginExecutableName("hello-frog")), content, 0755))
+	should, err = shouldDownloadPlugin(tmpDir, "hello-frog", server.URL+"/hello-frog", httputils.HttpClientDetails{})
+	assert.NoError(t, err)
+	assert.False(t, should)
+}
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"encoding/json"
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	pluginsutils "github.com/jfrog/jfrog-cli/plugins/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+type installedPlugin struct {
+	Name           string `json:"name"`
+	Version        string `json:"version"`
+	ExecutablePath string `json:"executablePath"`
+	Registry       string `json:"registry"`
+}
+
+func ListCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	return runListCmd()
+}
+
+func runListCmd() error {
+	installedPlugins, err := getInstalledPlugins()
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(installedPlugins)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	log.Output(clientutils.IndentJson(content))
+	return nil
+}
+
+// Returns the details of all plugins in the plugins dir.
+func getInstalledPlugins() ([]installedPlugin, error) {
+	installedPlugins := []installedPlugin{}
+	pluginsDir, err := coreutils.GetJfrogPluginsDir()
+	if err != nil {
+		return nil, err
+	}
+	pluginsNames, err := getInstalledPluginsNames(pluginsDir)
+	if err != nil {
+		return nil, err
+	}
+	allInstallDetails, err := utils.GetAllInstallDetails()
+	if err != nil {
+		return nil, err
+	}
+	for _, pluginName := range pluginsNames {
+		plugin := installedPlugin{
+			Name:           pluginName,
+			ExecutablePath: filepath.Join(pluginsDir, utils.GetLocalPluginExecutableName(pluginName)),
+		}
+		plugin.Version, err = getPluginVersion(plugin.ExecutablePath)
+		if err != nil {
+			log.Warn("Failed getting the version of plugin '" + pluginName + "': " + err.Error())
+		}
+		installDetails, exists := allInstallDetails[pluginName]
+		if !exists {
+			installDetails = &utils.InstallDetails{Repo: utils.DefaultPluginsRepo}
+		}
+		plugin.Registry = installDetails.Registry()
+		installedPlugins = append(installedPlugins, plugin)
+	}
+	return installedPlugins, nil
+}
+
+// Returns the names of all plugins in the plugins dir.
+func getInstalledPluginsNames(pluginsDir string) ([]string, error) {
+	var pluginsNames []string
+	exists, err := fileutils.IsDirExists(pluginsDir, false)
+	if err != nil || !exists {
+		return pluginsNames, err
+	}
+	files, err := ioutil.ReadDir(pluginsDir)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		pluginsNames = append(pluginsNames, strings.TrimSuffix(f.Name(), filepath.Ext(f.Name())))
+	}
+	return pluginsNames, nil
+}
+
+// Runs the plugin's version command and parses its output.
+func getPluginVersion(execPath string) (string, error) {
+	output, err := io.RunCmdOutput(&pluginsutils.PluginExecCmd{
+		ExecPath: execPath,
+		Command:  []string{pluginVersionCommandName},
+	})
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return utils.ParsePluginVersion(output)
+}
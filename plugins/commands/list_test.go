@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	coreTests "github.com/jfrog/jfrog-cli-core/utils/tests"
+	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetInstalledPlugins(t *testing.T) {
+	// Clean from previous tests.
+	coreTests.CleanUnitTestsJfrogHome()
+	// Create temp jfrog home
+	oldHome, err := coreTests.SetJfrogHome()
+	if err != nil {
+		return
+	}
+	defer os.Setenv(coreutils.HomeDir, oldHome)
+	defer coreTests.CleanUnitTestsJfrogHome()
+
+	// No plugins installed.
+	installedPlugins, err := getInstalledPlugins()
+	assert.NoError(t, err)
+	assert.Empty(t, installedPlugins)
+
+	// Create a file in plugins dir to mock a plugin.
+	pluginsDir, err := coreutils.GetJfrogPluginsDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	assert.NoError(t, fileutils.CopyFile(pluginsDir, pluginMockPath))
+	pluginName := filepath.Base(pluginMockPath)
+	pluginExePath := filepath.Join(pluginsDir, utils.GetLocalPluginExecutableName(pluginName))
+	assert.NoError(t, os.Rename(filepath.Join(pluginsDir, pluginName), pluginExePath))
+
+	// A plugin without install details is assumed to be installed from the official registry.
+	installedPlugins, err = getInstalledPlugins()
+	assert.NoError(t, err)
+	if assert.Len(t, installedPlugins, 1) {
+		assert.Equal(t, pluginName, installedPlugins[0].Name)
+		assert.Equal(t, pluginExePath, installedPlugins[0].ExecutablePath)
+		assert.Equal(t, utils.PluginsOfficialRegistryUrl+utils.DefaultPluginsRepo, installedPlugins[0].Registry)
+		// The mock is not executable, so its version is unknown.
+		assert.Empty(t, installedPlugins[0].Version)
+	}
+
+	// Assert the registry is taken from the install details.
+	assert.NoError(t, utils.SaveInstallDetails(pluginName, &utils.InstallDetails{ServerId: "my-server", Repo: "my-repo"}))
+	installedPlugins, err = getInstalledPlugins()
+	assert.NoError(t, err)
+	if assert.Len(t, installedPlugins, 1) {
+		assert.Equal(t, "my-server/my-repo", installedPlugins[0].Registry)
+	}
+}
+
+func TestParsePluginVersion(t *testing.T) {
+	version, err := utils.ParsePluginVersion("hello-frog version v1.0.0\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0", version)
+
+	_, err = utils.ParsePluginVersion("unexpected output")
+	assert.Error(t, err)
+}
@@ -0,0 +1,280 @@
+package commands
+
+import (
+	"errors"
+	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+const (
+	manifestFileName = "plugins.yaml"
+	lockFileName     = "plugins.lock.yaml"
+)
+
+// The plugins manifest declares the plugins required by a project.
+// Example:
+// plugins:
+//   - plugin: hello-frog@1.0.0
+//   - plugin: build-deps-info@1.2.0
+//     serverId: my-server
+//     repo: my-plugins-repo
+type pluginsManifest struct {
+	Plugins []manifestEntry `yaml:"plugins"`
+}
+
+type manifestEntry struct {
+	// The plugin's name and version, separated by '@'. The version is mandatory, since the latest version changes after it is locked.
+	Plugin   string `yaml:"plugin"`
+	ServerId string `yaml:"serverId,omitempty"`
+	Repo     string `yaml:"repo,omitempty"`
+}
+
+// The lock file is written next to the manifest, and holds the checksums the manifest's plugins were resolved to.
+// Since plugins are built per architecture, a checksum is stored for each architecture the plugin was installed on.
+type pluginsLock struct {
+	Plugins []*lockEntry `yaml:"plugins"`
+}
+
+type lockEntry struct {
+	Name     string            `yaml:"name"`
+	Version  string            `yaml:"version"`
+	ServerId string            `yaml:"serverId,omitempty"`
+	Repo     string            `yaml:"repo"`
+	Sha256   map[string]string `yaml:"sha256"`
+}
+
+// Returns the path of the plugins manifest and lock files, located in the .jfrog dir of the working directory.
+func getManifestAndLockPaths() (manifestPath, lockPath string, err error) {
+	wd, err := os.Getwd()
+	if errorutils.CheckError(err) != nil {
+		return "", "", err
+	}
+	jfrogDir := filepath.Join(wd, ".jfrog")
+	return filepath.Join(jfrogDir, manifestFileName), filepath.Join(jfrogDir, lockFileName), nil
+}
+
+func readManifest(manifestPath string) (*pluginsManifest, error) {
+	exists, err := fileutils.IsFileExists(manifestPath, false)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errorutils.CheckError(errors.New("the plugins manifest could not be found at: " + manifestPath))
+	}
+	content, err := fileutils.ReadFile(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	manifest := new(pluginsManifest)
+	err = yaml.Unmarshal(content, manifest)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	for _, entry := range manifest.Plugins {
+		if entry.Plugin == "" {
+			return nil, errorutils.CheckError(errors.New("the plugins manifest includes an entry without a plugin name"))
+		}
+		_, version, err := getNameAndVersion(entry.Plugin)
+		if err != nil {
+			return nil, err
+		}
+		if version == utils.LatestVersionName {
+			return nil, errorutils.CheckError(errors.New("the plugins manifest entry '" + entry.Plugin + "' doesn't specify a version. " +
+				"Plugins are locked by their versions, so the version must be specified, such as 'hello-frog@1.0.0'"))
+		}
+		if entry.Repo != "" && entry.ServerId == "" {
+			return nil, errorutils.CheckError(errors.New("the plugins manifest entry '" + entry.Plugin + "' includes a repo without a server ID"))
+		}
+	}
+	return manifest, nil
+}
+
+// Reads the lock file. Returns an empty lock if the file does not exist.
+func readLock(lockPath string) (*pluginsLock, error) {
+	lock := new(pluginsLock)
+	exists, err := fileutils.IsFileExists(lockPath, false)
+	if err != nil || !exists {
+		return lock, err
+	}
+	content, err := fileutils.ReadFile(lockPath)
+	if err != nil {
+		return nil, err
+	}
+	err = yaml.Unmarshal(content, lock)
+	return lock, errorutils.CheckError(err)
+}
+
+func writeLock(lockPath string, lock *pluginsLock) error {
+	content, err := yaml.Marshal(lock)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	// The lock is replaced at once, so that a failed write never leaves a partial lock file.
+	return utils.WriteFileAtomically(lockPath, content)
+}
+
+// Returns the lock entry of the plugin, if it matches the requested version and registry.
+func (lock *pluginsLock) get(pluginName, version string, installDetails *utils.InstallDetails) *lockEntry {
+	for _, entry := range lock.Plugins {
+		if entry.Name != pluginName {
+			continue
+		}
+		if entry.Version == version && entry.ServerId == installDetails.ServerId && entry.Repo == installDetails.Repo {
+			return entry
+		}
+		log.Info("The plugin '" + pluginName + "' was changed in the plugins manifest and will be resolved again.")
+		return nil
+	}
+	return nil
+}
+
+// Installs the plugins declared in the plugins manifest, and updates the lock file with the installed plugins' checksums.
+// If updateLock is true, the locked checksums are ignored and the plugins are resolved again.
+func runInstallFromManifestCmd(updateLock, allowServerChecksum bool) error {
+	manifestPath, lockPath, err := getManifestAndLockPaths()
+	if err != nil {
+		return err
+	}
+	manifest, err := readManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	lock, err := readLock(lockPath)
+	if err != nil {
+		return err
+	}
+	arc, err := utils.GetLocalArchitecture()
+	if err != nil {
+		return err
+	}
+	pluginsDir, err := createPluginsDirIfNeeded()
+	if err != nil {
+		return err
+	}
+
+	resolveLock := lock
+	if updateLock {
+		resolveLock = new(pluginsLock)
+	}
+	newLock := new(pluginsLock)
+	for i, manifestEntry := range manifest.Plugins {
+		entry, err := installManifestEntry(pluginsDir, arc, manifestEntry, resolveLock, allowServerChecksum)
+		if err != nil {
+			// Lock the plugins installed so far. The plugins which were not installed keep their previously locked checksums.
+			newLock.Plugins = append(newLock.Plugins, lock.getEntries(manifest.Plugins[i:])...)
+			if lockErr := writeLock(lockPath, newLock); lockErr != nil {
+				log.Error("Failed writing the plugins lock file: " + lockErr.Error())
+			}
+			return err
+		}
+		newLock.Plugins = append(newLock.Plugins, entry)
+	}
+	return writeLock(lockPath, newLock)
+}
+
+// Returns the lock entries of the plugins declared by the manifest entries.
+func (lock *pluginsLock) getEntries(manifestEntries []manifestEntry) []*lockEntry {
+	var entries []*lockEntry
+	for _, manifestEntry := range manifestEntries {
+		pluginName, _, err := getNameAndVersion(manifestEntry.Plugin)
+		if err != nil {
+			continue
+		}
+		for _, entry := range lock.Plugins {
+			if entry.Name == pluginName {
+				entries = append(entries, entry)
+				break
+			}
+		}
+	}
+	return entries
+}
+
+// Installs a single plugin declared in the manifest, unless the locked version is already installed.
+// Returns the updated lock entry of the plugin.
+func installManifestEntry(pluginsDir, arc string, manifestEntry manifestEntry, lock *pluginsLock, allowServerChecksum bool) (*lockEntry, error) {
+	pluginName, version, err := getNameAndVersion(manifestEntry.Plugin)
+	if err != nil {
+		return nil, err
+	}
+	installDetails := &utils.InstallDetails{ServerId: manifestEntry.ServerId, Repo: manifestEntry.Repo}
+	if installDetails.Repo == "" {
+		installDetails.Repo = utils.DefaultPluginsRepo
+	}
+	entry := lock.get(pluginName, version, installDetails)
+	if entry == nil {
+		entry = &lockEntry{Name: pluginName, Version: version, ServerId: installDetails.ServerId, Repo: installDetails.Repo}
+	}
+	if entry.Sha256 == nil {
+		entry.Sha256 = map[string]string{}
+	}
+	lockedSha256 := entry.Sha256[arc]
+
+	exePath := filepath.Join(pluginsDir, utils.GetLocalPluginExecutableName(pluginName))
+	exists, err := fileutils.IsFileExists(exePath, false)
+	if err != nil {
+		return nil, err
+	}
+	if exists && lockedSha256 != "" {
+		localSha256, err := utils.CalcSha256(exePath)
+		if err != nil {
+			return nil, err
+		}
+		if localSha256 == lockedSha256 {
+			log.Info("Plugin '" + pluginName + "' is already installed.")
+			return entry, utils.SaveInstallDetails(pluginName, installDetails)
+		}
+	}
+
+	download, err := getPluginDownloadDetails(pluginName, version, installDetails, allowServerChecksum)
+	if err != nil {
+		return nil, err
+	}
+	// If the plugin is not locked yet, avoid downloading it again if it matches the plugin in the registry.
+	if exists && lockedSha256 == "" {
+		should, err := shouldDownloadPlugin(pluginsDir, pluginName, download.url, download.httpDetails)
+		if err != nil {
+			return nil, err
+		}
+		if !should {
+			// The installed plugin is locked only after it is verified, as a downloaded plugin is.
+			entry.Sha256[arc], err = verifyInstalledPlugin(exePath, download)
+			if err != nil {
+				return nil, err
+			}
+			log.Info("Plugin '" + pluginName + "' is already installed.")
+			return entry, utils.SaveInstallDetails(pluginName, installDetails)
+		}
+	}
+
+	entry.Sha256[arc], err = installPlugin(pluginsDir, download, installDetails, lockedSha256)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Verifies the installed plugin against the checksum and signature published with the plugin.
+// Returns the plugin's sha256 checksum.
+func verifyInstalledPlugin(exePath string, download *pluginDownload) (string, error) {
+	client, err := httpclient.ClientBuilder().Build()
+	if err != nil {
+		return "", err
+	}
+	// The response holds the checksum reported by the server, which is used if the plugin was published without a checksum file.
+	resp, _, err := client.SendHead(download.url, download.httpDetails, "")
+	if err != nil {
+		return "", err
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK); err != nil {
+		return "", err
+	}
+	return verifyPluginDownload(client, exePath, download, resp)
+}
@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	coreTests "github.com/jfrog/jfrog-cli-core/utils/tests"
+	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadManifest(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	manifestPath := filepath.Join(tmpDir, manifestFileName)
+
+	tests := []struct {
+		name     string
+		content  string
+		isValid  bool
+		expected []manifestEntry
+	}{
+		{"valid", "plugins:\n  - plugin: hello-frog@1.0.0\n  - plugin: rt-fs@2.0.0\n    serverId: my-server\n    repo: my-repo\n", true,
+			[]manifestEntry{{Plugin: "hello-frog@1.0.0"}, {Plugin: "rt-fs@2.0.0", ServerId: "my-server", Repo: "my-repo"}}},
+		{"missingName", "plugins:\n  - serverId: my-server\n", false, nil},
+		{"missingVersion", "plugins:\n  - plugin: rt-fs\n", false, nil},
+		{"latestVersion", "plugins:\n  - plugin: rt-fs@latest\n", false, nil},
+		{"repoWithoutServer", "plugins:\n  - plugin: rt-fs@2.0.0\n    repo: my-repo\n", false, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.NoError(t, ioutil.WriteFile(manifestPath, []byte(test.content), 0644))
+			manifest, err := readManifest(manifestPath)
+			if !test.isValid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, manifest.Plugins)
+		})
+	}
+
+	_, err = readManifest(filepath.Join(tmpDir, "non-existing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestLockGet(t *testing.T) {
+	lock := &pluginsLock{Plugins: []*lockEntry{
+		{Name: "hello-frog", Version: "1.0.0", Repo: utils.DefaultPluginsRepo, Sha256: map[string]string{"linux-amd64": "sha"}},
+	}}
+	details := &utils.InstallDetails{Repo: utils.DefaultPluginsRepo}
+	assert.NotNil(t, lock.get("hello-frog", "1.0.0", details))
+	assert.Nil(t, lock.get("hello-frog", "1.0.1", details))
+	assert.Nil(t, lock.get("hello-frog", "1.0.0", &utils.InstallDetails{ServerId: "my-server", Repo: utils.DefaultPluginsRepo}))
+	assert.Nil(t, lock.get("rt-fs", "1.0.0", details))
+}
+
+func TestLockGetEntries(t *testing.T) {
+	helloFrog := &lockEntry{Name: "hello-frog", Version: "1.0.0", Repo: utils.DefaultPluginsRepo}
+	rtFs := &lockEntry{Name: "rt-fs", Version: utils.LatestVersionName, Repo: utils.DefaultPluginsRepo}
+	lock := &pluginsLock{Plugins: []*lockEntry{helloFrog, rtFs}}
+	assert.Equal(t, []*lockEntry{rtFs, helloFrog}, lock.getEntries([]manifestEntry{{Plugin: "rt-fs"}, {Plugin: "hello-frog@1.0.1"}, {Plugin: "not-locked"}}))
+	assert.Empty(t, lock.getEntries(nil))
+}
+
+func TestInstallLockedManifestEntry(t *testing.T) {
+	// Clean from previous tests.
+	coreTests.CleanUnitTestsJfrogHome()
+	// Create temp jfrog home
+	oldHome, err := coreTests.SetJfrogHome()
+	if err != nil {
+		return
+	}
+	defer os.Setenv(coreutils.HomeDir, oldHome)
+	defer coreTests.CleanUnitTestsJfrogHome()
+
+	// Create a file in plugins dir to mock a plugin.
+	pluginsDir, err := createPluginsDirIfNeeded()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	assert.NoError(t, fileutils.CopyFile(pluginsDir, pluginMockPath))
+	pluginName := filepath.Base(pluginMockPath)
+	pluginExePath := filepath.Join(pluginsDir, utils.GetLocalPluginExecutableName(pluginName))
+	assert.NoError(t, os.Rename(filepath.Join(pluginsDir, pluginName), pluginExePath))
+	sha256, err := utils.CalcSha256(pluginExePath)
+	assert.NoError(t, err)
+
+	// The installed plugin matches the lock, so it should not be downloaded again.
+	lock := &pluginsLock{Plugins: []*lockEntry{
+		{Name: pluginName, Version: "1.0.0", ServerId: "my-server", Repo: "my-repo", Sha256: map[string]string{"linux-amd64": sha256}},
+	}}
+	entry, err := installManifestEntry(pluginsDir, "linux-amd64", manifestEntry{Plugin: pluginName + "@1.0.0", ServerId: "my-server", Repo: "my-repo"}, lock, false)
+	assert.NoError(t, err)
+	assert.Equal(t, lock.Plugins[0], entry)
+	details, err := utils.GetInstallDetails(pluginName)
+	assert.NoError(t, err)
+	assert.Equal(t, &utils.InstallDetails{ServerId: "my-server", Repo: "my-repo"}, details)
+
+	// Assert the lock is written and read back.
+	lockPath := filepath.Join(pluginsDir, lockFileName)
+	assert.NoError(t, writeLock(lockPath, lock))
+	readBack, err := readLock(lockPath)
+	assert.NoError(t, err)
+	assert.Equal(t, lock, readBack)
+}
+
+func TestVerifyInstalledPlugin(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	publishedPath := filepath.Join(tmpDir, "published")
+	assert.NoError(t, ioutil.WriteFile(publishedPath, []byte("hello-frog"), 0644))
+	checksumFilePath, err := utils.CreateChecksumFile(publishedPath)
+	assert.NoError(t, err)
+	checksumContent, err := ioutil.ReadFile(checksumFilePath)
+	assert.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/hello-frog"+utils.ChecksumFileExtension {
+			w.Write(checksumContent)
+		}
+	}))
+	defer server.Close()
+	download := &pluginDownload{url: server.URL + "/hello-frog", name: "hello-frog", version: "1.0.0", arc: "linux-amd64"}
+
+	// An installed plugin which matches the published checksum file is locked by its checksum.
+	sha256, err := verifyInstalledPlugin(publishedPath, download)
+	assert.NoError(t, err)
+	expectedSha256, err := utils.CalcSha256(publishedPath)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSha256, sha256)
+
+	// A modified installed plugin isn't locked.
+	modifiedPath := filepath.Join(tmpDir, "modified")
+	assert.NoError(t, ioutil.WriteFile(modifiedPath, []byte("modified"), 0644))
+	_, err = verifyInstalledPlugin(modifiedPath, download)
+	assert.Error(t, err)
+}
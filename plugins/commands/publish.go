@@ -20,6 +20,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 )
 
 const pluginVersionCommandName = "-v"
@@ -34,24 +35,25 @@ func PublishCmd(c *cli.Context) error {
 		return err
 	}
 
-	return runPublishCmd(c.Args().Get(0), c.Args().Get(1), rtDetails)
+	return runPublishCmd(c.Args().Get(0), c.Args().Get(1), c.String("prebuilt-dir"), rtDetails)
 }
 
-func runPublishCmd(pluginName, pluginVersion string, rtDetails *config.ServerDetails) error {
+func runPublishCmd(pluginName, pluginVersion, prebuiltDir string, rtDetails *config.ServerDetails) error {
 	err := verifyUniqueVersion(pluginName, pluginVersion, rtDetails)
 	if err != nil {
 		return err
 	}
 
-	return doPublish(pluginName, pluginVersion, rtDetails)
+	return doPublish(pluginName, pluginVersion, prebuiltDir, rtDetails)
 }
 
-// Build and upload the plugin for every supported architecture.
-func doPublish(pluginName, pluginVersion string, rtDetails *config.ServerDetails) error {
+// Build (or take from the prebuilt dir) and upload the plugin for every supported architecture.
+func doPublish(pluginName, pluginVersion, prebuiltDir string, rtDetails *config.ServerDetails) error {
 	tmpDir, err := fileutils.CreateTempDir()
 	if err != nil {
 		return err
 	}
+	defer fileutils.RemoveTempDir(tmpDir)
 
 	localArc, err := utils.GetLocalArchitecture()
 	if err != nil {
@@ -63,10 +65,11 @@ func doPublish(pluginName, pluginVersion string, rtDetails *config.ServerDetails
 		return err
 	}
 
-	// Build and upload the plugin for all architectures.
-	// Start with the local architecture, to assert versions match before uploading.
+	// Prepare the plugin for all architectures before uploading, so that a partial version is never published.
+	// Start with the local architecture, to assert versions match.
+	pluginPaths := make(map[string]string, len(arcs))
 	for _, arc := range arcs {
-		pluginPath, err := buildPlugin(pluginName, tmpDir, utils.ArchitecturesMap[arc])
+		pluginPath, err := preparePlugin(pluginName, tmpDir, prebuiltDir, arc)
 		if err != nil {
 			return err
 		}
@@ -76,7 +79,11 @@ func doPublish(pluginName, pluginVersion string, rtDetails *config.ServerDetails
 				return err
 			}
 		}
-		err = uploadPlugin(pluginPath, pluginName, pluginVersion, arc, rtDetails)
+		pluginPaths[arc] = pluginPath
+	}
+
+	for _, arc := range arcs {
+		err = uploadPlugin(pluginPaths[arc], pluginName, pluginVersion, arc, rtDetails)
 		if err != nil {
 			return err
 		}
@@ -121,9 +128,43 @@ func verifyMatchingVersion(pluginFullPath, pluginVersion string) error {
 	return utils.AssertPluginVersion(output, pluginVersion)
 }
 
-func buildPlugin(pluginName, tmpDir string, arc utils.Architecture) (string, error) {
+// Returns the path of the plugin's executable for the architecture, in a dedicated dir under tmpDir.
+// The executable is built, unless a prebuilt dir is provided.
+func preparePlugin(pluginName, tmpDir, prebuiltDir, arcName string) (string, error) {
+	arcDir := filepath.Join(tmpDir, arcName)
+	err := os.MkdirAll(arcDir, 0777)
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	if prebuiltDir == "" {
+		return buildPlugin(pluginName, arcDir, utils.ArchitecturesMap[arcName])
+	}
+	return copyPrebuiltPlugin(pluginName, arcDir, prebuiltDir, arcName)
+}
+
+// Copies the plugin's executable from the prebuilt dir, expected to be laid out as '<prebuilt-dir>/<architecture>/<plugin-name>'.
+// The executable is copied, to avoid creating the checksum and signature files in the prebuilt dir.
+func copyPrebuiltPlugin(pluginName, arcDir, prebuiltDir, arcName string) (string, error) {
+	exeName := pluginName + utils.ArchitecturesMap[arcName].FileExtension
+	prebuiltPath := filepath.Join(prebuiltDir, arcName, exeName)
+	exists, err := fileutils.IsFileExists(prebuiltPath, false)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", errorutils.CheckError(errors.New("the prebuilt plugin for the '" + arcName + "' architecture could not be found at: " + prebuiltPath + ". Aborting"))
+	}
+	log.Info("Using prebuilt plugin for: " + arcName + "...")
+	err = fileutils.CopyFile(arcDir, prebuiltPath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(arcDir, exeName), nil
+}
+
+func buildPlugin(pluginName, outputDir string, arc utils.Architecture) (string, error) {
 	log.Info("Building plugin for: " + arc.Goos + "-" + arc.Goarch + "...")
-	outputPath := filepath.Join(tmpDir, pluginName+arc.FileExtension)
+	outputPath := filepath.Join(outputDir, pluginName+arc.FileExtension)
 	buildCmd := utils.PluginBuildCmd{
 		OutputFullPath: outputPath,
 		Env: map[string]string{
@@ -175,14 +216,52 @@ func verifyUniqueVersion(pluginName, pluginVersion string, rtDetails *config.Ser
 	return errorutils.CheckResponseStatus(resp, http.StatusUnauthorized, http.StatusNotFound)
 }
 
+// Uploads the plugin's executable, along with its checksum file and signature (if a signing key is provided).
+// The checksum and signature files are uploaded first, so that the executable is never published without them.
 func uploadPlugin(pluginLocalPath, pluginName, pluginVersion, arc string, rtDetails *config.ServerDetails) error {
+	sidecars, err := createSidecarFiles(pluginLocalPath, pluginName, pluginVersion, arc)
+	if err != nil {
+		return err
+	}
 	targetPath := utils.GetPluginPathInArtifactory(pluginName, pluginVersion, arc)
+	for _, sidecar := range sidecars {
+		err = uploadFile(sidecar, targetPath+strings.TrimPrefix(sidecar, pluginLocalPath), rtDetails)
+		if err != nil {
+			return err
+		}
+	}
 	log.Info("Upload plugin to: " + targetPath + "...")
+	return uploadFile(pluginLocalPath, targetPath, rtDetails)
+}
 
+// Creates the files published next to the plugin's executable, to allow verifying it on installation.
+func createSidecarFiles(pluginLocalPath, pluginName, pluginVersion, arc string) ([]string, error) {
+	checksumFilePath, err := utils.CreateChecksumFile(pluginLocalPath)
+	if err != nil {
+		return nil, err
+	}
+	sidecars := []string{checksumFilePath}
+	signingKeyPath := os.Getenv(utils.PluginsSigningKeyEnv)
+	if signingKeyPath == "" {
+		return sidecars, nil
+	}
+	sha256, err := utils.CalcSha256(pluginLocalPath)
+	if err != nil {
+		return nil, err
+	}
+	details := &utils.SignedPluginDetails{Name: pluginName, Version: pluginVersion, Arch: arc, Sha256: sha256}
+	signatureFilePath, err := utils.CreateSignatureFile(pluginLocalPath, details, signingKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	return append(sidecars, signatureFilePath), nil
+}
+
+func uploadFile(localPath, targetPath string, rtDetails *config.ServerDetails) error {
 	uploadCmd := generic.NewUploadCommand()
 	uploadCmd.SetUploadConfiguration(createUploadConfiguration()).
 		SetServerDetails(rtDetails).
-		SetSpec(createUploadSpec(pluginLocalPath, targetPath))
+		SetSpec(createUploadSpec(localPath, targetPath))
 
 	err := uploadCmd.Run()
 	if err != nil {
@@ -200,8 +279,18 @@ func uploadPlugin(pluginLocalPath, pluginName, pluginVersion, arc string, rtDeta
 
 // Copy the uploaded version to override latest dir.
 func copyToLatestDir(pluginName, pluginVersion string, rtDetails *config.ServerDetails) error {
-	log.Info("Copying version to latest dir...")
+	// The copy overrides only the files of the uploaded version, so the sidecars of a previous version, such as its signature, are removed first.
+	log.Info("Removing the checksum and signature files from latest dir...")
+	deleteCmd := generic.NewDeleteCommand()
+	deleteCmd.SetQuiet(true).SetServerDetails(rtDetails).SetSpec(createLatestSidecarsDeleteSpec(pluginName))
+	if err := deleteCmd.Run(); err != nil {
+		return err
+	}
+	if deleteCmd.Result().FailCount() > 0 {
+		return errorutils.CheckError(errors.New("failed removing the checksum and signature files from latest dir"))
+	}
 
+	log.Info("Copying version to latest dir...")
 	copyCmd := generic.NewCopyCommand()
 	copyCmd.SetServerDetails(rtDetails).SetSpec(createCopySpec(pluginName, pluginVersion))
 	return copyCmd.Run()
@@ -218,6 +307,18 @@ func createCopySpec(pluginName, pluginVersion string) *spec.SpecFiles {
 		BuildSpec()
 }
 
+func createLatestSidecarsDeleteSpec(pluginName string) *spec.SpecFiles {
+	latestDir := path.Join(utils.GetPluginsRepo(), pluginName, utils.LatestVersionName)
+	sidecarsSpec := new(spec.SpecFiles)
+	for _, extension := range []string{utils.ChecksumFileExtension, utils.SignatureFileExtension} {
+		sidecarsSpec.Files = append(sidecarsSpec.Files, spec.NewBuilder().
+			Pattern(path.Join(latestDir, "*"+extension)).
+			Recursive(true).
+			BuildSpec().Files...)
+	}
+	return sidecarsSpec
+}
+
 func createUploadSpec(source, target string) *spec.SpecFiles {
 	return spec.NewBuilder().
 		Pattern(source).
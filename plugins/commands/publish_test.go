@@ -2,7 +2,11 @@ package commands
 
 import (
 	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -27,3 +31,42 @@ func TestGetOrderedArchitectures(t *testing.T) {
 	assert.Error(t, err)
 
 }
+
+func TestPreparePrebuiltPlugin(t *testing.T) {
+	prebuiltDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(prebuiltDir)
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+
+	// Create a prebuilt executable for a single architecture.
+	arcDir := filepath.Join(prebuiltDir, "linux-amd64")
+	assert.NoError(t, os.MkdirAll(arcDir, 0777))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(arcDir, "hello-frog"), []byte("hello-frog"), 0644))
+
+	pluginPath, err := preparePlugin("hello-frog", tmpDir, prebuiltDir, "linux-amd64")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(tmpDir, "linux-amd64", "hello-frog"), pluginPath)
+	assert.FileExists(t, pluginPath)
+
+	// Assert a missing architecture fails.
+	_, err = preparePlugin("hello-frog", tmpDir, prebuiltDir, "windows-amd64")
+	assert.Error(t, err)
+}
+
+func TestCreateLatestSidecarsDeleteSpec(t *testing.T) {
+	sidecarsSpec := createLatestSidecarsDeleteSpec("hello-frog")
+	if assert.Len(t, sidecarsSpec.Files, 2) {
+		latestDir := utils.GetPluginsRepo() + "/hello-frog/" + utils.LatestVersionName + "/"
+		assert.Equal(t, latestDir+"*"+utils.ChecksumFileExtension, sidecarsSpec.Files[0].Pattern)
+		assert.Equal(t, latestDir+"*"+utils.SignatureFileExtension, sidecarsSpec.Files[1].Pattern)
+		assert.Equal(t, "true", sidecarsSpec.Files[1].Recursive)
+	}
+}
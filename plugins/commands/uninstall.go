@@ -5,10 +5,12 @@ import (
 	"github.com/codegangsta/cli"
 	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
 	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	pluginsutils "github.com/jfrog/jfrog-cli/plugins/utils"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 	"os"
 	"path/filepath"
 )
@@ -43,7 +45,20 @@ func runUninstallCmd(requestedPlugin string) error {
 			return nil
 		}
 	}
-	return os.Remove(pluginExePath)
+	err = os.Remove(pluginExePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	// The plugin is already uninstalled, so failing to clean up after it is not an error.
+	err = pluginsutils.RemovePluginSignature(utils.GetLocalPluginExecutableName(requestedPlugin))
+	if err != nil {
+		log.Warn("failed removing the signature of plugin '" + requestedPlugin + "': " + err.Error())
+	}
+	err = utils.RemoveInstallDetails(requestedPlugin)
+	if err != nil {
+		log.Warn("failed removing the install details of plugin '" + requestedPlugin + "': " + err.Error())
+	}
+	return nil
 }
 
 func generateNoPluginFoundError(pluginName string) error {
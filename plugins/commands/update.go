@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"path/filepath"
+)
+
+func UpdateCmd(c *cli.Context) error {
+	if c.Bool("all") {
+		if c.NArg() != 0 {
+			return cliutils.PrintHelpAndReturnError("No arguments should be sent when the 'all' option is used.", c)
+		}
+		return runUpdateAllCmd(c.Bool("allow-server-checksum"))
+	}
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	return runUpdateCmd(c.Args().Get(0), c.Bool("allow-server-checksum"))
+}
+
+func runUpdateCmd(pluginName string, allowServerChecksum bool) error {
+	pluginsDir, err := coreutils.GetJfrogPluginsDir()
+	if err != nil {
+		return err
+	}
+	exists, err := fileutils.IsFileExists(filepath.Join(pluginsDir, utils.GetLocalPluginExecutableName(pluginName)), false)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return generateNoPluginFoundError(pluginName)
+	}
+	return updatePlugin(pluginsDir, pluginName, allowServerChecksum)
+}
+
+// Updates all installed plugins. Failing to update a plugin does not stop the other plugins from being updated.
+func runUpdateAllCmd(allowServerChecksum bool) error {
+	pluginsDir, err := coreutils.GetJfrogPluginsDir()
+	if err != nil {
+		return err
+	}
+	pluginsNames, err := getInstalledPluginsNames(pluginsDir)
+	if err != nil {
+		return err
+	}
+	var finalErr error
+	for _, pluginName := range pluginsNames {
+		err = updatePlugin(pluginsDir, pluginName, allowServerChecksum)
+		if err != nil {
+			log.Error("Failed updating plugin '" + pluginName + "': " + err.Error())
+			finalErr = err
+		}
+	}
+	return finalErr
+}
+
+// Replaces the plugin's executable with the latest version, from the registry it was installed from.
+func updatePlugin(pluginsDir, pluginName string, allowServerChecksum bool) error {
+	installDetails, err := utils.GetInstallDetails(pluginName)
+	if err != nil {
+		return err
+	}
+	download, err := getPluginDownloadDetails(pluginName, utils.LatestVersionName, installDetails, allowServerChecksum)
+	if err != nil {
+		return err
+	}
+	should, err := shouldDownloadPlugin(pluginsDir, pluginName, download.url, download.httpDetails)
+	if err != nil {
+		return err
+	}
+	if !should {
+		log.Info("Plugin '" + pluginName + "' is up to date.")
+		return nil
+	}
+	_, err = installPlugin(pluginsDir, download, installDetails, "")
+	if err != nil {
+		return err
+	}
+	log.Info("Plugin '" + pluginName + "' was updated successfully.")
+	return nil
+}
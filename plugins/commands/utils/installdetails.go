@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"path/filepath"
+)
+
+// Stores the registry each plugin was installed from, so that it can later be listed and updated from the same registry.
+const installDetailsFileName = "plugins-install-details.json"
+
+type InstallDetails struct {
+	// Empty if the plugin was installed from the official registry.
+	ServerId string `json:"serverId,omitempty"`
+	Repo     string `json:"repo,omitempty"`
+}
+
+// Returns a description of the registry the plugin was installed from.
+func (details *InstallDetails) Registry() string {
+	if details.ServerId == "" {
+		return PluginsOfficialRegistryUrl + details.Repo
+	}
+	return details.ServerId + "/" + details.Repo
+}
+
+func getInstallDetailsFilePath() (string, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, installDetailsFileName), nil
+}
+
+// Returns the install details of all installed plugins, mapped by the plugins' names.
+func GetAllInstallDetails() (map[string]*InstallDetails, error) {
+	allDetails := map[string]*InstallDetails{}
+	detailsPath, err := getInstallDetailsFilePath()
+	if err != nil {
+		return nil, err
+	}
+	exists, err := fileutils.IsFileExists(detailsPath, false)
+	if err != nil || !exists {
+		return allDetails, err
+	}
+	content, err := fileutils.ReadFile(detailsPath)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(content, &allDetails)
+	return allDetails, errorutils.CheckError(err)
+}
+
+// Returns the install details of a plugin.
+// Plugins installed before the install details were stored are assumed to be installed from the official registry.
+func GetInstallDetails(pluginName string) (*InstallDetails, error) {
+	allDetails, err := GetAllInstallDetails()
+	if err != nil {
+		return nil, err
+	}
+	if details, exists := allDetails[pluginName]; exists {
+		return details, nil
+	}
+	return &InstallDetails{Repo: DefaultPluginsRepo}, nil
+}
+
+func SaveInstallDetails(pluginName string, details *InstallDetails) error {
+	allDetails, err := GetAllInstallDetails()
+	if err != nil {
+		return err
+	}
+	allDetails[pluginName] = details
+	return writeAllInstallDetails(allDetails)
+}
+
+func RemoveInstallDetails(pluginName string) error {
+	allDetails, err := GetAllInstallDetails()
+	if err != nil {
+		return err
+	}
+	if _, exists := allDetails[pluginName]; !exists {
+		return nil
+	}
+	delete(allDetails, pluginName)
+	return writeAllInstallDetails(allDetails)
+}
+
+func writeAllInstallDetails(allDetails map[string]*InstallDetails) error {
+	detailsPath, err := getInstallDetailsFilePath()
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(allDetails, "", "  ")
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	return WriteFileAtomically(detailsPath, content)
+}
@@ -1,15 +1,20 @@
 package utils
 
 import (
+	"crypto/sha256"
 	"errors"
+	"fmt"
 	"github.com/jfrog/jfrog-cli-core/utils/config"
 	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
 	"io"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
 	"runtime"
 	"strings"
 )
@@ -35,10 +40,22 @@ var ArchitecturesMap = map[string]Architecture{
 	"linux-s390x":   {"linux", "s390x", ""},
 	"linux-arm64":   {"linux", "arm64", ""},
 	"linux-arm":     {"linux", "arm", ""},
-	"linux-ppc6":    {"linux", "ppc64", ""},
+	"linux-ppc6":    {"linux", "ppc64", ""}, // Named 'linux-ppc6' for compatibility with the plugins already published for linux/ppc64.
 	"linux-ppc64le": {"linux", "ppc64le", ""},
+	"linux-riscv64": {"linux", "riscv64", ""},
+	// Named 'mac-386' for compatibility with the plugins already published for darwin/amd64.
 	"mac-386":       {"darwin", "amd64", ""},
+	"mac-arm64":     {"darwin", "arm64", ""},
 	"windows-amd64": {"windows", "amd64", ".exe"},
+	"windows-arm64": {"windows", "arm64", ".exe"},
+}
+
+// Architectures to fall back to, if a plugin was not published for the local architecture.
+var architectureFallbacks = map[string][]string{
+	// Apple Silicon machines can run darwin/amd64 executables using Rosetta 2.
+	"mac-arm64": {"mac-386"},
+	// Windows on ARM can run windows/amd64 executables using emulation.
+	"windows-arm64": {"windows-amd64"},
 }
 
 func GetLocalPluginExecutableName(pluginName string) string {
@@ -51,7 +68,12 @@ func GetLocalPluginExecutableName(pluginName string) string {
 // Returns the full path of a plugin in Artifactory.
 // Example path: "repo-name/plugin-name/version/architecture-name/executable-name"
 func GetPluginPathInArtifactory(pluginName, pluginVersion, architecture string) string {
-	return path.Join(GetPluginVersionDirInArtifactory(pluginName, pluginVersion), architecture, pluginName+ArchitecturesMap[architecture].FileExtension)
+	return GetPluginPathInRepo(GetPluginsRepo(), pluginName, pluginVersion, architecture)
+}
+
+// Returns the full path of a plugin in the provided repository.
+func GetPluginPathInRepo(repo, pluginName, pluginVersion, architecture string) string {
+	return path.Join(repo, pluginName, pluginVersion, architecture, pluginName+ArchitecturesMap[architecture].FileExtension)
 }
 
 // Example path: "repo-name/plugin-name/v1.0.0/"
@@ -76,32 +98,20 @@ type Architecture struct {
 
 // Get the local architecture name corresponding to the architectures that exist in registry.
 func GetLocalArchitecture() (string, error) {
-	switch runtime.GOOS {
-	case "windows":
-		return "windows-amd64", nil
-	case "darwin":
-		return "mac-386", nil
-	}
-	// Assuming linux.
-	switch runtime.GOARCH {
-	case "amd64":
-		return "linux-amd64", nil
-	case "arm64":
-		return "linux-arm64", nil
-	case "arm":
-		return "linux-arm", nil
-	case "386":
-		return "linux-386", nil
-	case "s390x":
-		return "linux-s390x", nil
-	case "ppc64":
-		return "linux-ppc64", nil
-	case "ppc64le":
-		return "linux-ppc64le", nil
+	for name, arc := range ArchitecturesMap {
+		if arc.Goos == runtime.GOOS && arc.Goarch == runtime.GOARCH {
+			return name, nil
+		}
 	}
 	return "", errorutils.CheckError(errors.New("no compatible plugin architecture was found for the architecture of this machine"))
 }
 
+// Returns the architectures whose plugins can run on the provided architecture, ordered by preference.
+// The first architecture is always the provided one.
+func GetCompatibleArchitectures(arc string) []string {
+	return append([]string{arc}, architectureFallbacks[arc]...)
+}
+
 func CreatePluginsHttpDetails(rtDetails *config.ServerDetails) httputils.HttpClientDetails {
 	if rtDetails.AccessToken != "" && rtDetails.RefreshToken == "" {
 		return httputils.HttpClientDetails{AccessToken: rtDetails.AccessToken}
@@ -114,14 +124,63 @@ func CreatePluginsHttpDetails(rtDetails *config.ServerDetails) httputils.HttpCli
 
 // Asserts a plugin's version is as expected, by parsing the output of the version command.
 func AssertPluginVersion(versionCmdOut string, expectedPluginVersion string) error {
+	actualVersion, err := ParsePluginVersion(versionCmdOut)
+	if err != nil {
+		return err
+	}
+	if actualVersion != expectedPluginVersion {
+		return errorutils.CheckError(errors.New("provided version does not match the plugin's actual version. " +
+			"Provided: '" + expectedPluginVersion + "', Actual: '" + actualVersion + "'"))
+	}
+	return nil
+}
+
+// Parses the plugin's version from the output of the version command.
+func ParsePluginVersion(versionCmdOut string) (string, error) {
 	// Get the actual version which is after the last space. (expected output to -v for example: "plugin-name version v1.0.0")
 	split := strings.Split(strings.TrimSpace(versionCmdOut), " ")
 	if len(split) != 3 {
-		return errorutils.CheckError(errors.New("failed verifying plugin version. Unexpected plugin output for version command: '" + versionCmdOut + "'"))
+		return "", errorutils.CheckError(errors.New("failed verifying plugin version. Unexpected plugin output for version command: '" + versionCmdOut + "'"))
 	}
-	if split[2] != expectedPluginVersion {
-		return errorutils.CheckError(errors.New("provided version does not match the plugin's actual version. " +
-			"Provided: '" + expectedPluginVersion + "', Actual: '" + split[2] + "'"))
+	return split[2], nil
+}
+
+// Calculates the sha256 checksum of a local file.
+func CalcSha256(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// Writes the file through a temp file in the same dir, which is then renamed to it.
+// This way, concurrent JFrog CLI runs never read a partially written file.
+func WriteFileAtomically(filePath string, content []byte) error {
+	if err := fileutils.CreateDirIfNotExist(filepath.Dir(filePath)); err != nil {
+		return err
+	}
+	tmpFile, err := ioutil.TempFile(filepath.Dir(filePath), filepath.Base(filePath)+".*.tmp")
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	_, err = tmpFile.Write(content)
+	closeErr := tmpFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if errorutils.CheckError(err) != nil {
+		os.Remove(tmpFile.Name())
+		return err
+	}
+	if err = os.Rename(tmpFile.Name(), filePath); errorutils.CheckError(err) != nil {
+		os.Remove(tmpFile.Name())
+		return err
 	}
 	return nil
 }
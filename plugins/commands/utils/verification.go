@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// Path to an ed25519 private key in PEM format (PKCS #8).
+	// If provided, the 'publish' command signs the published plugins' details with this key.
+	PluginsSigningKeyEnv = "JFROG_CLI_PLUGINS_SIGNING_KEY"
+	// Path to an ed25519 public key in PEM format (PKIX).
+	// If provided, the 'install' command verifies the signature of the plugin's details with this key.
+	PluginsPublicKeyEnv = "JFROG_CLI_PLUGINS_PUBLIC_KEY"
+
+	// Extensions of the files published next to each plugin executable.
+	ChecksumFileExtension  = ".sha256"
+	SignatureFileExtension = ".sig"
+)
+
+// Creates a checksum file next to the provided file, in the format of the sha256sum utility.
+// Returns the path of the created file.
+func CreateChecksumFile(filePath string) (string, error) {
+	sha256, err := CalcSha256(filePath)
+	if err != nil {
+		return "", err
+	}
+	checksumFilePath := filePath + ChecksumFileExtension
+	content := sha256 + "  " + filepath.Base(filePath) + "\n"
+	return checksumFilePath, errorutils.CheckError(ioutil.WriteFile(checksumFilePath, []byte(content), 0644))
+}
+
+// Returns the sha256 stored in the content of a checksum file.
+func ParseChecksumFile(content []byte) (string, error) {
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 || len(fields[0]) != 64 {
+		return "", errorutils.CheckError(errors.New("unexpected content of the plugin's checksum file: '" + string(content) + "'"))
+	}
+	return strings.ToLower(fields[0]), nil
+}
+
+// The details of a published plugin executable, signed by its publisher.
+// Signing the details rather than the checksum alone binds the checksum to the plugin's name, version and architecture,
+// so that a signed executable cannot be served as another plugin, version or architecture.
+type SignedPluginDetails struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Arch    string `json:"arch"`
+	Sha256  string `json:"sha256"`
+}
+
+// The content of the signature file published next to a plugin executable.
+type signatureFile struct {
+	// The base64 encoded JSON of the signed plugin details.
+	Payload   string `json:"payload"`
+	Signature string `json:"signature"`
+}
+
+// Signs the plugin's details using the private key in the provided path, and writes them to a signature file next to the plugin's executable.
+// Returns the path of the created signature file.
+func CreateSignatureFile(pluginPath string, details *SignedPluginDetails, privateKeyPath string) (string, error) {
+	privateKey, err := readPrivateKey(privateKeyPath)
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(details)
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	content, err := json.Marshal(&signatureFile{
+		Payload:   base64.StdEncoding.EncodeToString(payload),
+		Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, payload)),
+	})
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	signatureFilePath := pluginPath + SignatureFileExtension
+	return signatureFilePath, errorutils.CheckError(ioutil.WriteFile(signatureFilePath, content, 0644))
+}
+
+// Verifies the signature file using the public key in the provided path, and asserts the signed details match the expected plugin.
+// If the expected version is 'latest', the signed details may be of any version, since the latest version is a copy of a published version.
+func VerifySignatureFile(content []byte, expected *SignedPluginDetails, publicKeyPath string) error {
+	publicKey, err := readPublicKey(publicKeyPath)
+	if err != nil {
+		return err
+	}
+	file := new(signatureFile)
+	if err = json.Unmarshal(content, file); err != nil {
+		return errorutils.CheckError(errors.New("failed parsing the plugin's signature file: " + err.Error()))
+	}
+	payload, err := base64.StdEncoding.DecodeString(file.Payload)
+	if err != nil {
+		return errorutils.CheckError(errors.New("failed decoding the plugin's signed details: " + err.Error()))
+	}
+	signature, err := base64.StdEncoding.DecodeString(file.Signature)
+	if err != nil {
+		return errorutils.CheckError(errors.New("failed decoding the plugin's signature: " + err.Error()))
+	}
+	if !ed25519.Verify(publicKey, payload, signature) {
+		return errorutils.CheckError(errors.New("the plugin's signature does not match the public key provided by " + PluginsPublicKeyEnv))
+	}
+	signed := new(SignedPluginDetails)
+	if err = json.Unmarshal(payload, signed); err != nil {
+		return errorutils.CheckError(errors.New("failed parsing the plugin's signed details: " + err.Error()))
+	}
+	if signed.Name != expected.Name || signed.Arch != expected.Arch || signed.Sha256 != expected.Sha256 ||
+		(expected.Version != LatestVersionName && signed.Version != expected.Version) {
+		return errorutils.CheckError(fmt.Errorf("the plugin's signed details do not match the downloaded plugin. "+
+			"Signed: '%s@%s' (%s, sha256 %s), Downloaded: '%s@%s' (%s, sha256 %s)",
+			signed.Name, signed.Version, signed.Arch, signed.Sha256, expected.Name, expected.Version, expected.Arch, expected.Sha256))
+	}
+	return nil
+}
+
+func readPrivateKey(keyPath string) (ed25519.PrivateKey, error) {
+	block, err := readPemFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errorutils.CheckError(errors.New("the private key in '" + keyPath + "' is not an ed25519 key"))
+	}
+	return privateKey, nil
+}
+
+func readPublicKey(keyPath string) (ed25519.PublicKey, error) {
+	block, err := readPemFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, errorutils.CheckError(errors.New("the public key in '" + keyPath + "' is not an ed25519 key"))
+	}
+	return publicKey, nil
+}
+
+func readPemFile(filePath string) (*pem.Block, error) {
+	content, err := fileutils.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errorutils.CheckError(errors.New("failed decoding the PEM file '" + filePath + "'"))
+	}
+	return block, nil
+}
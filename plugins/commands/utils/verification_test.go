@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestChecksumFile(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+
+	pluginPath := filepath.Join(tmpDir, "hello-frog")
+	assert.NoError(t, ioutil.WriteFile(pluginPath, []byte("hello-frog"), 0644))
+	checksumFilePath, err := CreateChecksumFile(pluginPath)
+	assert.NoError(t, err)
+	assert.Equal(t, pluginPath+ChecksumFileExtension, checksumFilePath)
+
+	content, err := ioutil.ReadFile(checksumFilePath)
+	assert.NoError(t, err)
+	sha256, err := ParseChecksumFile(content)
+	assert.NoError(t, err)
+	expectedSha256, err := CalcSha256(pluginPath)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSha256, sha256)
+
+	_, err = ParseChecksumFile([]byte("not-a-checksum"))
+	assert.Error(t, err)
+}
+
+func TestSignatureFile(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+
+	privateKeyPath, publicKeyPath := createKeyPair(t, tmpDir, "signing")
+	_, otherPublicKeyPath := createKeyPair(t, tmpDir, "other")
+
+	pluginPath := filepath.Join(tmpDir, "hello-frog")
+	details := &SignedPluginDetails{Name: "hello-frog", Version: "1.0.0", Arch: "linux-amd64", Sha256: "a1b2"}
+	signatureFilePath, err := CreateSignatureFile(pluginPath, details, privateKeyPath)
+	assert.NoError(t, err)
+	assert.Equal(t, pluginPath+SignatureFileExtension, signatureFilePath)
+	signature, err := ioutil.ReadFile(signatureFilePath)
+	assert.NoError(t, err)
+
+	assert.NoError(t, VerifySignatureFile(signature, details, publicKeyPath))
+	// The latest version is a copy of a published version.
+	assert.NoError(t, VerifySignatureFile(signature, &SignedPluginDetails{Name: "hello-frog", Version: LatestVersionName, Arch: "linux-amd64", Sha256: "a1b2"}, publicKeyPath))
+	// A signed plugin served as another version, architecture or plugin, or with another checksum.
+	assert.Error(t, VerifySignatureFile(signature, &SignedPluginDetails{Name: "hello-frog", Version: "1.1.0", Arch: "linux-amd64", Sha256: "a1b2"}, publicKeyPath))
+	assert.Error(t, VerifySignatureFile(signature, &SignedPluginDetails{Name: "hello-frog", Version: "1.0.0", Arch: "linux-arm64", Sha256: "a1b2"}, publicKeyPath))
+	assert.Error(t, VerifySignatureFile(signature, &SignedPluginDetails{Name: "other-frog", Version: "1.0.0", Arch: "linux-amd64", Sha256: "a1b2"}, publicKeyPath))
+	assert.Error(t, VerifySignatureFile(signature, &SignedPluginDetails{Name: "hello-frog", Version: "1.0.0", Arch: "linux-amd64", Sha256: "c3d4"}, publicKeyPath))
+	// Signed by another key, or not a signature file.
+	assert.Error(t, VerifySignatureFile(signature, details, otherPublicKeyPath))
+	assert.Error(t, VerifySignatureFile([]byte("invalid signature"), details, publicKeyPath))
+}
+
+func createKeyPair(t *testing.T, dir, name string) (privateKeyPath, publicKeyPath string) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	assert.NoError(t, err)
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(publicKey)
+	assert.NoError(t, err)
+
+	privateKeyPath = filepath.Join(dir, name+".key")
+	publicKeyPath = filepath.Join(dir, name+".pub")
+	assert.NoError(t, ioutil.WriteFile(privateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyBytes}), 0600))
+	assert.NoError(t, ioutil.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}), 0644))
+	return
+}
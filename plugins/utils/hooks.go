@@ -0,0 +1,215 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const (
+	BeforeHook = "before"
+	AfterHook  = "after"
+)
+
+// Plugins declare their hooks by implementing a command with this name, which prints the hooks as a JSON array.
+// Plugins without this command declare no hooks.
+const HooksCommandName = "cli-hooks"
+
+// The values of these flags are masked in the hooks payload.
+var secretFlags = map[string]bool{"password": true, "apikey": true, "access-token": true, "ssh-passphrase": true}
+
+// A hook declared by a plugin's hooks command.
+// Example:
+// {"when": "before", "command": "rt upload", "run": "check-naming"}
+type Hook struct {
+	// Either 'before' or 'after'.
+	When string `json:"when"`
+	// The hooked JFrog CLI command, such as 'rt upload' or 'rt build-publish'. Command aliases are also accepted.
+	Command string `json:"command"`
+	// The plugin's command to run. The hook's payload is passed to it through the standard input.
+	Run string `json:"run"`
+}
+
+func (hook *Hook) validate() error {
+	if hook.When != BeforeHook && hook.When != AfterHook {
+		return errors.New(fmt.Sprintf("unexpected hook type '%s'. Expecting '%s' or '%s'", hook.When, BeforeHook, AfterHook))
+	}
+	if len(strings.Fields(hook.Command)) == 0 || len(strings.Fields(hook.Command)) > 2 {
+		return errors.New(fmt.Sprintf("unexpected hooked command '%s'", hook.Command))
+	}
+	if hook.Run == "" {
+		return errors.New("the command to run is missing in the hook of '" + hook.Command + "'")
+	}
+	return nil
+}
+
+// Runs the plugin's hooks command, and returns the hooks the plugin declares.
+// The command's errors are not returned, since plugins which don't declare hooks don't have this command.
+func runHooksCommand(execPath string) []*Hook {
+	// The error output is discarded, since plugins without the hooks command print their usage to it.
+	output, err := exec.Command(execPath, HooksCommandName).Output()
+	if err != nil {
+		log.Debug(pluginsErrorPrefix + "no hooks were declared by '" + execPath + "': " + err.Error())
+		return nil
+	}
+	return parseHooks(execPath, output)
+}
+
+// Parses the output of the plugin's hooks command. Hooks which are not valid are skipped with a warning.
+func parseHooks(execPath string, output []byte) []*Hook {
+	output = bytes.TrimSpace(output)
+	// Plugins without the hooks command may print their usage instead.
+	if !bytes.HasPrefix(output, []byte("[")) {
+		log.Debug(pluginsErrorPrefix + "no hooks were declared by '" + execPath + "'.")
+		return nil
+	}
+	var hooks []*Hook
+	if err := json.Unmarshal(output, &hooks); err != nil {
+		log.Warn(pluginsErrorPrefix + "failed parsing the hooks of '" + execPath + "': " + err.Error())
+		return nil
+	}
+	var validHooks []*Hook
+	for _, hook := range hooks {
+		if err := hook.validate(); err != nil {
+			log.Warn(pluginsErrorPrefix + "skipping a hook of '" + execPath + "': " + err.Error())
+			continue
+		}
+		validHooks = append(validHooks, hook)
+	}
+	return validHooks
+}
+
+// The JSON passed to a hook through the standard input.
+type hookPayload struct {
+	Hook    string            `json:"hook"`
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	Flags   map[string]string `json:"flags"`
+	// Only provided to 'after' hooks.
+	Result *hookResult `json:"result,omitempty"`
+}
+
+type hookResult struct {
+	Status summary.StatusType `json:"status"`
+	Error  string             `json:"error,omitempty"`
+	// The summary report of the command, if it has one.
+	Summary interface{} `json:"summary,omitempty"`
+}
+
+type pluginHook struct {
+	*Hook
+	pluginName     string
+	executablePath string
+}
+
+// Wraps the actions of the hooked commands, to run the plugins' hooks before and after them.
+// Hooks of commands which do not exist are ignored.
+func addHooks(commands []cli.Command, signatures []*PluginSignature) {
+	hooksByCommand := map[string][]*pluginHook{}
+	var orderedCommands []string
+	for _, sig := range signatures {
+		for _, hook := range sig.Hooks {
+			command := strings.Join(strings.Fields(hook.Command), " ")
+			if _, exists := hooksByCommand[command]; !exists {
+				orderedCommands = append(orderedCommands, command)
+			}
+			hooksByCommand[command] = append(hooksByCommand[command], &pluginHook{Hook: hook, pluginName: sig.Name, executablePath: sig.ExecutablePath})
+		}
+	}
+	for _, command := range orderedCommands {
+		cmd := findCommand(commands, strings.Fields(command))
+		if cmd == nil || cmd.Action == nil {
+			log.Debug(pluginsErrorPrefix + "ignoring the hooks of a non existing command: '" + command + "'")
+			continue
+		}
+		cmd.Action = getHookedAction(command, cmd.Action, hooksByCommand[command])
+	}
+}
+
+// Returns the command matching the provided names (such as ["rt", "upload"]), or nil if it does not exist.
+func findCommand(commands []cli.Command, names []string) *cli.Command {
+	for i := range commands {
+		if !commands[i].HasName(names[0]) {
+			continue
+		}
+		if len(names) == 1 {
+			return &commands[i]
+		}
+		return findCommand(commands[i].Subcommands, names[1:])
+	}
+	return nil
+}
+
+func getHookedAction(command string, action interface{}, hooks []*pluginHook) func(*cli.Context) error {
+	return func(c *cli.Context) error {
+		payload := &hookPayload{Command: command, Args: cliutils.ExtractCommand(c), Flags: getSetFlags(c)}
+		payload.Hook = BeforeHook
+		for _, hook := range hooks {
+			if hook.When != BeforeHook {
+				continue
+			}
+			if err := runHook(hook, payload); err != nil {
+				return errorutils.CheckError(errors.New(fmt.Sprintf("the '%s' hook of the '%s' plugin failed: %s", hook.Run, hook.pluginName, err.Error())))
+			}
+		}
+
+		err := cli.HandleAction(action, c)
+
+		payload.Hook = AfterHook
+		payload.Result = &hookResult{Status: summary.Success, Summary: cliutils.GetLastSummaryReport()}
+		if err != nil {
+			payload.Result.Status = summary.Failure
+			payload.Result.Error = err.Error()
+		}
+		for _, hook := range hooks {
+			if hook.When != AfterHook {
+				continue
+			}
+			// The command already ran, so a failing 'after' hook should not change its result.
+			if hookErr := runHook(hook, payload); hookErr != nil {
+				log.Warn(fmt.Sprintf("the '%s' hook of the '%s' plugin failed: %s", hook.Run, hook.pluginName, hookErr.Error()))
+			}
+		}
+		return err
+	}
+}
+
+// Returns the values of the flags which were set for the command.
+func getSetFlags(c *cli.Context) map[string]string {
+	flags := map[string]string{}
+	for _, name := range c.FlagNames() {
+		if !c.IsSet(name) {
+			continue
+		}
+		if secretFlags[name] {
+			flags[name] = "***"
+			continue
+		}
+		flags[name] = c.String(name)
+	}
+	return flags
+}
+
+// Runs the hook's plugin command, with the payload in its standard input.
+// The plugin's output is redirected to the standard error, to keep the standard output of the hooked command clean.
+func runHook(hook *pluginHook, payload *hookPayload) error {
+	content, err := json.Marshal(payload)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	log.Debug(fmt.Sprintf("Running the '%s' hook of the '%s' plugin...", hook.Run, hook.pluginName))
+	cmd := exec.Command(hook.executablePath, hook.Run)
+	cmd.Stdin = bytes.NewReader(content)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"encoding/json"
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli-core/plugins/components"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestHookValidation(t *testing.T) {
+	assert.NoError(t, (&Hook{When: BeforeHook, Command: "rt upload", Run: "check"}).validate())
+	assert.NoError(t, (&Hook{When: AfterHook, Command: "ci-setup", Run: "notify"}).validate())
+	assert.Error(t, (&Hook{When: "during", Command: "rt upload", Run: "check"}).validate())
+	assert.Error(t, (&Hook{When: BeforeHook, Command: "", Run: "check"}).validate())
+	assert.Error(t, (&Hook{When: BeforeHook, Command: "rt upload extra", Run: "check"}).validate())
+	assert.Error(t, (&Hook{When: BeforeHook, Command: "rt upload"}).validate())
+}
+
+func TestHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The plugin mock is a shell script.")
+	}
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+
+	// The mock plugin stores each hook's payload in a file named after the hook's command, and fails the 'reject' command.
+	pluginPath := filepath.Join(tmpDir, "hooks-plugin")
+	script := "#!/bin/sh\nif [ \"$1\" = reject ]; then exit 1; fi\ncat > \"" + tmpDir + "/$1.json\"\n"
+	assert.NoError(t, ioutil.WriteFile(pluginPath, []byte(script), 0755))
+
+	actionRuns := 0
+	commands := []cli.Command{{
+		Name: "rt",
+		Subcommands: []cli.Command{{
+			Name:    "upload",
+			Aliases: []string{"u"},
+			Flags:   []cli.Flag{cli.StringFlag{Name: "password"}, cli.BoolFlag{Name: "dry-run"}},
+			Action: func(c *cli.Context) error {
+				actionRuns++
+				return nil
+			},
+		}},
+	}}
+	sig := &PluginSignature{
+		PluginSignature: components.PluginSignature{Name: "hooks-plugin", ExecutablePath: pluginPath},
+		Hooks: []*Hook{
+			{When: BeforeHook, Command: "rt u", Run: "before"},
+			{When: AfterHook, Command: "rt upload", Run: "after"},
+			{When: AfterHook, Command: "rt non-existing", Run: "after"},
+		},
+	}
+	addHooks(commands, []*PluginSignature{sig})
+	app := cli.NewApp()
+	app.Commands = commands
+	assert.NoError(t, app.Run([]string{"jfrog", "rt", "upload", "--password=secret", "--dry-run", "a/*", "repo/"}))
+	assert.Equal(t, 1, actionRuns)
+
+	before := readPayload(t, filepath.Join(tmpDir, "before.json"))
+	assert.Equal(t, BeforeHook, before.Hook)
+	assert.Equal(t, "rt u", before.Command)
+	assert.Equal(t, []string{"a/*", "repo/"}, before.Args)
+	assert.Equal(t, map[string]string{"password": "***", "dry-run": "true"}, before.Flags)
+	assert.Nil(t, before.Result)
+	after := readPayload(t, filepath.Join(tmpDir, "after.json"))
+	assert.Equal(t, AfterHook, after.Hook)
+	if assert.NotNil(t, after.Result) {
+		assert.Equal(t, summary.Success, after.Result.Status)
+	}
+
+	// A failing 'before' hook should prevent the command from running.
+	sig.Hooks = []*Hook{{When: BeforeHook, Command: "rt upload", Run: "reject"}}
+	addHooks(commands, []*PluginSignature{sig})
+	assert.Error(t, app.Run([]string{"jfrog", "rt", "upload", "a/*", "repo/"}))
+	assert.Equal(t, 1, actionRuns)
+}
+
+func readPayload(t *testing.T, path string) *hookPayload {
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	payload := new(hookPayload)
+	assert.NoError(t, json.Unmarshal(content, payload))
+	return payload
+}
+
+func TestRunHooksCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The plugin mock is a shell script.")
+	}
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+
+	// The mock plugin declares a valid hook and an invalid one, which is skipped.
+	pluginPath := filepath.Join(tmpDir, "hooks-plugin")
+	script := "#!/bin/sh\nif [ \"$1\" = " + HooksCommandName + " ]; then\n" +
+		"echo '[{\"when\": \"before\", \"command\": \"rt upload\", \"run\": \"check-naming\"}, {\"when\": \"during\", \"command\": \"rt upload\", \"run\": \"check\"}]'\n" +
+		"fi\n"
+	assert.NoError(t, ioutil.WriteFile(pluginPath, []byte(script), 0755))
+	assert.Equal(t, []*Hook{{When: BeforeHook, Command: "rt upload", Run: "check-naming"}}, runHooksCommand(pluginPath))
+
+	// Plugins without the hooks command declare no hooks, whether they fail or print their usage.
+	assert.NoError(t, ioutil.WriteFile(pluginPath, []byte("#!/bin/sh\necho >&2 'No help topic'\nexit 3\n"), 0755))
+	assert.Empty(t, runHooksCommand(pluginPath))
+	assert.NoError(t, ioutil.WriteFile(pluginPath, []byte("#!/bin/sh\necho 'USAGE: hooks-plugin command'\n"), 0755))
+	assert.Empty(t, runHooksCommand(pluginPath))
+}
@@ -0,0 +1,201 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/plugins"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	commandsutils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"os"
+	"path/filepath"
+)
+
+// The signatures cache file is stored in the JFrog home dir, and not in the plugins dir,
+// since every file in the plugins dir is considered a plugin executable.
+const signaturesCacheFileName = "plugins-signatures.json"
+
+// Should be increased whenever the structure of the cached signatures changes, to have all plugins executed again.
+const signaturesCacheVersion = 2
+
+// Holds the signatures of the installed plugins, keyed by the name of their executable file.
+// Running every plugin to get its signature on each CLI invocation is expensive,
+// so a plugin is only executed again once its executable has changed.
+type signaturesCache struct {
+	Version int                         `json:"version"`
+	Plugins map[string]*cachedSignature `json:"plugins"`
+	// Set when the cache was modified and should be saved.
+	modified bool
+}
+
+type cachedSignature struct {
+	ModTime   int64            `json:"modTime"`
+	Size      int64            `json:"size"`
+	Sha256    string           `json:"sha256"`
+	Signature *PluginSignature `json:"signature,omitempty"`
+	// The error returned from the plugin while retrieving its signature.
+	// Stored in order to avoid running a broken plugin again, before it is replaced.
+	Error string `json:"error,omitempty"`
+}
+
+func getSignaturesCacheFilePath() (string, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, signaturesCacheFileName), nil
+}
+
+// Reads the signatures cache from the JFrog home dir.
+// A missing or corrupted cache file is treated as an empty cache.
+func loadSignaturesCache() *signaturesCache {
+	cache := &signaturesCache{Version: signaturesCacheVersion, Plugins: map[string]*cachedSignature{}}
+	cachePath, err := getSignaturesCacheFilePath()
+	if err != nil {
+		return cache
+	}
+	exists, err := fileutils.IsFileExists(cachePath, false)
+	if err != nil || !exists {
+		return cache
+	}
+	content, err := fileutils.ReadFile(cachePath)
+	if err != nil {
+		log.Debug(pluginsErrorPrefix + "failed reading the plugins signatures cache: " + err.Error())
+		return cache
+	}
+	if err = json.Unmarshal(content, cache); err != nil || cache.Plugins == nil {
+		log.Debug(pluginsErrorPrefix + "the plugins signatures cache is corrupted and will be recreated.")
+		cache.Plugins = map[string]*cachedSignature{}
+		cache.modified = true
+	}
+	if cache.Version != signaturesCacheVersion {
+		log.Debug(pluginsErrorPrefix + "the plugins signatures cache was created by a different version of JFrog CLI and will be recreated.")
+		cache.Version = signaturesCacheVersion
+		cache.Plugins = map[string]*cachedSignature{}
+		cache.modified = true
+	}
+	return cache
+}
+
+// Saves the cache if it was modified.
+// The content is written to a temp file which is then renamed, so that concurrent CLI runs never read a partially written cache.
+func (cache *signaturesCache) save() error {
+	if !cache.modified {
+		return nil
+	}
+	cachePath, err := getSignaturesCacheFilePath()
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(cache)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	if err = commandsutils.WriteFileAtomically(cachePath, content); err != nil {
+		return err
+	}
+	cache.modified = false
+	return nil
+}
+
+// Returns the signature of the plugin executable, from the cache if the executable was not changed since it was cached.
+// Otherwise, the plugin is executed and the result (including a failure) is stored in the cache.
+func (cache *signaturesCache) getSignature(execPath string, fileInfo os.FileInfo) (*PluginSignature, error) {
+	fileName := fileInfo.Name()
+	entry, exists := cache.Plugins[fileName]
+	if exists && entry.ModTime == fileInfo.ModTime().UnixNano() && entry.Size == fileInfo.Size() {
+		return entry.toSignature(execPath)
+	}
+
+	sha256, err := commandsutils.CalcSha256(execPath)
+	if err != nil {
+		return nil, err
+	}
+	// The executable was touched, but its content remains the same.
+	if exists && entry.Sha256 == sha256 {
+		entry.ModTime = fileInfo.ModTime().UnixNano()
+		entry.Size = fileInfo.Size()
+		cache.modified = true
+		return entry.toSignature(execPath)
+	}
+
+	entry = &cachedSignature{ModTime: fileInfo.ModTime().UnixNano(), Size: fileInfo.Size(), Sha256: sha256}
+	entry.Signature, err = runSignatureCommand(execPath)
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	cache.Plugins[fileName] = entry
+	cache.modified = true
+	return entry.toSignature(execPath)
+}
+
+// Removes cached signatures of executables which no longer exist in the plugins dir.
+func (cache *signaturesCache) removeMissing(existingFileNames map[string]bool) {
+	for fileName := range cache.Plugins {
+		if !existingFileNames[fileName] {
+			delete(cache.Plugins, fileName)
+			cache.modified = true
+		}
+	}
+}
+
+func (entry *cachedSignature) toSignature(execPath string) (*PluginSignature, error) {
+	if entry.Error != "" {
+		return nil, errors.New(entry.Error)
+	}
+	if entry.Signature == nil {
+		return nil, errors.New("empty signature")
+	}
+	signature := *entry.Signature
+	signature.ExecutablePath = execPath
+	return &signature, nil
+}
+
+// Runs the plugin's hidden signature command, and its hooks command.
+func runSignatureCommand(execPath string) (*PluginSignature, error) {
+	output, err := gofrogcmd.RunCmdOutput(
+		&PluginExecCmd{
+			execPath,
+			[]string{plugins.SignatureCommandName},
+		})
+	if err != nil {
+		return nil, err
+	}
+	signature := new(PluginSignature)
+	err = json.Unmarshal([]byte(output), &signature.PluginSignature)
+	if err != nil {
+		return nil, errors.New("failed unmarshalling signature: " + err.Error())
+	}
+	signature.Hooks = runHooksCommand(execPath)
+	return signature, nil
+}
+
+// Refreshes the cached signature of a single plugin. Should be called after the plugin was installed or updated.
+func RefreshPluginSignature(pluginsDir, pluginExecutableName string) error {
+	cache := loadSignaturesCache()
+	delete(cache.Plugins, pluginExecutableName)
+	execPath := filepath.Join(pluginsDir, pluginExecutableName)
+	fileInfo, err := os.Stat(execPath)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	_, sigErr := cache.getSignature(execPath, fileInfo)
+	if err = cache.save(); err != nil {
+		return err
+	}
+	return errorutils.CheckError(sigErr)
+}
+
+// Removes the cached signature of a single plugin. Should be called after the plugin was uninstalled.
+func RemovePluginSignature(pluginExecutableName string) error {
+	cache := loadSignaturesCache()
+	if _, exists := cache.Plugins[pluginExecutableName]; !exists {
+		return nil
+	}
+	delete(cache.Plugins, pluginExecutableName)
+	cache.modified = true
+	return cache.save()
+}
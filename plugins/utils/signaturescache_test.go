@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"github.com/jfrog/jfrog-cli-core/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/utils/log"
+	coreTests "github.com/jfrog/jfrog-cli-core/utils/tests"
+	commandsutils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func init() {
+	log.SetDefaultLogger()
+}
+
+const pluginMockPath = "../../testdata/plugins/plugin-mock"
+
+func TestSignaturesCache(t *testing.T) {
+	// Clean from previous tests.
+	coreTests.CleanUnitTestsJfrogHome()
+	// Create temp jfrog home
+	oldHome, err := coreTests.SetJfrogHome()
+	if err != nil {
+		return
+	}
+	defer os.Setenv(coreutils.HomeDir, oldHome)
+	defer coreTests.CleanUnitTestsJfrogHome()
+
+	// Create a file in plugins dir to mock a plugin. The mock is not executable,
+	// so its signature can only be retrieved from the cache.
+	pluginsDir, err := coreutils.GetJfrogPluginsDir()
+	assert.NoError(t, err)
+	assert.NoError(t, fileutils.CopyFile(pluginsDir, pluginMockPath))
+	pluginFileName := filepath.Base(pluginMockPath)
+	execPath := filepath.Join(pluginsDir, pluginFileName)
+	fileInfo, err := os.Stat(execPath)
+	assert.NoError(t, err)
+	sha256, err := commandsutils.CalcSha256(execPath)
+	assert.NoError(t, err)
+
+	// Cache a signature matching the mock.
+	cache := loadSignaturesCache()
+	assert.Empty(t, cache.Plugins)
+	cache.Plugins[pluginFileName] = &cachedSignature{
+		ModTime:   fileInfo.ModTime().UnixNano(),
+		Size:      fileInfo.Size(),
+		Sha256:    sha256,
+		Signature: &PluginSignature{PluginSignature: components.PluginSignature{Name: "plugin-mock", Usage: "usage"}},
+	}
+	cache.Plugins["uninstalled-plugin"] = &cachedSignature{}
+	cache.modified = true
+	assert.NoError(t, cache.save())
+
+	// Assert the signature is read from the cache and the missing plugin is removed.
+	signatures, err := getPluginsSignatures()
+	assert.NoError(t, err)
+	if assert.Len(t, signatures, 1) {
+		assert.Equal(t, "plugin-mock", signatures[0].Name)
+		assert.Equal(t, execPath, signatures[0].ExecutablePath)
+	}
+	cache = loadSignaturesCache()
+	assert.Len(t, cache.Plugins, 1)
+	assert.Contains(t, cache.Plugins, pluginFileName)
+
+	// Assert a changed executable is executed again, and its failure is cached.
+	// A plugin which fails is skipped, without failing the other plugins.
+	assert.NoError(t, ioutil.WriteFile(execPath, []byte("This is a changed plugin mock."), 0600))
+	signatures, err = getPluginsSignatures()
+	assert.NoError(t, err)
+	assert.Empty(t, signatures)
+	cache = loadSignaturesCache()
+	if assert.Contains(t, cache.Plugins, pluginFileName) {
+		assert.NotEmpty(t, cache.Plugins[pluginFileName].Error)
+		assert.Nil(t, cache.Plugins[pluginFileName].Signature)
+	}
+
+	// Assert the cached signature is removed.
+	assert.NoError(t, RemovePluginSignature(pluginFileName))
+	assert.Empty(t, loadSignaturesCache().Plugins)
+}
@@ -1,11 +1,8 @@
 package utils
 
 import (
-	"encoding/json"
 	"fmt"
 	"github.com/codegangsta/cli"
-	gofrogcmd "github.com/jfrog/gofrog/io"
-	"github.com/jfrog/jfrog-cli-core/plugins"
 	"github.com/jfrog/jfrog-cli-core/plugins/components"
 	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
@@ -21,9 +18,19 @@ import (
 
 const pluginsErrorPrefix = "jfrog cli plugins: "
 
+// The signature of an installed plugin, as returned by the plugin's hidden signature command,
+// with the hooks returned by the plugin's hooks command.
+type PluginSignature struct {
+	components.PluginSignature
+	// Hooks declared by the plugin, to be run before or after JFrog CLI commands.
+	Hooks []*Hook `json:"hooks,omitempty"`
+}
+
 // Gets all the installed plugins' signatures by looping over the plugins dir.
-func getPluginsSignatures() ([]*components.PluginSignature, error) {
-	var signatures []*components.PluginSignature
+// Plugins are only executed if their signature is not found in the signatures cache.
+// A plugin whose signature could not be retrieved is skipped, without affecting the other plugins.
+func getPluginsSignatures() ([]*PluginSignature, error) {
+	var signatures []*PluginSignature
 	pluginsDir, err := coreutils.GetJfrogPluginsDir()
 	if err != nil {
 		return signatures, err
@@ -38,35 +45,29 @@ func getPluginsSignatures() ([]*components.PluginSignature, error) {
 		return signatures, errorutils.CheckError(err)
 	}
 
-	var finalErr error
+	cache := loadSignaturesCache()
+	existingFileNames := map[string]bool{}
 	for _, f := range files {
 		if f.IsDir() {
 			logSkippablePluginsError("unexpected directory in plugins directory", f.Name(), nil)
 			continue
 		}
+		existingFileNames[f.Name()] = true
 		pluginName := strings.TrimSuffix(f.Name(), filepath.Ext(f.Name()))
 		execPath := filepath.Join(pluginsDir, f.Name())
-		output, err := gofrogcmd.RunCmdOutput(
-			&PluginExecCmd{
-				execPath,
-				[]string{plugins.SignatureCommandName},
-			})
+		curSignature, err := cache.getSignature(execPath, f)
 		if err != nil {
-			finalErr = err
 			logSkippablePluginsError("failed getting signature from plugin", pluginName, err)
 			continue
 		}
-		curSignature := new(components.PluginSignature)
-		err = json.Unmarshal([]byte(output), &curSignature)
-		if err != nil {
-			finalErr = err
-			logSkippablePluginsError("failed unmarshalling signature from plugin", pluginName, err)
-			continue
-		}
-		curSignature.ExecutablePath = execPath
 		signatures = append(signatures, curSignature)
 	}
-	return signatures, finalErr
+	cache.removeMissing(existingFileNames)
+	// Failing to save the cache should not fail the CLI, the signatures will be retrieved again on the next run.
+	if err = cache.save(); err != nil {
+		log.Debug(pluginsErrorPrefix + "failed saving the plugins signatures cache: " + err.Error())
+	}
+	return signatures, nil
 }
 
 func logSkippablePluginsError(msg, pluginName string, err error) {
@@ -77,7 +78,7 @@ func logSkippablePluginsError(msg, pluginName string, err error) {
 }
 
 // Converts signatures to commands to be appended to the CLI commands.
-func signaturesToCommands(signatures []*components.PluginSignature) []cli.Command {
+func signaturesToCommands(signatures []*PluginSignature) []cli.Command {
 	var commands []cli.Command
 	for _, sig := range signatures {
 		commands = append(commands, cli.Command{
@@ -90,7 +91,7 @@ func signaturesToCommands(signatures []*components.PluginSignature) []cli.Comman
 	return commands
 }
 
-func getAction(sig components.PluginSignature) func(*cli.Context) error {
+func getAction(sig PluginSignature) func(*cli.Context) error {
 	return func(c *cli.Context) error {
 		cmd := exec.Command(sig.ExecutablePath, cliutils.ExtractCommand(c)...)
 		cmd.Stdout = os.Stdout
@@ -100,12 +101,15 @@ func getAction(sig components.PluginSignature) func(*cli.Context) error {
 	}
 }
 
-func GetPlugins() []cli.Command {
+// Appends the installed plugins' commands to the provided commands,
+// and adds the plugins' hooks to the commands they were declared for.
+func AddPlugins(commands []cli.Command) []cli.Command {
 	signatures, err := getPluginsSignatures()
 	if err != nil {
 		// Intentionally ignoring error to avoid failing if running other commands.
 		log.Error("failed adding certain plugins as commands. Last error: " + err.Error())
-		return []cli.Command{}
+		return commands
 	}
-	return signaturesToCommands(signatures)
+	addHooks(commands, signatures)
+	return append(commands, signaturesToCommands(signatures)...)
 }
@@ -0,0 +1,127 @@
+package attestation
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	statementType      = "https://in-toto.io/Statement/v0.1"
+	PredicateType      = "https://slsa.dev/provenance/v0.2"
+	buildType          = "https://github.com/jfrog/jfrog-cli/build-publish@v1"
+	payloadType        = "application/vnd.in-toto+json"
+	FileExtension      = ".intoto.jsonl"
+	slsaTimeFormat     = time.RFC3339
+	gitMaterialsPrefix = "git+"
+)
+
+// An in-toto statement, as defined by https://github.com/in-toto/attestation/blob/main/spec/v0.1.0/statement.md.
+type Statement struct {
+	Type          string              `json:"_type"`
+	PredicateType string              `json:"predicateType"`
+	Subject       []Subject           `json:"subject"`
+	Predicate     ProvenancePredicate `json:"predicate"`
+}
+
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// A SLSA provenance predicate, as defined by https://slsa.dev/provenance/v0.2.
+type ProvenancePredicate struct {
+	Builder   Builder    `json:"builder"`
+	BuildType string     `json:"buildType"`
+	Metadata  Metadata   `json:"metadata"`
+	Materials []Material `json:"materials,omitempty"`
+}
+
+type Builder struct {
+	Id string `json:"id"`
+}
+
+type Metadata struct {
+	BuildInvocationId string       `json:"buildInvocationId"`
+	BuildStartedOn    string       `json:"buildStartedOn,omitempty"`
+	BuildFinishedOn   string       `json:"buildFinishedOn"`
+	Completeness      Completeness `json:"completeness"`
+	Reproducible      bool         `json:"reproducible"`
+}
+
+// The build-info doesn't hold the parameters of the build, and doesn't necessarily hold all of its environment and materials.
+type Completeness struct {
+	Parameters  bool `json:"parameters"`
+	Environment bool `json:"environment"`
+	Materials   bool `json:"materials"`
+}
+
+type Material struct {
+	Uri    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// Creates the provenance statement of a published build.
+// The subjects are the artifacts of the build, by their paths in Artifactory, mapped to their sha256 checksums.
+// The materials are the VCS revisions of the build, as collected by 'build-add-git'.
+// The builder is identified by the URL of the CI build which ran the build.
+func CreateStatement(buildInfo *buildinfo.BuildInfo, artifacts map[string]string, builderId string) (*Statement, error) {
+	if builderId == "" {
+		return nil, errorutils.CheckError(errors.New("the builder ID of the provenance is missing. Set the build URL with the --build-url option or the JFROG_CLI_BUILD_URL environment variable"))
+	}
+	if len(artifacts) == 0 {
+		return nil, errorutils.CheckError(errors.New("build " + buildInfo.Name + "/" + buildInfo.Number + " has no artifacts with sha256 checksums to attest"))
+	}
+	statement := &Statement{
+		Type:          statementType,
+		PredicateType: PredicateType,
+		Predicate: ProvenancePredicate{
+			Builder:   Builder{Id: builderId},
+			BuildType: buildType,
+			Metadata: Metadata{
+				BuildInvocationId: buildInfo.Name + "/" + buildInfo.Number,
+				BuildStartedOn:    getStartedTime(buildInfo),
+				BuildFinishedOn:   time.Now().UTC().Format(slsaTimeFormat),
+			},
+		},
+	}
+	for path, sha256 := range artifacts {
+		statement.Subject = append(statement.Subject, Subject{Name: path, Digest: map[string]string{"sha256": sha256}})
+	}
+	sort.Slice(statement.Subject, func(i, j int) bool {
+		return statement.Subject[i].Name < statement.Subject[j].Name
+	})
+	for _, vcs := range buildInfo.VcsList {
+		if vcs.Url == "" {
+			continue
+		}
+		material := Material{Uri: vcs.Url}
+		if !strings.HasPrefix(material.Uri, gitMaterialsPrefix) {
+			material.Uri = gitMaterialsPrefix + material.Uri
+		}
+		if vcs.Revision != "" {
+			material.Digest = map[string]string{"sha1": vcs.Revision}
+		}
+		statement.Predicate.Materials = append(statement.Predicate.Materials, material)
+	}
+	return statement, nil
+}
+
+// Returns the statement as the JSON payload of its envelope.
+func (statement *Statement) Marshal() ([]byte, error) {
+	content, err := json.Marshal(statement)
+	return content, errorutils.CheckError(err)
+}
+
+func getStartedTime(buildInfo *buildinfo.BuildInfo) string {
+	started, err := time.Parse(buildinfo.TimeFormat, buildInfo.Started)
+	if err != nil {
+		return ""
+	}
+	return started.UTC().Format(slsaTimeFormat)
+}
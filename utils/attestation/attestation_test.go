@@ -0,0 +1,132 @@
+package attestation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestBuildInfo() *buildinfo.BuildInfo {
+	return &buildinfo.BuildInfo{
+		Name:    "app",
+		Number:  "42",
+		Started: "2021-03-01T10:00:00.000+0200",
+		VcsList: []buildinfo.Vcs{
+			{Url: "https://github.com/acme/app.git", Revision: "6a1b2c"},
+			{Url: "git+ssh://git@github.com/acme/lib.git"},
+		},
+	}
+}
+
+func TestCreateStatement(t *testing.T) {
+	statement, err := CreateStatement(createTestBuildInfo(), map[string]string{"repo/app/42/app.zip": "b2", "repo/app/42/app.pom": "a1"}, "https://ci.acme.io/job/app/42")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://in-toto.io/Statement/v0.1", statement.Type)
+	assert.Equal(t, "https://slsa.dev/provenance/v0.2", statement.PredicateType)
+	assert.Equal(t, []Subject{
+		{Name: "repo/app/42/app.pom", Digest: map[string]string{"sha256": "a1"}},
+		{Name: "repo/app/42/app.zip", Digest: map[string]string{"sha256": "b2"}},
+	}, statement.Subject)
+	assert.Equal(t, "https://ci.acme.io/job/app/42", statement.Predicate.Builder.Id)
+	assert.Equal(t, "app/42", statement.Predicate.Metadata.BuildInvocationId)
+	assert.Equal(t, "2021-03-01T08:00:00Z", statement.Predicate.Metadata.BuildStartedOn)
+	assert.NotEmpty(t, statement.Predicate.Metadata.BuildFinishedOn)
+	assert.Equal(t, []Material{
+		{Uri: "git+https://github.com/acme/app.git", Digest: map[string]string{"sha1": "6a1b2c"}},
+		{Uri: "git+ssh://git@github.com/acme/lib.git"},
+	}, statement.Predicate.Materials)
+
+	_, err = CreateStatement(createTestBuildInfo(), map[string]string{"repo/app.zip": "a"}, "")
+	assert.EqualError(t, err, "the builder ID of the provenance is missing. Set the build URL with the --build-url option or the JFROG_CLI_BUILD_URL environment variable")
+	_, err = CreateStatement(createTestBuildInfo(), nil, "https://ci.acme.io/job/app/42")
+	assert.EqualError(t, err, "build app/42 has no artifacts with sha256 checksums to attest")
+}
+
+func TestSign(t *testing.T) {
+	ed25519Public, ed25519Private, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecdsaDer, err := x509.MarshalECPrivateKey(ecdsaKey)
+	assert.NoError(t, err)
+	ed25519Der, err := x509.MarshalPKCS8PrivateKey(ed25519Private)
+	assert.NoError(t, err)
+
+	statement, err := CreateStatement(createTestBuildInfo(), map[string]string{"repo/app.zip": "a1"}, "https://ci.acme.io/job/app/42")
+	assert.NoError(t, err)
+	tests := []struct {
+		name   string
+		block  *pem.Block
+		verify func(message, signature []byte) bool
+	}{
+		{"ed25519", &pem.Block{Type: "PRIVATE KEY", Bytes: ed25519Der}, func(message, signature []byte) bool {
+			return ed25519.Verify(ed25519Public, message, signature)
+		}},
+		{"ecdsa", &pem.Block{Type: "EC PRIVATE KEY", Bytes: ecdsaDer}, func(message, signature []byte) bool {
+			digest := sha256.Sum256(message)
+			var ecdsaSignature struct{ R, S *big.Int }
+			if _, err := asn1.Unmarshal(signature, &ecdsaSignature); err != nil {
+				return false
+			}
+			return ecdsa.Verify(&ecdsaKey.PublicKey, digest[:], ecdsaSignature.R, ecdsaSignature.S)
+		}},
+		{"rsa", &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}, func(message, signature []byte) bool {
+			digest := sha256.Sum256(message)
+			return rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, digest[:], signature) == nil
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signer, err := newSigner(pem.EncodeToMemory(test.block))
+			if !assert.NoError(t, err) {
+				return
+			}
+			content, err := signer.Sign(statement)
+			assert.NoError(t, err)
+			assert.NotContains(t, string(content), "\n")
+			envelope := new(Envelope)
+			assert.NoError(t, json.Unmarshal(content, envelope))
+			assert.Equal(t, "application/vnd.in-toto+json", envelope.PayloadType)
+			payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+			assert.NoError(t, err)
+			signedStatement := new(Statement)
+			assert.NoError(t, json.Unmarshal(payload, signedStatement))
+			assert.Equal(t, statement, signedStatement)
+			if assert.Len(t, envelope.Signatures, 1) {
+				assert.Len(t, envelope.Signatures[0].KeyId, 64)
+				signature, err := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+				assert.NoError(t, err)
+				assert.True(t, test.verify(preAuthenticationEncoding(envelope.PayloadType, payload), signature))
+			}
+		})
+	}
+}
+
+func TestNewSignerErrors(t *testing.T) {
+	_, err := newSigner([]byte("not a key"))
+	assert.EqualError(t, err, "the signing key is not PEM encoded")
+	_, err = newSigner(pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte{1}}))
+	assert.EqualError(t, err, "unsupported signing key of type 'ENCRYPTED PRIVATE KEY'. Encrypted keys are not supported")
+	_, err = newSigner(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte{1}}))
+	assert.Error(t, err)
+}
+
+func TestPreAuthenticationEncoding(t *testing.T) {
+	assert.Equal(t, "DSSEv1 29 http://example.com/HelloWorld 11 hello world", string(preAuthenticationEncoding("http://example.com/HelloWorld", []byte("hello world"))))
+}
@@ -0,0 +1,125 @@
+package attestation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// A DSSE envelope, as defined by https://github.com/secure-systems-lab/dsse/blob/master/envelope.md.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+type Signature struct {
+	KeyId string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// Signs envelopes with a private key.
+type Signer struct {
+	key crypto.Signer
+	// The hex encoded sha256 of the DER encoded public key.
+	keyId string
+}
+
+// Reads an unencrypted PEM encoded private key from a file. Ed25519, ECDSA and RSA keys are supported.
+func LoadSigner(keyPath string) (*Signer, error) {
+	content, err := ioutil.ReadFile(keyPath)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	return newSigner(content)
+}
+
+func newSigner(pemContent []byte) (*Signer, error) {
+	block, _ := pem.Decode(pemContent)
+	if block == nil {
+		return nil, errorutils.CheckError(errors.New("the signing key is not PEM encoded"))
+	}
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, errorutils.CheckError(errors.New("unsupported signing key of type '" + block.Type + "'. Encrypted keys are not supported"))
+	}
+	if err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("failed to parse the signing key: %s", err.Error()))
+	}
+	signer := &Signer{}
+	switch typedKey := key.(type) {
+	case ed25519.PrivateKey:
+		signer.key = typedKey
+	case *ecdsa.PrivateKey:
+		signer.key = typedKey
+	case *rsa.PrivateKey:
+		signer.key = typedKey
+	default:
+		return nil, errorutils.CheckError(errors.New("unsupported signing key. Ed25519, ECDSA and RSA keys are supported"))
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(signer.key.Public())
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	keyId := sha256.Sum256(publicKey)
+	signer.keyId = hex.EncodeToString(keyId[:])
+	return signer, nil
+}
+
+// Signs the statement, and returns its envelope as a single JSON line.
+func (signer *Signer) Sign(statement *Statement) ([]byte, error) {
+	payload, err := statement.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signer.sign(preAuthenticationEncoding(payloadType, payload))
+	if err != nil {
+		return nil, err
+	}
+	envelope := &Envelope{
+		PayloadType: payloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []Signature{{KeyId: signer.keyId, Sig: base64.StdEncoding.EncodeToString(signature)}},
+	}
+	content, err := json.Marshal(envelope)
+	return content, errorutils.CheckError(err)
+}
+
+// Ed25519 keys sign the message itself, while ECDSA and RSA keys sign its sha256 digest.
+func (signer *Signer) sign(message []byte) ([]byte, error) {
+	var signature []byte
+	var err error
+	if _, isEd25519 := signer.key.(ed25519.PrivateKey); isEd25519 {
+		signature, err = signer.key.Sign(rand.Reader, message, crypto.Hash(0))
+	} else {
+		digest := sha256.Sum256(message)
+		signature, err = signer.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	return signature, errorutils.CheckError(err)
+}
+
+// Returns the bytes DSSE signs, which bind the payload to its type.
+func preAuthenticationEncoding(contentType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(contentType), contentType, len(payload), payload))
+}
@@ -0,0 +1,269 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli-core/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	auditLogFileName = "audit.jsonl"
+	// Set to 'false' to stop recording the mutating commands in the audit log.
+	AuditLogEnv = "JFROG_CLI_AUDIT_LOG"
+)
+
+// The commands recorded in the audit log.
+var auditedCommands = []string{
+	"rt move",
+	"rt copy",
+	"rt delete",
+	"rt restore",
+	"rt trash purge",
+	"rt sync",
+	"rt set-props",
+	"rt delete-props",
+	"rt build-discard",
+	"rt git-lfs-clean",
+	"rt cleanup",
+	"rt release-bundle-delete",
+	"rt repo-delete",
+	"rt replication-delete",
+	"rt permission-target-delete",
+	"rt users-delete",
+	"rt group-delete",
+	"bt package-delete",
+	"bt version-delete",
+}
+
+// The outcome of a recorded command.
+type Status string
+
+const (
+	Success Status = "success"
+	Failure Status = "failure"
+	// The command succeeded without affecting any file, for example because its confirmation prompt was declined.
+	NoOp Status = "no-op"
+)
+
+// A single line of the audit log.
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	// The ID of the server the command ran against, if it is a configured server.
+	ServerId string            `json:"serverId,omitempty"`
+	Command  string            `json:"command"`
+	Args     []string          `json:"args"`
+	Flags    map[string]string `json:"flags,omitempty"`
+	// The paths, repositories or entities the command was asked to modify.
+	Targets []string `json:"targets,omitempty"`
+	Status  Status   `json:"status"`
+	Error   string   `json:"error,omitempty"`
+	// The totals of the command's summary report, if it has one.
+	Totals *summary.Totals `json:"totals,omitempty"`
+}
+
+func isEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(AuditLogEnv))
+	return err != nil || enabled
+}
+
+func GetAuditLogPath() (string, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, auditLogFileName), nil
+}
+
+// Wraps the actions of the audited commands, to record them in the audit log once they are done.
+func AddAuditing(commands []cli.Command) []cli.Command {
+	if !isEnabled() {
+		return commands
+	}
+	for _, command := range auditedCommands {
+		cmd := cliutils.FindCommand(commands, strings.Fields(command))
+		if cmd == nil || cmd.Action == nil {
+			continue
+		}
+		cmd.Action = getAuditedAction(command, cmd.Action)
+	}
+	return commands
+}
+
+func getAuditedAction(command string, action interface{}) func(*cli.Context) error {
+	return func(c *cli.Context) error {
+		err := cli.HandleAction(action, c)
+		if c.Bool("dry-run") {
+			return err
+		}
+		entry := &Entry{
+			Timestamp: time.Now(),
+			ServerId:  getServerId(c, command),
+			Command:   command,
+			Args:      cliutils.ExtractCommand(c),
+			Flags:     cliutils.GetSetFlags(c),
+			Targets:   getTargets(c),
+			Totals:    getTotals(cliutils.GetLastSummaryReport()),
+		}
+		entry.Status = getStatus(err, entry.Totals)
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		// Failing to record the command should not change its result.
+		if auditErr := Append(entry); auditErr != nil {
+			log.Warn("Failed writing to the audit log: " + auditErr.Error())
+		}
+		return err
+	}
+}
+
+// Returns the ID of the configured server the command used.
+// Bintray commands do not use the configured servers, so they have no server ID.
+func getServerId(c *cli.Context, command string) string {
+	if c.String("server-id") != "" {
+		return c.String("server-id")
+	}
+	if !strings.HasPrefix(command, cliutils.CmdArtifactory+" ") || c.String("url") != "" {
+		return ""
+	}
+	serverDetails, err := config.GetDefaultServerConf()
+	if err != nil || serverDetails == nil {
+		return ""
+	}
+	return serverDetails.ServerId
+}
+
+// Returns the patterns and targets of the file spec if one was used, or the command's arguments otherwise.
+func getTargets(c *cli.Context) []string {
+	if c.String("spec") == "" {
+		return cliutils.ExtractCommand(c)
+	}
+	specFiles, err := spec.CreateSpecFromFile(c.String("spec"), coreutils.SpecVarsStringToMap(c.String("spec-vars")))
+	if err != nil {
+		log.Debug("Couldn't read the file spec for the audit log: " + err.Error())
+		return nil
+	}
+	var targets []string
+	for _, file := range specFiles.Files {
+		for _, target := range []string{file.Pattern, file.Target} {
+			if target != "" {
+				targets = append(targets, target)
+			}
+		}
+	}
+	return targets
+}
+
+// Returns the status of the command by its error and the totals of its summary report.
+// Commands without a summary report are recorded as succeeded if they returned no error.
+func getStatus(err error, totals *summary.Totals) Status {
+	switch {
+	case err != nil || (totals != nil && totals.Failure > 0):
+		return Failure
+	case totals != nil && totals.Success == 0:
+		return NoOp
+	}
+	return Success
+}
+
+func getTotals(summaryReport interface{}) *summary.Totals {
+	switch summaryReport := summaryReport.(type) {
+	case *summary.Summary:
+		return summaryReport.Totals
+	case *summary.BuildInfoSummary:
+		return summaryReport.Totals
+	}
+	return nil
+}
+
+// Appends the entry to the audit log.
+// Each entry is written with a single write to a file opened in append mode, so concurrent CLI processes do not mix their entries.
+func Append(entry *Entry) error {
+	logPath, err := GetAuditLogPath()
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(entry)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(logPath)); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(append(content, '\n'))
+	return errorutils.CheckError(err)
+}
+
+// Returns the audit log entries recorded since the provided time, from the oldest to the newest.
+// Lines which cannot be parsed are skipped.
+func Read(since time.Time) ([]*Entry, error) {
+	entries := []*Entry{}
+	logPath, err := GetAuditLogPath()
+	if err != nil {
+		return nil, err
+	}
+	exists, err := fileutils.IsFileExists(logPath, false)
+	if err != nil || !exists {
+		return entries, err
+	}
+	file, err := os.Open(logPath)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		entry := new(Entry)
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			log.Debug("Skipping line " + strconv.Itoa(lineNumber) + " of the audit log: " + err.Error())
+			continue
+		}
+		if entry.Timestamp.Before(since) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, errorutils.CheckError(scanner.Err())
+}
+
+// Parses the value of the --since option.
+// Accepts a duration before the current time, such as '36h', '7d' or '2w', a date such as '2021-01-31', or an RFC 3339 timestamp.
+func ParseSince(since string, now time.Time) (time.Time, error) {
+	if since == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if parsed, err := time.ParseInLocation(layout, since, time.Local); err == nil {
+			return parsed, nil
+		}
+	}
+	for unit, duration := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if count, err := strconv.Atoi(strings.TrimSuffix(since, unit)); strings.HasSuffix(since, unit) && err == nil && count >= 0 {
+			return now.Add(-time.Duration(count) * duration), nil
+		}
+	}
+	if duration, err := time.ParseDuration(since); err == nil && duration >= 0 {
+		return now.Add(-duration), nil
+	}
+	return time.Time{}, errorutils.CheckError(errors.New("invalid --since value '" + since + "'. Expecting a duration, such as 36h or 7d, a date, such as 2021-01-31, or an RFC 3339 timestamp"))
+}
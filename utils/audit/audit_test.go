@@ -0,0 +1,108 @@
+package audit
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2021, 3, 10, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		since    string
+		expected time.Time
+	}{
+		{"", time.Time{}},
+		{"36h", now.Add(-36 * time.Hour)},
+		{"7d", now.AddDate(0, 0, -7)},
+		{"2w", now.AddDate(0, 0, -14)},
+		{"2021-01-31", time.Date(2021, 1, 31, 0, 0, 0, 0, time.Local)},
+		{"2021-01-31T10:00:00Z", time.Date(2021, 1, 31, 10, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		t.Run(test.since, func(t *testing.T) {
+			since, err := ParseSince(test.since, now)
+			assert.NoError(t, err)
+			assert.True(t, test.expected.Equal(since), "expected %s, got %s", test.expected, since)
+		})
+	}
+	_, err := ParseSince("yesterday", now)
+	assert.Error(t, err)
+}
+
+func TestGetStatus(t *testing.T) {
+	assert.Equal(t, Success, getStatus(nil, nil))
+	assert.Equal(t, Success, getStatus(nil, &summary.Totals{Success: 2}))
+	assert.Equal(t, NoOp, getStatus(nil, &summary.Totals{}))
+	assert.Equal(t, Failure, getStatus(nil, &summary.Totals{Success: 1, Failure: 1}))
+	assert.Equal(t, Failure, getStatus(errors.New("failed"), nil))
+}
+
+func TestAuditedCommands(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	oldHome := os.Getenv(coreutils.HomeDir)
+	assert.NoError(t, os.Setenv(coreutils.HomeDir, tmpDir))
+	defer os.Setenv(coreutils.HomeDir, oldHome)
+
+	commands := []cli.Command{{
+		Name: "rt",
+		Subcommands: []cli.Command{{
+			Name:    "delete",
+			Aliases: []string{"del"},
+			Flags:   []cli.Flag{cli.StringFlag{Name: "server-id"}, cli.StringFlag{Name: "password"}, cli.BoolFlag{Name: "dry-run"}},
+			Action: func(c *cli.Context) error {
+				switch c.Args().First() {
+				case "fail/*":
+					return errors.New("delete failed")
+				case "declined/*":
+					// A declined confirmation prompt ends the command with an empty summary.
+					return cliutils.PrintSummaryReport(0, 0, nil)
+				}
+				return nil
+			},
+		}, {
+			Name:   "search",
+			Action: func(c *cli.Context) error { return nil },
+		}},
+	}}
+	app := cli.NewApp()
+	app.Commands = AddAuditing(commands)
+	assert.NoError(t, app.Run([]string{"jfrog", "rt", "del", "--server-id=my-server", "--password=secret", "repo/*"}))
+	assert.Error(t, app.Run([]string{"jfrog", "rt", "delete", "--server-id=my-server", "fail/*"}))
+	assert.NoError(t, app.Run([]string{"jfrog", "rt", "delete", "declined/*"}))
+	// Dry runs and commands which do not modify data should not be recorded.
+	assert.NoError(t, app.Run([]string{"jfrog", "rt", "delete", "--dry-run", "repo/*"}))
+	assert.NoError(t, app.Run([]string{"jfrog", "rt", "search", "repo/*"}))
+
+	entries, err := Read(time.Time{})
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 3) {
+		assert.Equal(t, "rt delete", entries[0].Command)
+		assert.Equal(t, "my-server", entries[0].ServerId)
+		assert.Equal(t, []string{"repo/*"}, entries[0].Targets)
+		assert.Equal(t, "***", entries[0].Flags["password"])
+		assert.Equal(t, Success, entries[0].Status)
+		assert.Equal(t, Failure, entries[1].Status)
+		assert.Equal(t, "delete failed", entries[1].Error)
+		assert.Equal(t, NoOp, entries[2].Status)
+		assert.Equal(t, &summary.Totals{}, entries[2].Totals)
+	}
+
+	// Entries recorded before the requested time should be filtered out.
+	entries, err = Read(time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -13,6 +13,8 @@ const (
 	CmdCompletion     = "completion"
 	CmdPlugin         = "plugin"
 	CmdConfig         = "config"
+	CmdAudit          = "audit"
+	CmdCache          = "cache"
 
 	// Download
 	DownloadMinSplitKb    = 5120
@@ -30,4 +32,5 @@ const (
 	BuildUrl    = "JFROG_CLI_BUILD_URL"
 	EnvExclude  = "JFROG_CLI_ENV_EXCLUDE"
 	UserAgent   = "JFROG_CLI_USER_AGENT"
+	TrashPath   = "JFROG_CLI_TRASH_PATH"
 )
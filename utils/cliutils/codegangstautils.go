@@ -36,3 +36,39 @@ func GetSortedCommands(commands cli.CommandsByName) cli.CommandsByName {
 	sort.Sort(commands)
 	return commands
 }
+
+// The values of these flags are masked when the command's flags are recorded.
+// The 'key' flag is the Bintray API key.
+var secretFlags = map[string]bool{password: true, apikey: true, accessToken: true, sshPassPhrase: true, passphrase: true, mcAccessToken: true,
+	configPassword: true, configApiKey: true, configAccessToken: true, "key": true}
+
+// Returns the values of the flags which were set for the command, with the values of secret flags masked.
+func GetSetFlags(c *cli.Context) map[string]string {
+	flags := map[string]string{}
+	for _, name := range c.FlagNames() {
+		if !c.IsSet(name) {
+			continue
+		}
+		if secretFlags[name] {
+			flags[name] = "***"
+			continue
+		}
+		flags[name] = c.String(name)
+	}
+	return flags
+}
+
+// Returns the command matching the provided names (such as ["rt", "upload"]), or nil if it does not exist.
+// Command aliases are matched as well.
+func FindCommand(commands []cli.Command, names []string) *cli.Command {
+	for i := range commands {
+		if !commands[i].HasName(names[0]) {
+			continue
+		}
+		if len(names) == 1 {
+			return &commands[i]
+		}
+		return FindCommand(commands[i].Subcommands, names[1:])
+	}
+	return nil
+}
@@ -17,18 +17,29 @@ const (
 	Move                    = "move"
 	Copy                    = "copy"
 	Delete                  = "delete"
+	Restore                 = "restore"
+	TrashPurge              = "trash-purge"
 	Properties              = "properties"
 	Search                  = "search"
+	Sync                    = "sync"
+	Diff                    = "diff"
 	BuildPublish            = "build-publish"
 	BuildAppend             = "build-append"
 	BuildScan               = "build-scan"
 	BuildPromote            = "build-promote"
 	BuildDistribute         = "build-distribute"
 	BuildDiscard            = "build-discard"
+	BuildDiff               = "build-diff"
+	BuildSbom               = "build-sbom"
+	BuildShow               = "build-show"
+	BuildEdit               = "build-edit"
+	BuildExport             = "build-export"
+	BuildImport             = "build-import"
 	BuildAddDependencies    = "build-add-dependencies"
 	BuildAddGit             = "build-add-git"
 	BuildCollectEnv         = "build-collect-env"
 	GitLfsClean             = "git-lfs-clean"
+	Cleanup                 = "cleanup"
 	Mvn                     = "mvn"
 	MvnConfig               = "mvn-config"
 	Gradle                  = "gradle"
@@ -86,6 +97,20 @@ const (
 	AddConfig  = "config-add"
 	EditConfig = "config-edit"
 
+	// Plugin commands keys
+	PluginInstall = "plugin-install"
+	PluginUpdate  = "plugin-update"
+	PluginPublish = "plugin-publish"
+
+	// Audit commands keys
+	AuditShow = "audit-show"
+
+	// Cache commands keys
+	CachePrune = "cache-prune"
+
+	// Global flags key
+	Global = "global"
+
 	// *** Artifactory Commands' flags ***
 	// Base flags
 	url         = "url"
@@ -152,9 +177,13 @@ const (
 	bundle           = "bundle"
 	archiveEntries   = "archive-entries"
 	detailedSummary  = "detailed-summary"
+	reportFile       = "report-file"
+	reportFormat     = "report-format"
 	archive          = "archive"
 	syncDeletesQuiet = syncDeletes + "-" + quiet
 	antFlag          = "ant"
+	resume           = "resume"
+	atomic           = "atomic"
 	fromRt           = "from-rt"
 	transitive       = "transitive"
 
@@ -179,6 +208,7 @@ const (
 	deb                   = "deb"
 	symlinks              = "symlinks"
 	uploadAnt             = uploadPrefix + antFlag
+	uploadResume          = uploadPrefix + resume
 
 	// Unique download flags
 	downloadPrefix       = "download-"
@@ -188,6 +218,7 @@ const (
 	downloadProps        = downloadPrefix + props
 	downloadExcludeProps = downloadPrefix + excludeProps
 	downloadSyncDeletes  = downloadPrefix + syncDeletes
+	downloadResume       = downloadPrefix + resume
 	minSplit             = "min-split"
 	splitCount           = "split-count"
 	validateSymlinks     = "validate-symlinks"
@@ -198,6 +229,7 @@ const (
 	moveFlat         = movePrefix + flat
 	moveProps        = movePrefix + props
 	moveExcludeProps = movePrefix + excludeProps
+	moveAtomic       = movePrefix + atomic
 
 	// Unique copy flags
 	copyPrefix       = "copy-"
@@ -205,6 +237,7 @@ const (
 	copyFlat         = copyPrefix + flat
 	copyProps        = copyPrefix + props
 	copyExcludeProps = copyPrefix + excludeProps
+	copyAtomic       = copyPrefix + atomic
 
 	// Unique delete flags
 	deletePrefix       = "delete-"
@@ -212,6 +245,24 @@ const (
 	deleteProps        = deletePrefix + props
 	deleteExcludeProps = deletePrefix + excludeProps
 	deleteQuiet        = deletePrefix + quiet
+	deleteSoft         = deletePrefix + "soft"
+
+	// Unique trash flags
+	trashPath       = "trash-path"
+	trashPurgeQuiet = "trash-purge-" + quiet
+	olderThan       = "older-than"
+
+	// Unique sync flags
+	syncPrefix         = "sync-"
+	syncDryRun         = syncPrefix + dryRun
+	syncQuiet          = syncPrefix + quiet
+	syncConflictPolicy = "conflict-policy"
+
+	// Unique diff flags
+	diffPrefix       = "diff-"
+	diffRecursive    = diffPrefix + recursive
+	diffProps        = diffPrefix + props
+	diffExcludeProps = diffPrefix + excludeProps
 
 	// Unique search flags
 	searchPrefix       = "search-"
@@ -220,6 +271,10 @@ const (
 	searchExcludeProps = searchPrefix + excludeProps
 	count              = "count"
 	searchTransitive   = searchPrefix + transitive
+	searchFormat       = searchPrefix + format
+	searchFields       = searchPrefix + "fields"
+	searchGroupBy      = searchPrefix + "group-by"
+	searchAggregate    = searchPrefix + "aggregate"
 
 	// Unique properties flags
 	propertiesPrefix  = "props-"
@@ -231,11 +286,34 @@ const (
 	buildPublishPrefix = "bp-"
 	bpDryRun           = buildPublishPrefix + dryRun
 	bpDetailedSummary  = buildPublishPrefix + detailedSummary
+	bpSbom             = buildPublishPrefix + sbom
+	bpSbomFile         = buildPublishPrefix + sbomFile
+	attest             = "attest"
+	attestKey          = "attest-key"
+	attestRepo         = "attest-repo"
+	sbom               = "sbom"
+	sbomFile           = "sbom-file"
 	envInclude         = "env-include"
 	envExclude         = "env-exclude"
 	buildUrl           = "build-url"
 	project            = "project"
 
+	// Unique build-sbom flags
+	buildSbomPrefix = "bs-"
+	bsSbom          = buildSbomPrefix + sbom
+	bsSbomFile      = buildSbomPrefix + sbomFile
+
+	// Unique build-edit flags
+	buildEditPrefix = "be-"
+	beRemoveModule  = buildEditPrefix + "remove-module"
+	beProps         = buildEditPrefix + props
+
+	// Unique build-export and build-import flags
+	buildExportPrefix = "bex-"
+	bexFile           = buildExportPrefix + "file"
+	buildImportPrefix = "bim-"
+	bimFile           = buildImportPrefix + "file"
+
 	// Unique build-add-dependencies flags
 	badPrefix    = "bad-"
 	badDryRun    = badPrefix + dryRun
@@ -287,6 +365,12 @@ const (
 	glcRepo   = glcPrefix + repo
 	refs      = "refs"
 
+	// Unique cleanup flags
+	cleanupPrefix = "cleanup-"
+	cleanupDryRun = cleanupPrefix + dryRun
+	cleanupQuiet  = cleanupPrefix + quiet
+	policy        = "policy"
+
 	// Build tool config flags
 	global          = "global"
 	serverIdResolve = "server-id-resolve"
@@ -414,6 +498,31 @@ const (
 	configPassword    = configPrefix + password
 	configApiKey      = configPrefix + apikey
 	configInsecureTls = configPrefix + insecureTls
+
+	// *** Plugin Commands' flags ***
+	// Unique plugin-install flags
+	fromManifest = "from-manifest"
+	updateLock   = "update-lock"
+
+	// Unique plugin-update flags
+	all = "all"
+
+	// Shared plugin-install and plugin-update flags
+	allowServerChecksum = "allow-server-checksum"
+
+	// Unique plugin-publish flags
+	prebuiltDir = "prebuilt-dir"
+
+	// *** Audit Commands' flags ***
+	// Unique audit-show flags
+	since = "since"
+
+	// *** Cache Commands' flags ***
+	// Unique cache-prune flags
+	maxSize = "max-size"
+
+	// *** Global flags ***
+	format = "format"
 )
 
 var flagsMap = map[string]cli.Flag{
@@ -591,6 +700,14 @@ var flagsMap = map[string]cli.Flag{
 		Name:  detailedSummary,
 		Usage: "[Default: false] Set to true to include a list of the affected files in the command summary.` `",
 	},
+	reportFile: cli.StringFlag{
+		Name:  reportFile,
+		Usage: "[Optional] Path to a file, to which a report of the command's results is written. The report can be rendered by CI servers, such as Jenkins and GitHub.` `",
+	},
+	reportFormat: cli.StringFlag{
+		Name:  reportFormat,
+		Usage: "[Default: junit] Format of the report written to the file provided by the --report-file option. Can be junit or sarif.` `",
+	},
 	interactive: cli.BoolTFlag{
 		Name:  interactive,
 		Usage: "[Default: true, unless $CI is true] Set to false if you do not want the config command to be interactive. If true, the --url option becomes optional.` `",
@@ -658,6 +775,10 @@ var flagsMap = map[string]cli.Flag{
 		Name:  archive,
 		Usage: "[Optional] Set to \"zip\" to deploy the files to Artifactory in a ZIP archive.` `",
 	},
+	uploadResume: cli.BoolFlag{
+		Name:  resume,
+		Usage: "[Default: false] Set to true to record the uploaded files in a journal, and skip the files which were already uploaded when the command is run again with the same spec after being interrupted. Files of 200MB or larger are uploaded in parts if Artifactory supports multipart uploads, so their interrupted uploads continue from the missing parts. Other files whose upload was interrupted are uploaded again from their beginning. Cannot be used with --sync-deletes, --archive or --include-dirs.` `",
+	},
 	syncDeletesQuiet: cli.BoolFlag{
 		Name:  quiet,
 		Usage: "[Default: $CI] Set to true to skip the sync-deletes confirmation message.` `",
@@ -700,6 +821,10 @@ var flagsMap = map[string]cli.Flag{
 		Name:  syncDeletes,
 		Usage: "[Optional] Specific path in the local file system, under which to sync dependencies after the download. After the download, this path will include only the dependencies downloaded during this download operation. The other files under this path will be deleted.` `",
 	},
+	downloadResume: cli.BoolFlag{
+		Name:  resume,
+		Usage: "[Default: false] Set to true to keep the downloaded parts of files which are split into ranges, if the download fails. Running the download again with this option downloads only the missing ranges. The downloaded files are verified against their sha256 checksum.` `",
+	},
 	moveRecursive: cli.BoolTFlag{
 		Name:  recursive,
 		Usage: "[Default: true] Set to false if you do not wish to move artifacts inside sub-folders in Artifactory.` `",
@@ -716,6 +841,10 @@ var flagsMap = map[string]cli.Flag{
 		Name:  excludeProps,
 		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\". Only artifacts without the specified properties will be moved.` `",
 	},
+	moveAtomic: cli.BoolFlag{
+		Name:  atomic,
+		Usage: "[Default: false] Set to true to move the files one by one, and move the moved files back if a move fails. Files which already exist in the target are not overwritten in this mode.` `",
+	},
 	copyRecursive: cli.BoolTFlag{
 		Name:  recursive,
 		Usage: "[Default: true] Set to false if you do not wish to copy artifacts inside sub-folders in Artifactory.` `",
@@ -732,6 +861,10 @@ var flagsMap = map[string]cli.Flag{
 		Name:  excludeProps,
 		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\". Only artifacts without the specified properties will be copied.` `",
 	},
+	copyAtomic: cli.BoolFlag{
+		Name:  atomic,
+		Usage: "[Default: false] Set to true to copy the files one by one, and delete the copies if a copy fails. Files which already exist in the target are not overwritten in this mode.` `",
+	},
 	deleteRecursive: cli.BoolTFlag{
 		Name:  recursive,
 		Usage: "[Default: true] Set to false if you do not wish to delete artifacts inside sub-folders in Artifactory.` `",
@@ -748,6 +881,46 @@ var flagsMap = map[string]cli.Flag{
 		Name:  quiet,
 		Usage: "[Default: $CI] Set to true to skip the delete confirmation message.` `",
 	},
+	deleteSoft: cli.BoolFlag{
+		Name:  "soft",
+		Usage: "[Default: false] Set to true to move the files to the trash path instead of deleting them. The files can be restored using the 'jfrog rt restore' command. Cannot be used together with --report-file.` `",
+	},
+	trashPath: cli.StringFlag{
+		Name:  trashPath,
+		Usage: "[Optional] The trash path in Artifactory, in the form of <repository name>/<repository path>. If not set, the JFROG_CLI_TRASH_PATH environment variable is used.` `",
+	},
+	trashPurgeQuiet: cli.BoolFlag{
+		Name:  quiet,
+		Usage: "[Default: $CI] Set to true to skip the purge confirmation message.` `",
+	},
+	olderThan: cli.StringFlag{
+		Name:  olderThan,
+		Usage: "[Mandatory] Delete only the files deleted before this time. Can be a duration before the current time, such as 36h or 30d, a date, such as 2021-01-31, or an RFC 3339 timestamp.` `",
+	},
+	syncDryRun: cli.BoolFlag{
+		Name:  dryRun,
+		Usage: "[Default: false] Set to true to print the sync plan as JSON, without changing any files.` `",
+	},
+	syncQuiet: cli.BoolFlag{
+		Name:  quiet,
+		Usage: "[Default: $CI] Set to true to skip the confirmation message before deleting files.` `",
+	},
+	syncConflictPolicy: cli.StringFlag{
+		Name:  syncConflictPolicy,
+		Usage: "[Default: fail] The side whose version of a file is kept, if the file changed on both sides since the last sync. Can be one of: newest-wins, local-wins, remote-wins or fail.` `",
+	},
+	diffRecursive: cli.BoolTFlag{
+		Name:  recursive,
+		Usage: "[Default: true] Set to false if you do not wish to compare artifacts inside sub-folders.` `",
+	},
+	diffProps: cli.StringFlag{
+		Name:  props,
+		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\". Only artifacts with these properties are compared.` `",
+	},
+	diffExcludeProps: cli.StringFlag{
+		Name:  excludeProps,
+		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\". Only artifacts without the specified properties are compared.` `",
+	},
 	searchRecursive: cli.BoolTFlag{
 		Name:  recursive,
 		Usage: "[Default: true] Set to false if you do not wish to search artifacts inside sub-folders in Artifactory.` `",
@@ -768,6 +941,22 @@ var flagsMap = map[string]cli.Flag{
 		Name:  transitive,
 		Usage: "[Default: false] Set to true to look for artifacts also in remote repositories. Available on Artifactory version 7.17.0 or higher.` `",
 	},
+	searchFormat: cli.StringFlag{
+		Name:  "search-format",
+		Usage: "[Default: json] The format of the search results. Can be json, csv or ndjson. The results are written as they are read, so that large results are not held in memory.` `",
+	},
+	searchFields: cli.StringFlag{
+		Name:  "fields",
+		Usage: "[Optional] Comma-separated list of the fields to include in the search results, in the order they are written. The supported fields are path, type, size, created, modified, sha1, sha256, md5 and props.<key>.` `",
+	},
+	searchGroupBy: cli.StringFlag{
+		Name:  "group-by",
+		Usage: "[Optional] Summarize the found files by groups instead of listing them. Can be repo, dir-depth=<N> to group by the first N directories of the repository, or prop:<key> to group by the values of a property.` `",
+	},
+	searchAggregate: cli.StringFlag{
+		Name:  "aggregate",
+		Usage: "[Default: size,count] Comma-separated list of the aggregates of each group, when the group-by option is used. Can be size and count.` `",
+	},
 	propsRecursive: cli.BoolTFlag{
 		Name:  recursive,
 		Usage: "[Default: true] When false, artifacts inside sub-folders in Artifactory will not be affected.` `",
@@ -796,6 +985,50 @@ var flagsMap = map[string]cli.Flag{
 		Name:  detailedSummary,
 		Usage: "[Default: false] Set to true to get a command summary with details about the build info artifact.` `",
 	},
+	bpSbom: cli.StringFlag{
+		Name:  sbom,
+		Usage: "[Optional] Set to cyclonedx or spdx to generate an SBOM of the published build-info in this format.` `",
+	},
+	bpSbomFile: cli.StringFlag{
+		Name:  sbomFile,
+		Usage: "[Default: <build name>-<build number>.cdx.json or .spdx.json] Path of the generated SBOM file, when the --sbom option is used.` `",
+	},
+	attest: cli.BoolFlag{
+		Name:  attest,
+		Usage: "[Default: false] Set to true to upload a signed in-toto provenance of the published build, with the build artifacts as its subjects. Requires the build URL.` `",
+	},
+	attestKey: cli.StringFlag{
+		Name:  attestKey,
+		Usage: "[Mandatory when --attest is set] Path of a PEM encoded private key, to sign the provenance with. Ed25519, ECDSA and RSA keys are supported.` `",
+	},
+	attestRepo: cli.StringFlag{
+		Name:  attestRepo,
+		Usage: "[Mandatory when --attest is set] Repository to upload the provenance to, under <build name>/<build number>/.` `",
+	},
+	bsSbom: cli.StringFlag{
+		Name:  sbom,
+		Usage: "[Default: cyclonedx] The SBOM format. Can be cyclonedx or spdx.` `",
+	},
+	bsSbomFile: cli.StringFlag{
+		Name:  sbomFile,
+		Usage: "[Optional] Path of a file to write the SBOM to. If not set, the SBOM is printed.` `",
+	},
+	beRemoveModule: cli.StringFlag{
+		Name:  "remove-module",
+		Usage: "[Optional] ID of a module to remove from the build-info, with its artifacts and dependencies.` `",
+	},
+	beProps: cli.StringFlag{
+		Name:  props,
+		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2\". The properties override the collected environment variables of the same names.` `",
+	},
+	bexFile: cli.StringFlag{
+		Name:  "file",
+		Usage: "[Mandatory] Path of the file to export the collected build-info to.` `",
+	},
+	bimFile: cli.StringFlag{
+		Name:  "file",
+		Usage: "[Mandatory] List of build export files in the form of \"path1;path2;...\". The paths may contain wildcards.` `",
+	},
 	envInclude: cli.StringFlag{
 		Name:  envInclude,
 		Usage: "[Default: *] List of patterns in the form of \"value1;value2;...\" Only environment variables match those patterns will be included.` `",
@@ -932,6 +1165,18 @@ var flagsMap = map[string]cli.Flag{
 		Name:  quiet,
 		Usage: "[Default: $CI] Set to true to skip the delete confirmation message.` `",
 	},
+	cleanupDryRun: cli.BoolFlag{
+		Name:  dryRun,
+		Usage: "[Default: false] Set to true to report the files the policy deletes and the reclaimed bytes, without deleting them.` `",
+	},
+	cleanupQuiet: cli.BoolFlag{
+		Name:  quiet,
+		Usage: "[Default: $CI] Set to true to skip the delete confirmation message.` `",
+	},
+	policy: cli.StringFlag{
+		Name:  policy,
+		Usage: "[Mandatory] Path to a YAML cleanup policy file, with the rules which select the files to delete.` `",
+	},
 	global: cli.BoolFlag{
 		Name:  global,
 		Usage: "[Default: false] Set to true if you'd like the configuration to be global (for all projects). Specific projects can override the global configuration.` `",
@@ -1253,6 +1498,42 @@ var flagsMap = map[string]cli.Flag{
 		Name:  insecureTls,
 		Usage: "[Default: false] Set to true to skip TLS certificates verification, while encrypting the Artifactory password during the config process.` `",
 	},
+	// Plugin's commands Flags
+	fromManifest: cli.BoolFlag{
+		Name:  fromManifest,
+		Usage: "[Default: false] Set to true to install the plugins declared in the .jfrog/plugins.yaml manifest of the current directory. The resolved checksums are locked in .jfrog/plugins.lock.yaml.` `",
+	},
+	updateLock: cli.BoolFlag{
+		Name:  updateLock,
+		Usage: "[Default: false] Can be used with the --from-manifest option. Set to true to ignore the checksums locked in .jfrog/plugins.lock.yaml, resolve the plugins declared in the manifest again and lock the newly resolved checksums. Plugins declared without a version are updated to their latest version.` `",
+	},
+	all: cli.BoolFlag{
+		Name:  all,
+		Usage: "[Default: false] Set to true to update all installed plugins.` `",
+	},
+	allowServerChecksum: cli.BoolFlag{
+		Name:  allowServerChecksum,
+		Usage: "[Default: false] Set to true to install plugins published without a checksum file, by verifying them against the checksum reported by the plugins server. Since this checksum is reported by the same server which serves the plugin, this is not an independent verification.` `",
+	},
+	prebuiltDir: cli.StringFlag{
+		Name:  prebuiltDir,
+		Usage: "[Optional] Path to a directory containing the plugin's prebuilt executables, laid out as '<architecture>/<plugin name>'. If provided, the executables are taken from this directory instead of being built for each architecture.` `",
+	},
+	// Audit's commands Flags
+	since: cli.StringFlag{
+		Name:  since,
+		Usage: "[Optional] Show only the entries recorded since this time. Can be a duration before the current time, such as 36h or 7d, a date, such as 2021-01-31, or an RFC 3339 timestamp.` `",
+	},
+	// Cache's commands Flags
+	maxSize: cli.StringFlag{
+		Name:  maxSize,
+		Usage: "[Mandatory] The maximum size of the download cache. The least recently used files are removed until the cache fits this size. Can be a number of bytes, or a number followed by KB, MB, GB or TB, such as 10GB.` `",
+	},
+	// Global Flags
+	format: cli.StringFlag{
+		Name:  format,
+		Usage: "[Default: json] Output format of the commands results. Can be json, yaml or table. Should be placed before the command, for example: 'jfrog --format=yaml rt search ...'.` `",
+	},
 }
 
 var commandFlags = map[string][]string{
@@ -1277,7 +1558,7 @@ var commandFlags = map[string][]string{
 		clientCertKeyPath, spec, specVars, buildName, buildNumber, module, uploadExcludePatterns, uploadExclusions, deb,
 		uploadRecursive, uploadFlat, uploadRegexp, retries, dryRun, uploadExplode, symlinks, includeDirs,
 		uploadProps, failNoOp, threads, uploadSyncDeletes, syncDeletesQuiet, insecureTls, detailedSummary, project,
-		uploadAnt, uploadArchive,
+		uploadAnt, uploadArchive, reportFile, reportFormat, uploadResume,
 	},
 	Download: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
@@ -1285,30 +1566,47 @@ var commandFlags = map[string][]string{
 		sortOrder, limit, offset, downloadRecursive, downloadFlat, build, includeDeps, excludeArtifacts, minSplit, splitCount,
 		retries, dryRun, downloadExplode, validateSymlinks, bundle, includeDirs, downloadProps, downloadExcludeProps,
 		failNoOp, threads, archiveEntries, downloadSyncDeletes, syncDeletesQuiet, insecureTls, detailedSummary, project,
+		reportFile, reportFormat, downloadResume,
 	},
 	Move: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, spec, specVars, excludePatterns, exclusions, sortBy, sortOrder, limit, offset, moveRecursive,
 		moveFlat, dryRun, build, includeDeps, excludeArtifacts, moveProps, moveExcludeProps, failNoOp, threads, archiveEntries,
-		insecureTls, retries,
+		insecureTls, retries, moveAtomic,
 	},
 	Copy: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, spec, specVars, excludePatterns, exclusions, sortBy, sortOrder, limit, offset, copyRecursive,
 		copyFlat, dryRun, build, includeDeps, excludeArtifacts, bundle, copyProps, copyExcludeProps, failNoOp, threads,
-		archiveEntries, insecureTls, retries,
+		archiveEntries, insecureTls, retries, copyAtomic,
 	},
 	Delete: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, spec, specVars, excludePatterns, exclusions, sortBy, sortOrder, limit, offset,
 		deleteRecursive, dryRun, build, includeDeps, excludeArtifacts, deleteQuiet, deleteProps, deleteExcludeProps, failNoOp, threads, archiveEntries,
-		insecureTls, retries,
+		insecureTls, retries, reportFile, reportFormat, deleteSoft, trashPath,
+	},
+	Restore: {
+		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, trashPath, dryRun, failNoOp, insecureTls, retries,
+	},
+	TrashPurge: {
+		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, trashPath, olderThan, dryRun, trashPurgeQuiet, insecureTls, retries,
+	},
+	Diff: {
+		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, exclusions, diffRecursive, diffProps, diffExcludeProps, insecureTls, retries,
+	},
+	Sync: {
+		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, syncDryRun, syncQuiet, syncConflictPolicy, threads, insecureTls, retries,
 	},
 	Search: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, spec, specVars, excludePatterns, exclusions, sortBy, sortOrder, limit, offset,
 		searchRecursive, build, includeDeps, excludeArtifacts, count, bundle, includeDirs, searchProps, searchExcludeProps, failNoOp, archiveEntries,
-		insecureTls, searchTransitive, retries,
+		insecureTls, searchTransitive, searchFormat, searchFields, searchGroupBy, searchAggregate, retries,
 	},
 	Properties: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
@@ -1318,12 +1616,33 @@ var commandFlags = map[string][]string{
 	},
 	BuildPublish: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, buildUrl, bpDryRun,
-		envInclude, envExclude, insecureTls, project, bpDetailedSummary,
+		envInclude, envExclude, insecureTls, project, bpDetailedSummary, bpSbom, bpSbomFile,
+		attest, attestKey, attestRepo,
+	},
+	BuildSbom: {
+		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, project, bsSbom, bsSbomFile, insecureTls, retries,
+	},
+	BuildShow: {
+		envInclude, envExclude, buildUrl, project,
+	},
+	BuildEdit: {
+		beRemoveModule, beProps, project,
+	},
+	BuildExport: {
+		bexFile, project,
+	},
+	BuildImport: {
+		bimFile, project,
 	},
 	BuildAppend: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, buildUrl, bpDryRun,
 		envInclude, envExclude, insecureTls, project,
 	},
+	BuildDiff: {
+		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, project, insecureTls, retries,
+	},
 	BuildAddDependencies: {
 		spec, specVars, uploadExcludePatterns, uploadExclusions, badRecursive, badRegexp, badDryRun, project, badFromRt, serverId,
 	},
@@ -1339,7 +1658,7 @@ var commandFlags = map[string][]string{
 	},
 	BuildScan: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, fail, insecureTls,
-		project,
+		project, reportFile, reportFormat,
 	},
 	BuildPromote: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, status, comment,
@@ -1357,6 +1676,10 @@ var commandFlags = map[string][]string{
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, refs, glcRepo, glcDryRun,
 		glcQuiet, insecureTls, retries,
 	},
+	Cleanup: {
+		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, policy, cleanupDryRun, cleanupQuiet, threads, failNoOp, insecureTls, retries,
+	},
 	MvnConfig: {
 		global, serverIdResolve, serverIdDeploy, repoResolveReleases, repoResolveSnapshots, repoDeployReleases, repoDeploySnapshots,
 	},
@@ -1526,6 +1849,28 @@ var commandFlags = map[string][]string{
 	JpdDelete: {
 		mcUrl, mcAccessToken,
 	},
+	// Plugin's commands
+	PluginInstall: {
+		fromManifest, updateLock, allowServerChecksum,
+	},
+	PluginUpdate: {
+		all, allowServerChecksum,
+	},
+	PluginPublish: {
+		prebuiltDir,
+	},
+	// Audit's commands
+	AuditShow: {
+		since,
+	},
+	// Cache's commands
+	CachePrune: {
+		maxSize,
+	},
+	// Global flags
+	Global: {
+		format,
+	},
 }
 
 func GetCommandFlags(cmd string) []cli.Flag {
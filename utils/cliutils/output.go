@@ -0,0 +1,257 @@
+package cliutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+type OutputFormat string
+
+const (
+	Json  OutputFormat = "json"
+	Yaml  OutputFormat = "yaml"
+	Table OutputFormat = "table"
+)
+
+var outputFormats = []OutputFormat{Json, Yaml, Table}
+
+// The output format requested by the global --format option. Empty if the option was not used.
+var outputFormat OutputFormat
+
+// Holds the commands output when it should be rendered in a format other than JSON.
+var outputBuffer *bytes.Buffer
+
+// Sets the output format of the commands results.
+// Since the results are written as JSON, any other format requires collecting the output and rendering it once the command is done.
+func SetOutputFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+	for _, supported := range outputFormats {
+		if OutputFormat(strings.ToLower(format)) == supported {
+			outputFormat = supported
+			if outputFormat != Json {
+				outputBuffer = new(bytes.Buffer)
+				log.Logger.SetOutputWriter(outputBuffer)
+			}
+			return nil
+		}
+	}
+	return errorutils.CheckError(errors.New(fmt.Sprintf("unsupported output format '%s'. Supported formats are: json, yaml and table", format)))
+}
+
+// Returns the output format requested by the global --format option, or an empty string if the option was not used.
+func GetOutputFormat() OutputFormat {
+	return outputFormat
+}
+
+// Returns the writer the commands output should be written to.
+// Should be used when replacing the logger while a command is running.
+func GetOutputWriter() io.Writer {
+	if outputBuffer != nil {
+		return outputBuffer
+	}
+	return os.Stdout
+}
+
+// Writes the collected output to the standard output, rendered in the requested output format.
+func FlushOutput() error {
+	if outputBuffer == nil {
+		return nil
+	}
+	content := outputBuffer.Bytes()
+	outputBuffer = nil
+	return RenderOutput(content, outputFormat, os.Stdout)
+}
+
+// Renders the JSON content in the provided format.
+// Content which is not valid JSON, such as free text, is written as is.
+func RenderOutput(content []byte, format OutputFormat, writer io.Writer) error {
+	values, err := decodeOrderedJson(content)
+	if err != nil || format == Json {
+		_, err = writer.Write(content)
+		return errorutils.CheckError(err)
+	}
+	for _, value := range values {
+		switch format {
+		case Yaml:
+			err = writeYaml(value, writer)
+		case Table:
+			err = writeTable(value, writer)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Decodes all the JSON values in the content, keeping the order of the objects keys.
+// Objects are decoded to yaml.MapSlice, to allow rendering the keys in their original order.
+func decodeOrderedJson(content []byte) ([]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	var values []interface{}
+	for decoder.More() {
+		value, err := decodeOrderedValue(decoder)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	// Make sure there's no trailing content.
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected content after the JSON values")
+	}
+	return values, nil
+}
+
+func decodeOrderedValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token := token.(type) {
+	case json.Delim:
+		switch token {
+		case '{':
+			object := yaml.MapSlice{}
+			for decoder.More() {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := decodeOrderedValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				object = append(object, yaml.MapItem{Key: key, Value: value})
+			}
+			_, err = decoder.Token()
+			return object, err
+		case '[':
+			array := []interface{}{}
+			for decoder.More() {
+				value, err := decodeOrderedValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				array = append(array, value)
+			}
+			_, err = decoder.Token()
+			return array, err
+		}
+		return nil, errors.New("unexpected JSON delimiter: " + token.String())
+	case json.Number:
+		if intValue, err := token.Int64(); err == nil {
+			return intValue, nil
+		}
+		return token.Float64()
+	}
+	return token, nil
+}
+
+func writeYaml(value interface{}, writer io.Writer) error {
+	content, err := yaml.Marshal(value)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	_, err = writer.Write(content)
+	return errorutils.CheckError(err)
+}
+
+// Writes the value as an aligned table.
+// An array of objects is written with a column for each key, and a single object is written as key-value rows.
+// Nested objects are flattened, using their path as the column name (for example 'totals.success').
+func writeTable(value interface{}, writer io.Writer) error {
+	tableWriter := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	switch value := value.(type) {
+	case yaml.MapSlice:
+		fmt.Fprintln(tableWriter, "KEY\tVALUE")
+		for _, item := range flattenObject("", value) {
+			fmt.Fprintf(tableWriter, "%v\t%s\n", item.Key, formatTableCell(item.Value))
+		}
+	case []interface{}:
+		var columns []string
+		columnsSet := map[string]bool{}
+		var rows []map[string]interface{}
+		for _, element := range value {
+			row := map[string]interface{}{}
+			object, isObject := element.(yaml.MapSlice)
+			if !isObject {
+				object = yaml.MapSlice{{Key: "value", Value: element}}
+			}
+			for _, item := range flattenObject("", object) {
+				column := fmt.Sprint(item.Key)
+				if !columnsSet[column] {
+					columnsSet[column] = true
+					columns = append(columns, column)
+				}
+				row[column] = item.Value
+			}
+			rows = append(rows, row)
+		}
+		if len(columns) > 0 {
+			fmt.Fprintln(tableWriter, strings.ToUpper(strings.Join(columns, "\t")))
+		}
+		for _, row := range rows {
+			cells := make([]string, len(columns))
+			for i, column := range columns {
+				if cell, exists := row[column]; exists {
+					cells[i] = formatTableCell(cell)
+				}
+			}
+			fmt.Fprintln(tableWriter, strings.Join(cells, "\t"))
+		}
+	default:
+		fmt.Fprintln(tableWriter, formatTableCell(value))
+	}
+	return errorutils.CheckError(tableWriter.Flush())
+}
+
+func flattenObject(prefix string, object yaml.MapSlice) yaml.MapSlice {
+	var flattened yaml.MapSlice
+	for _, item := range object {
+		key := fmt.Sprint(item.Key)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, isObject := item.Value.(yaml.MapSlice); isObject {
+			flattened = append(flattened, flattenObject(key, nested)...)
+			continue
+		}
+		flattened = append(flattened, yaml.MapItem{Key: key, Value: item.Value})
+	}
+	return flattened
+}
+
+// Arrays are written to a single cell as a comma separated list.
+func formatTableCell(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		cells := make([]string, len(value))
+		for i, element := range value {
+			cells[i] = formatTableCell(element)
+		}
+		return strings.Join(cells, ",")
+	case yaml.MapSlice:
+		var cells []string
+		for _, item := range flattenObject("", value) {
+			cells = append(cells, fmt.Sprintf("%v=%s", item.Key, formatTableCell(item.Value)))
+		}
+		return strings.Join(cells, ",")
+	}
+	return fmt.Sprint(value)
+}
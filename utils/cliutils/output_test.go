@@ -0,0 +1,53 @@
+package cliutils
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRenderOutput(t *testing.T) {
+	summary := `{
+  "status": "success",
+  "totals": {
+    "success": 2,
+    "failure": 0
+  }
+}`
+	searchResults := `[
+  {"path": "repo/a.zip", "size": 10, "props": {"k": ["v1", "v2"]}},
+  {"path": "repo/b.zip", "type": "file"}
+]`
+	tests := []struct {
+		name     string
+		content  string
+		format   OutputFormat
+		expected string
+	}{
+		{"summaryYaml", summary, Yaml, "status: success\ntotals:\n  success: 2\n  failure: 0\n"},
+		{"summaryTable", summary, Table, "KEY             VALUE\nstatus          success\ntotals.success  2\ntotals.failure  0\n"},
+		{"searchYaml", searchResults, Yaml, "- path: repo/a.zip\n  size: 10\n  props:\n    k:\n    - v1\n    - v2\n- path: repo/b.zip\n  type: file\n"},
+		{"searchTable", searchResults, Table, "PATH        SIZE  PROPS.K  TYPE\nrepo/a.zip  10    v1,v2    \nrepo/b.zip                 file\n"},
+		{"json", summary, Json, summary},
+		{"freeText", "Server ID: my-server\n", Table, "Server ID: my-server\n"},
+		{"multipleValues", "[1]\n[2]\n", Yaml, "- 1\n- 2\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+			assert.NoError(t, RenderOutput([]byte(test.content), test.format, buffer))
+			assert.Equal(t, test.expected, buffer.String())
+		})
+	}
+}
+
+func TestSetOutputFormat(t *testing.T) {
+	defer func() {
+		outputFormat = ""
+	}()
+	assert.NoError(t, SetOutputFormat(""))
+	assert.Equal(t, OutputFormat(""), GetOutputFormat())
+	assert.NoError(t, SetOutputFormat("JSON"))
+	assert.Equal(t, Json, GetOutputFormat())
+	assert.Error(t, SetOutputFormat("xml"))
+}
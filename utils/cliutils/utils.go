@@ -1,6 +1,7 @@
 package cliutils
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -114,6 +115,10 @@ func PrintDetailedSummaryReport(success, failed int, reader *content.ContentRead
 	}
 	reader.Reset()
 	defer reader.Close()
+	// Rendering the output in a format other than JSON requires the complete summary, so it cannot be streamed to the standard output.
+	if outputBuffer != nil {
+		return summaryPrintError(printCompleteDetailedSummaryReport(success, failed, reader, printExtendedDetails, originalErr), originalErr)
+	}
 	writer, mErr := content.NewContentWriter("files", false, true)
 	if mErr != nil {
 		log.Output(basicSummary)
@@ -126,7 +131,7 @@ func PrintDetailedSummaryReport(success, failed int, reader *content.ContentRead
 	readerLength, _ := reader.Length()
 	// If the reader is empty we will print an empty array.
 	if readerLength == 0 {
-		log.Output("  files: []")
+		log.Output("  \"files\": []")
 	} else {
 		for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
 			writer.Write(getDetailedSummaryRecord(transferDetails, printExtendedDetails))
@@ -136,6 +141,25 @@ func PrintDetailedSummaryReport(success, failed int, reader *content.ContentRead
 	return summaryPrintError(mErr, originalErr)
 }
 
+func printCompleteDetailedSummaryReport(success, failed int, reader *content.ContentReader, printExtendedDetails bool, originalErr error) error {
+	detailedSummary := struct {
+		*summary.Summary
+		Files []interface{} `json:"files"`
+	}{Summary: summary.GetSummaryReport(success, failed, originalErr), Files: []interface{}{}}
+	for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		detailedSummary.Files = append(detailedSummary.Files, getDetailedSummaryRecord(transferDetails, printExtendedDetails))
+	}
+	if err := reader.GetError(); err != nil {
+		return err
+	}
+	content, err := json.Marshal(detailedSummary)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	log.Output(utils.IndentJson(content))
+	return nil
+}
+
 // Get the detailed summary record.
 // In case of an upload/publish commands we want to print sha256 of the uploaded file in addition to the source and the target.
 func getDetailedSummaryRecord(transferDetails *clientutils.FileTransferDetails, extendDetailedSummary bool) interface{} {
@@ -166,8 +190,17 @@ func PrintBuildInfoSummaryReport(succeeded bool, sha256 string, originalErr erro
 	return summaryPrintError(mErr, originalErr)
 }
 
+// The last summary report created by the running command.
+var lastSummaryReport interface{}
+
+// Returns the last summary report created by the running command, or nil if no summary report was created.
+func GetLastSummaryReport() interface{} {
+	return lastSummaryReport
+}
+
 func CreateSummaryReportString(success, failed int, err error) (string, error) {
 	summaryReport := summary.GetSummaryReport(success, failed, err)
+	lastSummaryReport = summaryReport
 	content, mErr := summaryReport.Marshal()
 	if errorutils.CheckError(mErr) != nil {
 		return "", mErr
@@ -177,6 +210,7 @@ func CreateSummaryReportString(success, failed int, err error) (string, error) {
 
 func CreateBuildInfoSummaryReportString(success, failed int, sha256 string, err error) (string, error) {
 	buildInfoSummary := summary.NewBuildInfoSummary(success, failed, sha256, err)
+	lastSummaryReport = buildInfoSummary
 	content, mErr := buildInfoSummary.Marshal()
 	if errorutils.CheckError(mErr) != nil {
 		return "", mErr
@@ -0,0 +1,295 @@
+package dirsync
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	syncStatesDirName = "sync"
+	// The format of the modification time of an item in Artifactory.
+	artifactoryTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+)
+
+// The sides of a synchronized directory.
+const (
+	Local  = "local"
+	Remote = "remote"
+)
+
+// The changes a plan applies to a side.
+const (
+	Add    = "add"
+	Update = "update"
+	Delete = "delete"
+)
+
+// Decides which side's version of a file is kept, if the file changed on both sides since the last sync.
+type ConflictPolicy string
+
+const (
+	NewestWins ConflictPolicy = "newest-wins"
+	LocalWins  ConflictPolicy = "local-wins"
+	RemoteWins ConflictPolicy = "remote-wins"
+	// Fails the sync without applying any change.
+	Fail ConflictPolicy = "fail"
+)
+
+func GetConflictPolicy(policy string) (ConflictPolicy, error) {
+	switch ConflictPolicy(policy) {
+	case NewestWins, LocalWins, RemoteWins, Fail:
+		return ConflictPolicy(policy), nil
+	}
+	return "", errorutils.CheckError(errors.New("unexpected conflict policy '" + policy + "'. Expecting one of: newest-wins, local-wins, remote-wins or fail"))
+}
+
+// A file on one of the sides, by its path relative to the synchronized directory.
+type File struct {
+	Sha1     string
+	Modified time.Time
+}
+
+// A change to apply to one of the sides.
+type Action struct {
+	// The path of the file, relative to the synchronized directory.
+	Path   string `json:"path"`
+	Side   string `json:"side"`
+	Change string `json:"change"`
+	// Set if the file changed on both sides since the last sync, and the change was chosen by the conflict policy.
+	Conflict bool `json:"conflict,omitempty"`
+}
+
+type Plan struct {
+	Actions []*Action `json:"actions"`
+}
+
+// Compares the files of both sides with the files of the last sync, by their sha1 checksum, and returns the actions which make both sides equal.
+// A file which exists on one side only is added to the other side, unless it was synced before, in which case it is deleted from its side.
+// A file which was not synced before never causes a deletion.
+func CreatePlan(local, remote map[string]*File, lastSync map[string]string, policy ConflictPolicy) (*Plan, error) {
+	plan := &Plan{Actions: []*Action{}}
+	var conflicts []string
+	for _, filePath := range getSortedPaths(local, remote) {
+		localFile, remoteFile := local[filePath], remote[filePath]
+		syncedSha1, synced := lastSync[filePath]
+		var action *Action
+		switch {
+		case localFile != nil && remoteFile != nil && localFile.Sha1 == remoteFile.Sha1:
+			continue
+		case synced && isUnchanged(remoteFile, syncedSha1):
+			action = newAction(filePath, localFile, remoteFile, Remote)
+		case synced && isUnchanged(localFile, syncedSha1):
+			action = newAction(filePath, remoteFile, localFile, Local)
+		case remoteFile == nil:
+			action = newAction(filePath, localFile, remoteFile, Remote)
+			// A file which was synced and deleted from one side, while it changed on the other side, is a conflict.
+			action.Conflict = synced
+		case localFile == nil:
+			action = newAction(filePath, remoteFile, localFile, Local)
+			action.Conflict = synced
+		default:
+			action = &Action{Conflict: true}
+		}
+		if action.Conflict {
+			if policy == Fail {
+				conflicts = append(conflicts, filePath)
+				continue
+			}
+			action = resolveConflict(filePath, localFile, remoteFile, policy)
+		}
+		plan.Actions = append(plan.Actions, action)
+	}
+	if len(conflicts) > 0 {
+		return nil, errorutils.CheckError(errors.New("the following files changed on both sides since the last sync:\n" + strings.Join(conflicts, "\n") +
+			"\nUse the --conflict-policy option to choose which side's version to keep"))
+	}
+	return plan, nil
+}
+
+func getSortedPaths(local, remote map[string]*File) []string {
+	var paths []string
+	for filePath := range local {
+		paths = append(paths, filePath)
+	}
+	for filePath := range remote {
+		if _, exists := local[filePath]; !exists {
+			paths = append(paths, filePath)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Returns true if the file is as it was in the last sync.
+func isUnchanged(file *File, syncedSha1 string) bool {
+	return file != nil && file.Sha1 == syncedSha1
+}
+
+// Returns the action which copies the source file to the target side, or deletes the file from the target side if there's no source file.
+func newAction(filePath string, source, target *File, targetSide string) *Action {
+	action := &Action{Path: filePath, Side: targetSide, Change: Update}
+	if source == nil {
+		action.Change = Delete
+	} else if target == nil {
+		action.Change = Add
+	}
+	return action
+}
+
+// Keeps the version of the winning side.
+// With the newest-wins policy, an existing file wins over a deleted file, since the time of the deletion is unknown.
+func resolveConflict(filePath string, localFile, remoteFile *File, policy ConflictPolicy) *Action {
+	localWins := policy == LocalWins
+	if policy == NewestWins {
+		localWins = remoteFile == nil || (localFile != nil && localFile.Modified.After(remoteFile.Modified))
+	}
+	var action *Action
+	if localWins {
+		action = newAction(filePath, localFile, remoteFile, Remote)
+	} else {
+		action = newAction(filePath, remoteFile, localFile, Local)
+	}
+	action.Conflict = true
+	return action
+}
+
+// Returns the checksums of the files which are equal on both sides once the plan is applied, to be saved as the state of the sync.
+// If the plan was not fully applied, the files of its actions keep their state from the last sync, so that the next sync plans the same actions.
+func GetSyncedFiles(local, remote map[string]*File, lastSync map[string]string, plan *Plan, applied bool) map[string]string {
+	synced := map[string]string{}
+	for filePath, localFile := range local {
+		if remoteFile, exists := remote[filePath]; exists && remoteFile.Sha1 == localFile.Sha1 {
+			synced[filePath] = localFile.Sha1
+		}
+	}
+	for _, action := range plan.Actions {
+		source := remote[action.Path]
+		if action.Side == Remote {
+			source = local[action.Path]
+		}
+		switch {
+		case !applied:
+			if syncedSha1, exists := lastSync[action.Path]; exists {
+				synced[action.Path] = syncedSha1
+			}
+		case action.Change == Delete:
+			delete(synced, action.Path)
+		default:
+			synced[action.Path] = source.Sha1
+		}
+	}
+	return synced
+}
+
+// Returns the files under the local directory, by their slash-separated paths relative to the directory.
+func ScanLocal(localDir string) (map[string]*File, error) {
+	files := map[string]*File{}
+	err := filepath.Walk(localDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		relativePath, err := filepath.Rel(localDir, filePath)
+		if err != nil {
+			return err
+		}
+		details, err := fileutils.GetFileDetails(filePath)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(relativePath)] = &File{Sha1: details.Checksum.Sha1, Modified: info.ModTime()}
+		return nil
+	})
+	return files, errorutils.CheckError(err)
+}
+
+// Returns the files under the path in Artifactory, by their paths relative to it.
+func ScanRemote(serviceManager artifactory.ArtifactoryServicesManager, remotePath string) (map[string]*File, error) {
+	params := services.NewSearchParams()
+	params.Pattern = remotePath + "/"
+	params.Recursive = true
+	reader, err := serviceManager.SearchFiles(params)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	files := map[string]*File{}
+	for item := new(serviceutils.ResultItem); reader.NextRecord(item) == nil; item = new(serviceutils.ResultItem) {
+		if item.Type == "folder" {
+			continue
+		}
+		modified, err := time.Parse(artifactoryTimeFormat, item.Modified)
+		if err != nil {
+			log.Debug("Couldn't parse the modification time of " + item.GetItemRelativePath() + ": " + err.Error())
+		}
+		files[strings.TrimPrefix(item.GetItemRelativePath(), remotePath+"/")] = &File{Sha1: item.Actual_Sha1, Modified: modified}
+	}
+	return files, reader.GetError()
+}
+
+// The files which were equal on both sides at the end of the last sync.
+type syncState struct {
+	Files map[string]string `json:"files"`
+}
+
+// Returns the path of the file which stores the state of the sync between the local directory and the path in Artifactory.
+func getStatePath(rtUrl, localDir, remotePath string) (string, error) {
+	statesDir, err := coreutils.CreateDirInJfrogHome(syncStatesDirName)
+	if err != nil {
+		return "", err
+	}
+	absLocalDir, err := filepath.Abs(localDir)
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	checksum := sha256.Sum256([]byte(strings.Join([]string{rtUrl, absLocalDir, path.Clean(remotePath)}, "\n")))
+	return filepath.Join(statesDir, hex.EncodeToString(checksum[:])+".json"), nil
+}
+
+// Returns the checksums of the files which were equal on both sides at the end of the last sync, by their relative paths.
+func LoadState(rtUrl, localDir, remotePath string) (map[string]string, error) {
+	statePath, err := getStatePath(rtUrl, localDir, remotePath)
+	if err != nil {
+		return nil, err
+	}
+	state := &syncState{Files: map[string]string{}}
+	content, err := ioutil.ReadFile(statePath)
+	if os.IsNotExist(err) {
+		return state.Files, nil
+	}
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(content, state); errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	return state.Files, nil
+}
+
+func SaveState(rtUrl, localDir, remotePath string, files map[string]string) error {
+	statePath, err := getStatePath(rtUrl, localDir, remotePath)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(&syncState{Files: files})
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	return errorutils.CheckError(ioutil.WriteFile(statePath, content, 0600))
+}
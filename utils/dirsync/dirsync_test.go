@@ -0,0 +1,108 @@
+package dirsync
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	older = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer = older.Add(time.Hour)
+)
+
+func TestCreatePlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		local    *File
+		remote   *File
+		lastSync string
+		policy   ConflictPolicy
+		expected *Action
+	}{
+		{"equal", &File{Sha1: "a"}, &File{Sha1: "a"}, "", Fail, nil},
+		{"addedLocally", &File{Sha1: "a"}, nil, "", Fail, &Action{Side: Remote, Change: Add}},
+		{"addedRemotely", nil, &File{Sha1: "a"}, "", Fail, &Action{Side: Local, Change: Add}},
+		{"updatedLocally", &File{Sha1: "b"}, &File{Sha1: "a"}, "a", Fail, &Action{Side: Remote, Change: Update}},
+		{"updatedRemotely", &File{Sha1: "a"}, &File{Sha1: "b"}, "a", Fail, &Action{Side: Local, Change: Update}},
+		{"deletedLocally", nil, &File{Sha1: "a"}, "a", Fail, &Action{Side: Remote, Change: Delete}},
+		{"deletedRemotely", &File{Sha1: "a"}, nil, "a", Fail, &Action{Side: Local, Change: Delete}},
+		{"localWins", &File{Sha1: "b"}, &File{Sha1: "c"}, "a", LocalWins, &Action{Side: Remote, Change: Update, Conflict: true}},
+		{"remoteWins", &File{Sha1: "b"}, &File{Sha1: "c"}, "", RemoteWins, &Action{Side: Local, Change: Update, Conflict: true}},
+		{"newestWins", &File{Sha1: "b", Modified: older}, &File{Sha1: "c", Modified: newer}, "a", NewestWins, &Action{Side: Local, Change: Update, Conflict: true}},
+		{"updateWinsOverDeletion", nil, &File{Sha1: "b", Modified: older}, "a", NewestWins, &Action{Side: Local, Change: Add, Conflict: true}},
+		{"deletionWins", nil, &File{Sha1: "b"}, "a", LocalWins, &Action{Side: Remote, Change: Delete, Conflict: true}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			local, remote, lastSync := map[string]*File{}, map[string]*File{}, map[string]string{}
+			if test.local != nil {
+				local["dir/file"] = test.local
+			}
+			if test.remote != nil {
+				remote["dir/file"] = test.remote
+			}
+			if test.lastSync != "" {
+				lastSync["dir/file"] = test.lastSync
+			}
+			plan, err := CreatePlan(local, remote, lastSync, test.policy)
+			assert.NoError(t, err)
+			if test.expected == nil {
+				assert.Empty(t, plan.Actions)
+				return
+			}
+			test.expected.Path = "dir/file"
+			assert.Equal(t, []*Action{test.expected}, plan.Actions)
+		})
+	}
+}
+
+func TestCreatePlanConflict(t *testing.T) {
+	local := map[string]*File{"a": {Sha1: "b"}, "b": {Sha1: "b"}}
+	remote := map[string]*File{"a": {Sha1: "c"}}
+	_, err := CreatePlan(local, remote, map[string]string{"a": "a"}, Fail)
+	assert.EqualError(t, err, "the following files changed on both sides since the last sync:\na\nUse the --conflict-policy option to choose which side's version to keep")
+}
+
+func TestGetSyncedFiles(t *testing.T) {
+	local := map[string]*File{"equal": {Sha1: "a"}, "added": {Sha1: "b"}, "deleted": {Sha1: "c"}}
+	remote := map[string]*File{"equal": {Sha1: "a"}}
+	lastSync := map[string]string{"equal": "a", "deleted": "c", "removed": "d"}
+	plan, err := CreatePlan(local, remote, lastSync, Fail)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"equal": "a", "added": "b"}, GetSyncedFiles(local, remote, lastSync, plan, true))
+	assert.Equal(t, map[string]string{"equal": "a", "deleted": "c"}, GetSyncedFiles(local, remote, lastSync, plan, false))
+}
+
+func TestSyncState(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	oldHome := os.Getenv(coreutils.HomeDir)
+	assert.NoError(t, os.Setenv(coreutils.HomeDir, filepath.Join(tmpDir, "home")))
+	defer os.Setenv(coreutils.HomeDir, oldHome)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "local", "dir"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(tmpDir, "local", "dir", "file"), []byte("content"), 0644))
+	local, err := ScanLocal(filepath.Join(tmpDir, "local"))
+	assert.NoError(t, err)
+	assert.Len(t, local, 1)
+	assert.Equal(t, "040f06fd774092478d450774f5ba30c5da78acc8", local["dir/file"].Sha1)
+
+	files, err := LoadState("http://localhost/artifactory/", filepath.Join(tmpDir, "local"), "repo/path")
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+	assert.NoError(t, SaveState("http://localhost/artifactory/", filepath.Join(tmpDir, "local"), "repo/path", map[string]string{"dir/file": local["dir/file"].Sha1}))
+	files, err = LoadState("http://localhost/artifactory/", filepath.Join(tmpDir, "local"), "repo/path/")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"dir/file": local["dir/file"].Sha1}, files)
+}
@@ -0,0 +1,306 @@
+package downloadcache
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The directory of the download cache. The cache is used only if this variable is set.
+	CacheDirEnv = "JFROG_CLI_CACHE_DIR"
+	// Set to 'true' to hard-link the cached files instead of copying them.
+	// Hard-linked files must not be modified, since they share their content with the cache.
+	CacheHardLinksEnv = "JFROG_CLI_CACHE_HARDLINKS"
+
+	blobsDirName = "sha256"
+	indexDirName = "sha1"
+)
+
+// A content-addressed store of downloaded files, shared by all the commands and jobs which use the same directory.
+// The files are stored by their sha256 checksum, and indexed by their sha1 checksum, which is returned by Artifactory's search.
+type Cache struct {
+	dir       string
+	hardLinks bool
+}
+
+// Returns the cache configured by the environment, or nil if no cache is configured.
+func GetCache() *Cache {
+	dir := os.Getenv(CacheDirEnv)
+	if dir == "" {
+		return nil
+	}
+	hardLinks, _ := strconv.ParseBool(os.Getenv(CacheHardLinksEnv))
+	return NewCache(dir, hardLinks)
+}
+
+func NewCache(dir string, hardLinks bool) *Cache {
+	return &Cache{dir: dir, hardLinks: hardLinks}
+}
+
+func (c *Cache) GetDir() string {
+	return c.dir
+}
+
+func (c *Cache) getBlobPath(checksum string) string {
+	return filepath.Join(c.dir, blobsDirName, checksum[:2], checksum)
+}
+
+func (c *Cache) getIndexPath(checksum string) string {
+	return filepath.Join(c.dir, indexDirName, checksum[:2], checksum)
+}
+
+// Creates the file at the local path from the cached file with the sha1 checksum.
+// Returns false if the file is not cached. Cached files are verified before they are used, and removed if they were modified.
+func (c *Cache) Restore(expectedSha1, localFilePath string) (bool, error) {
+	if len(expectedSha1) < 2 {
+		return false, nil
+	}
+	content, err := ioutil.ReadFile(c.getIndexPath(expectedSha1))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if errorutils.CheckError(err) != nil {
+		return false, err
+	}
+	cachedSha256 := strings.TrimSpace(string(content))
+	if len(cachedSha256) < 2 {
+		return false, c.remove(c.getIndexPath(expectedSha1))
+	}
+	blobPath := c.getBlobPath(cachedSha256)
+	if !fileutils.IsPathExists(blobPath, false) {
+		// The file was pruned.
+		return false, c.remove(c.getIndexPath(expectedSha1))
+	}
+	blobSha1, blobSha256, err := calcChecksums(blobPath)
+	if err != nil {
+		return false, err
+	}
+	if blobSha1 != expectedSha1 || blobSha256 != cachedSha256 {
+		log.Warn("The cached file " + blobPath + " was modified and is removed from the download cache.")
+		if err = c.remove(blobPath); err != nil {
+			return false, err
+		}
+		return false, c.remove(c.getIndexPath(expectedSha1))
+	}
+
+	if err = os.MkdirAll(filepath.Dir(localFilePath), 0755); errorutils.CheckError(err) != nil {
+		return false, err
+	}
+	if err = c.remove(localFilePath); err != nil {
+		return false, err
+	}
+	if err = c.linkOrCopy(blobPath, localFilePath); err != nil {
+		return false, err
+	}
+	// The modification time of the cached files determines which files are pruned first.
+	now := time.Now()
+	return true, errorutils.CheckError(os.Chtimes(blobPath, now, now))
+}
+
+// Adds the local file to the cache, if its sha1 checksum is the expected one.
+func (c *Cache) Store(localFilePath, expectedSha1 string) error {
+	fileSha1, fileSha256, err := calcChecksums(localFilePath)
+	if err != nil {
+		return err
+	}
+	if fileSha1 != expectedSha1 {
+		log.Debug("The checksum of " + localFilePath + " is not the expected one, so it is not added to the download cache.")
+		return nil
+	}
+	blobPath := c.getBlobPath(fileSha256)
+	if !fileutils.IsPathExists(blobPath, false) {
+		if err = os.MkdirAll(filepath.Dir(blobPath), 0755); errorutils.CheckError(err) != nil {
+			return err
+		}
+		// The file is added under a temporary name, so that other processes never use a partially written file.
+		tempPath := getTempPath(blobPath)
+		if err = c.linkOrCopy(localFilePath, tempPath); err != nil {
+			return err
+		}
+		if err = os.Rename(tempPath, blobPath); errorutils.CheckError(err) != nil {
+			c.remove(tempPath)
+			return err
+		}
+	}
+	indexPath := c.getIndexPath(expectedSha1)
+	if err = os.MkdirAll(filepath.Dir(indexPath), 0755); errorutils.CheckError(err) != nil {
+		return err
+	}
+	// The index entry is written the same way, since a restore which reads a partially written entry removes it.
+	tempPath := getTempPath(indexPath)
+	if err = ioutil.WriteFile(tempPath, []byte(fileSha256), 0644); errorutils.CheckError(err) != nil {
+		c.remove(tempPath)
+		return err
+	}
+	if err = os.Rename(tempPath, indexPath); errorutils.CheckError(err) != nil {
+		c.remove(tempPath)
+		return err
+	}
+	return nil
+}
+
+// Returns a unique temporary path next to the path, which files are written to before they are renamed to the path.
+func getTempPath(path string) string {
+	return path + "." + strconv.FormatInt(time.Now().UnixNano(), 10) + ".tmp"
+}
+
+// Returns the sha1 and sha256 checksums of the file.
+func calcChecksums(filePath string) (string, string, error) {
+	file, err := os.Open(filePath)
+	if errorutils.CheckError(err) != nil {
+		return "", "", err
+	}
+	defer file.Close()
+	sha1Hash, sha256Hash := sha1.New(), sha256.New()
+	if _, err = io.Copy(io.MultiWriter(sha1Hash, sha256Hash), file); errorutils.CheckError(err) != nil {
+		return "", "", err
+	}
+	return hex.EncodeToString(sha1Hash.Sum(nil)), hex.EncodeToString(sha256Hash.Sum(nil)), nil
+}
+
+func (c *Cache) linkOrCopy(sourcePath, targetPath string) error {
+	if c.hardLinks {
+		err := os.Link(sourcePath, targetPath)
+		if err == nil {
+			return nil
+		}
+		// Hard links cannot be created across file systems.
+		log.Debug("Couldn't hard-link " + sourcePath + ", copying it instead: " + err.Error())
+	}
+	return copyFile(sourcePath, targetPath)
+}
+
+func copyFile(sourcePath, targetPath string) error {
+	source, err := os.Open(sourcePath)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	defer source.Close()
+	target, err := os.Create(targetPath)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	if _, err = io.Copy(target, source); err != nil {
+		target.Close()
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(target.Close())
+}
+
+func (c *Cache) remove(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errorutils.CheckError(err)
+	}
+	return nil
+}
+
+type Stats struct {
+	Dir   string `json:"dir"`
+	Files int    `json:"files"`
+	// The total size of the cached files in bytes.
+	Size int64 `json:"size"`
+}
+
+func (c *Cache) Stats() (*Stats, error) {
+	blobs, err := c.listBlobs()
+	if err != nil {
+		return nil, err
+	}
+	stats := &Stats{Dir: c.dir, Files: len(blobs)}
+	for _, blob := range blobs {
+		stats.Size += blob.Size()
+	}
+	return stats, nil
+}
+
+type PruneResult struct {
+	RemovedFiles int `json:"removedFiles"`
+	// The size of the removed files in bytes.
+	FreedSize int64 `json:"freedSize"`
+	// The size of the files left in the cache in bytes.
+	Size int64 `json:"size"`
+}
+
+// Removes the least recently used files from the cache, until the size of the cache is at most maxSize bytes.
+// The index entries of removed files are removed by the next restore which looks them up.
+func (c *Cache) Prune(maxSize int64) (*PruneResult, error) {
+	blobs, err := c.listBlobs()
+	if err != nil {
+		return nil, err
+	}
+	result := &PruneResult{}
+	for _, blob := range blobs {
+		result.Size += blob.Size()
+	}
+	sort.Slice(blobs, func(i, j int) bool {
+		return blobs[i].ModTime().Before(blobs[j].ModTime())
+	})
+	for _, blob := range blobs {
+		if result.Size <= maxSize {
+			break
+		}
+		if err = c.remove(c.getBlobPath(blob.Name())); err != nil {
+			return result, err
+		}
+		result.RemovedFiles++
+		result.FreedSize += blob.Size()
+		result.Size -= blob.Size()
+	}
+	return result, nil
+}
+
+func (c *Cache) listBlobs() ([]os.FileInfo, error) {
+	var blobs []os.FileInfo
+	err := filepath.Walk(filepath.Join(c.dir, blobsDirName), func(path string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil || !info.Mode().IsRegular() || strings.HasSuffix(info.Name(), ".tmp") {
+			return err
+		}
+		blobs = append(blobs, info)
+		return nil
+	})
+	return blobs, errorutils.CheckError(err)
+}
+
+var sizeUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"TB", 1 << 40},
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// Parses a size in bytes, or with one of the units KB, MB, GB or TB, such as 500MB.
+func ParseSize(size string) (int64, error) {
+	value, multiplier := strings.ToUpper(strings.TrimSpace(size)), int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			value, multiplier = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix)), unit.multiplier
+			break
+		}
+	}
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || number < 0 {
+		return 0, errorutils.CheckError(errors.New("invalid size '" + size + "'. Expecting a number of bytes, or a number followed by KB, MB, GB or TB, such as 500MB"))
+	}
+	return number * multiplier, nil
+}
@@ -0,0 +1,145 @@
+package downloadcache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/utils/log"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	log.SetDefaultLogger()
+}
+
+const (
+	content     = "content"
+	contentSha1 = "040f06fd774092478d450774f5ba30c5da78acc8"
+)
+
+func TestStoreAndRestore(t *testing.T) {
+	for _, hardLinks := range []bool{false, true} {
+		tmpDir, err := fileutils.CreateTempDir()
+		if err != nil {
+			assert.NoError(t, err)
+			return
+		}
+		cache := NewCache(filepath.Join(tmpDir, "cache"), hardLinks)
+		downloaded := filepath.Join(tmpDir, "downloaded")
+		assert.NoError(t, ioutil.WriteFile(downloaded, []byte(content), 0644))
+
+		restoredPath := filepath.Join(tmpDir, "restored", "file")
+		restored, err := cache.Restore(contentSha1, restoredPath)
+		assert.NoError(t, err)
+		assert.False(t, restored)
+
+		// A file with an unexpected checksum is not stored.
+		assert.NoError(t, cache.Store(downloaded, "0000"))
+		stats, err := cache.Stats()
+		assert.NoError(t, err)
+		assert.Equal(t, 0, stats.Files)
+
+		assert.NoError(t, cache.Store(downloaded, contentSha1))
+		stats, err = cache.Stats()
+		assert.NoError(t, err)
+		assert.Equal(t, &Stats{Dir: cache.GetDir(), Files: 1, Size: int64(len(content))}, stats)
+		// The index entry is renamed from its temporary file once it is written.
+		indexEntries, err := ioutil.ReadDir(filepath.Dir(cache.getIndexPath(contentSha1)))
+		assert.NoError(t, err)
+		if assert.Len(t, indexEntries, 1) {
+			assert.Equal(t, contentSha1, indexEntries[0].Name())
+		}
+
+		restored, err = cache.Restore(contentSha1, restoredPath)
+		assert.NoError(t, err)
+		assert.True(t, restored)
+		restoredContent, err := ioutil.ReadFile(restoredPath)
+		assert.NoError(t, err)
+		assert.Equal(t, content, string(restoredContent))
+		assert.NoError(t, fileutils.RemoveTempDir(tmpDir))
+	}
+}
+
+func TestRestoreModifiedFile(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	cache := NewCache(filepath.Join(tmpDir, "cache"), true)
+	downloaded := filepath.Join(tmpDir, "downloaded")
+	assert.NoError(t, ioutil.WriteFile(downloaded, []byte(content), 0644))
+	assert.NoError(t, cache.Store(downloaded, contentSha1))
+
+	// Modifying a hard-linked file modifies the cached file.
+	assert.NoError(t, ioutil.WriteFile(downloaded, []byte("modified"), 0644))
+	restored, err := cache.Restore(contentSha1, filepath.Join(tmpDir, "restored"))
+	assert.NoError(t, err)
+	assert.False(t, restored)
+	stats, err := cache.Stats()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, stats.Files)
+	assert.False(t, fileutils.IsPathExists(cache.getIndexPath(contentSha1), false))
+}
+
+func TestPrune(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	cache := NewCache(filepath.Join(tmpDir, "cache"), false)
+	contents := []string{"oldest", "middle", "newest"}
+	var blobPaths []string
+	for i, fileContent := range contents {
+		filePath := filepath.Join(tmpDir, fileContent)
+		assert.NoError(t, ioutil.WriteFile(filePath, []byte(fileContent), 0644))
+		fileSha1, fileSha256, err := calcChecksums(filePath)
+		assert.NoError(t, err)
+		assert.NoError(t, cache.Store(filePath, fileSha1))
+		blobPath := cache.getBlobPath(fileSha256)
+		modified := time.Now().Add(time.Duration(i-len(contents)) * time.Hour)
+		assert.NoError(t, os.Chtimes(blobPath, modified, modified))
+		blobPaths = append(blobPaths, blobPath)
+	}
+
+	result, err := cache.Prune(12)
+	assert.NoError(t, err)
+	assert.Equal(t, &PruneResult{RemovedFiles: 1, FreedSize: 6, Size: 12}, result)
+	assert.False(t, fileutils.IsPathExists(blobPaths[0], false))
+	assert.True(t, fileutils.IsPathExists(blobPaths[1], false))
+	assert.True(t, fileutils.IsPathExists(blobPaths[2], false))
+
+	result, err = cache.Prune(0)
+	assert.NoError(t, err)
+	assert.Equal(t, &PruneResult{RemovedFiles: 2, FreedSize: 12, Size: 0}, result)
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		size     string
+		expected int64
+	}{
+		{"100", 100},
+		{"100B", 100},
+		{"2KB", 2048},
+		{"500MB", 500 << 20},
+		{"10 gb", 10 << 30},
+		{"1TB", 1 << 40},
+	}
+	for _, test := range tests {
+		size, err := ParseSize(test.size)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, size, test.size)
+	}
+	for _, size := range []string{"", "GB", "-1", "1.5GB", "10PB"} {
+		_, err := ParseSize(size)
+		assert.Error(t, err, size)
+	}
+}
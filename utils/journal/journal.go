@@ -0,0 +1,160 @@
+package journal
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const journalsDirName = "journals"
+
+// A file which was transferred by a previous run of the command.
+type Entry struct {
+	SourcePath string `json:"sourcePath"`
+	TargetPath string `json:"targetPath"`
+	Sha256     string `json:"sha256,omitempty"`
+	// The size and modification time of the local file when it was transferred.
+	// Used to verify the file did not change since.
+	Size     int64     `json:"size"`
+	Modified time.Time `json:"modified"`
+}
+
+// Creates an entry for a transferred file, recording the current size and modification time of its local copy.
+func NewEntry(sourcePath, targetPath, sha256, localPath string) (*Entry, error) {
+	info, err := os.Stat(localPath)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	return &Entry{SourcePath: sourcePath, TargetPath: targetPath, Sha256: sha256, Size: info.Size(), Modified: info.ModTime()}, nil
+}
+
+// An append-only record of the files transferred by a command, which allows resuming the command if it is interrupted.
+// The journal is stored as a JSONL file in the JFrog CLI home directory.
+type Journal struct {
+	path string
+	// The journaled entries, by their source path.
+	entries map[string]*Entry
+	// All the journaled entries, in the order they were added.
+	orderedEntries []*Entry
+}
+
+// Opens the journal of a command, creating it if it does not exist.
+// The journal is identified by the command's name and the details it was run with, such as the server URL and the file spec,
+// so running the command with different details uses a different journal.
+func Open(command string, details ...interface{}) (*Journal, error) {
+	content, err := json.Marshal(details)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	journalsDir, err := coreutils.CreateDirInJfrogHome(journalsDirName)
+	if err != nil {
+		return nil, err
+	}
+	checksum := sha256.Sum256(content)
+	journal := &Journal{
+		path:    filepath.Join(journalsDir, command+"-"+hex.EncodeToString(checksum[:])+".jsonl"),
+		entries: map[string]*Entry{},
+	}
+	return journal, journal.load()
+}
+
+func (journal *Journal) load() error {
+	exists, err := fileutils.IsFileExists(journal.path, false)
+	if err != nil || !exists {
+		return err
+	}
+	file, err := os.Open(journal.path)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		entry := new(Entry)
+		// The last line may be incomplete if the command was killed while writing it.
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			log.Debug("Skipping line " + strconv.Itoa(lineNumber) + " of the journal " + journal.path + ": " + err.Error())
+			continue
+		}
+		journal.entries[entry.SourcePath] = entry
+		journal.orderedEntries = append(journal.orderedEntries, entry)
+	}
+	return errorutils.CheckError(scanner.Err())
+}
+
+func (journal *Journal) GetPath() string {
+	return journal.path
+}
+
+func (journal *Journal) Len() int {
+	return len(journal.entries)
+}
+
+// Returns all the journaled entries in the order they were added, including entries with the same source path.
+func (journal *Journal) Entries() []*Entry {
+	return journal.orderedEntries
+}
+
+// Returns the journaled entry of the file, or nil if the file wasn't journaled, was transferred to a different target,
+// or if its local copy changed since it was transferred.
+func (journal *Journal) GetVerifiedEntry(sourcePath, targetPath, localPath string) *Entry {
+	entry, exists := journal.entries[sourcePath]
+	if !exists || entry.TargetPath != targetPath {
+		return nil
+	}
+	info, err := os.Stat(localPath)
+	if err != nil || info.Size() != entry.Size || !info.ModTime().Equal(entry.Modified) {
+		return nil
+	}
+	return entry
+}
+
+// Appends the entries to the journal.
+// The entries are written with a single write, so an interrupted command leaves at most one incomplete line.
+func (journal *Journal) Add(entries ...*Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	var content []byte
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if errorutils.CheckError(err) != nil {
+			return err
+		}
+		content = append(append(content, line...), '\n')
+	}
+	file, err := os.OpenFile(journal.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err = file.Write(content); errorutils.CheckError(err) != nil {
+		return err
+	}
+	for _, entry := range entries {
+		journal.entries[entry.SourcePath] = entry
+	}
+	journal.orderedEntries = append(journal.orderedEntries, entries...)
+	return nil
+}
+
+// Removes the journal, once the command completed successfully.
+func (journal *Journal) Remove() error {
+	journal.entries = map[string]*Entry{}
+	journal.orderedEntries = nil
+	exists, err := fileutils.IsFileExists(journal.path, false)
+	if err != nil || !exists {
+		return err
+	}
+	return errorutils.CheckError(os.Remove(journal.path))
+}
@@ -0,0 +1,67 @@
+package journal
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/utils/log"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	log.SetDefaultLogger()
+}
+
+func TestJournal(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	oldHome := os.Getenv(coreutils.HomeDir)
+	assert.NoError(t, os.Setenv(coreutils.HomeDir, tmpDir))
+	defer os.Setenv(coreutils.HomeDir, oldHome)
+
+	localPath := filepath.Join(tmpDir, "a.zip")
+	assert.NoError(t, ioutil.WriteFile(localPath, []byte("content"), 0644))
+	journal, err := Open("upload", "http://localhost:8081/artifactory/", "spec")
+	assert.NoError(t, err)
+	entry, err := NewEntry(localPath, "repo/a.zip", "sha256", localPath)
+	assert.NoError(t, err)
+	assert.NoError(t, journal.Add(entry))
+
+	// Simulate a command which was killed while writing to the journal.
+	file, err := os.OpenFile(journal.GetPath(), os.O_APPEND|os.O_WRONLY, 0600)
+	assert.NoError(t, err)
+	_, err = file.WriteString(`{"sourcePath": "b.zip", "targ`)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	journal, err = Open("upload", "http://localhost:8081/artifactory/", "spec")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, journal.Len())
+	if assert.Len(t, journal.Entries(), 1) {
+		assert.Equal(t, "repo/a.zip", journal.Entries()[0].TargetPath)
+	}
+	assert.NotNil(t, journal.GetVerifiedEntry(localPath, "repo/a.zip", localPath))
+	assert.Nil(t, journal.GetVerifiedEntry(localPath, "repo/b.zip", localPath))
+
+	// Different details use a different journal.
+	otherJournal, err := Open("upload", "http://localhost:8081/artifactory/", "other-spec")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, otherJournal.Len())
+
+	// A file which changed since it was journaled is not verified.
+	assert.NoError(t, ioutil.WriteFile(localPath, []byte("modified content"), 0644))
+	assert.Nil(t, journal.GetVerifiedEntry(localPath, "repo/a.zip", localPath))
+
+	assert.NoError(t, journal.Remove())
+	exists, err := fileutils.IsFileExists(journal.GetPath(), false)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
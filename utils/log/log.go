@@ -3,8 +3,10 @@ package log
 import (
 	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/utils/log"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	clientlog "github.com/jfrog/jfrog-client-go/utils/log"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -29,10 +31,11 @@ func CreateLogFile() (*os.File, error) {
 	return file, nil
 }
 
-// Closes the log file and resets to the default logger
+// Closes the log file and resets to the default logger, which keeps writing the command's output to the output writer.
 func CloseLogFile(logFile *os.File) {
 	if logFile != nil {
 		log.SetDefaultLogger()
+		clientlog.Logger.SetOutputWriter(cliutils.GetOutputWriter())
 		err := logFile.Close()
 		utils.CheckErrorWithMessage(err, "failed closing the log file")
 	}
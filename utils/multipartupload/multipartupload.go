@@ -0,0 +1,452 @@
+package multipartupload
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	uploadsDirName = "uploads"
+	// The multipart upload API of Artifactory. It is available when the binaries of the server are kept in a cloud storage.
+	uploadsApi = "api/v1/uploads/"
+
+	// The statuses of an upload which Artifactory is still completing.
+	partsStatus      = "PARTS"
+	queuedStatus     = "QUEUED"
+	processingStatus = "PROCESSING"
+	// The final statuses of an upload.
+	finishedStatus = "FINISHED"
+	abortedStatus  = "ABORTED"
+	// The interval between the checks of the status of a completed upload, while Artifactory merges its parts.
+	statusPollingInterval = 5 * time.Second
+	// The time to wait for Artifactory to merge the parts of a completed upload is minMergeTimeout,
+	// with mergeTimeoutPerGB added for every GB of the file.
+	minMergeTimeout   = 10 * time.Minute
+	mergeTimeoutPerGB = time.Minute
+)
+
+// A file to upload in parts.
+type UploadDetails struct {
+	LocalFilePath string
+	// The path of the file in Artifactory, in the form repository/path.
+	TargetPath string
+	Size       int64
+	// The checksum of the local file, which Artifactory verifies the merged parts against.
+	Sha1     string
+	PartSize int64
+	// The number of parts uploaded concurrently.
+	Threads int
+}
+
+func (details *UploadDetails) partsCount() int {
+	count := int((details.Size + details.PartSize - 1) / details.PartSize)
+	if count == 0 {
+		// An empty file is uploaded as a single empty part.
+		return 1
+	}
+	return count
+}
+
+// Describes a multipart upload which was started.
+// The state is kept in the JFrog CLI home directory, so that an interrupted upload can continue with the parts which were not uploaded.
+type state struct {
+	ArtifactoryUrl string `json:"artifactoryUrl"`
+	TargetPath     string `json:"targetPath"`
+	Size           int64  `json:"size"`
+	Sha1           string `json:"sha1"`
+	PartSize       int64  `json:"partSize"`
+	// Identifies the upload in the requests which follow its creation.
+	Token string `json:"token"`
+	// The numbers of the uploaded parts, starting from 1.
+	UploadedParts []int `json:"uploadedParts"`
+	// True once Artifactory accepted the request to complete the upload, which is not sent again when the upload is resumed.
+	Completed bool `json:"completed,omitempty"`
+}
+
+func (s *state) isUploaded(partNumber int) bool {
+	for _, uploaded := range s.UploadedParts {
+		if uploaded == partNumber {
+			return true
+		}
+	}
+	return false
+}
+
+type configResponse struct {
+	Supported bool `json:"supported,omitempty"`
+}
+
+type createResponse struct {
+	Token string `json:"token,omitempty"`
+}
+
+type urlPartResponse struct {
+	Url string `json:"url,omitempty"`
+}
+
+type statusResponse struct {
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Returned when Artifactory no longer accepts the token of an upload which was started by a previous run, for example once it expired.
+type rejectedUploadError struct {
+	status string
+}
+
+func (e *rejectedUploadError) Error() string {
+	return "Artifactory rejected the upload with the response: " + e.status
+}
+
+// Returns true if Artifactory supports multipart uploads.
+// Servers which do not provide the multipart upload API, or whose binaries are not kept in a cloud storage, do not support it.
+func IsSupported(serviceManager artifactory.ArtifactoryServicesManager) (bool, error) {
+	configUrl, err := serviceutils.BuildArtifactoryUrl(serviceManager.GetConfig().GetServiceDetails().GetUrl(), uploadsApi+"config", map[string]string{})
+	if err != nil {
+		return false, err
+	}
+	httpClientDetails := serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, _, err := serviceManager.Client().SendGet(configUrl, true, &httpClientDetails)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		log.Debug("Artifactory response for the multipart upload configuration: " + resp.Status)
+		return false, nil
+	}
+	response := new(configResponse)
+	err = json.Unmarshal(body, response)
+	return response.Supported, errorutils.CheckError(err)
+}
+
+// Uploads the file in parts, concurrently.
+// The uploaded parts are recorded in the JFrog CLI home directory, so if the upload fails, uploading the file again
+// only uploads the parts which are still missing. If Artifactory no longer accepts the recorded upload, the file is uploaded from its beginning.
+func Upload(serviceManager artifactory.ArtifactoryServicesManager, details *UploadDetails, progress ioUtils.ProgressMgr, logMsgPrefix string) error {
+	statePath, err := getStatePath(serviceManager.GetConfig().GetServiceDetails().GetUrl(), details)
+	if err != nil {
+		return err
+	}
+	uploadState := loadState(statePath, serviceManager.GetConfig().GetServiceDetails().GetUrl(), details)
+	if uploadState != nil {
+		log.Info(fmt.Sprintf("%sResuming the upload of %s, %d of %d parts were already uploaded.", logMsgPrefix, details.TargetPath, len(uploadState.UploadedParts), details.partsCount()))
+		err = completeUpload(serviceManager, uploadState, statePath, uploadParts(serviceManager, details, uploadState, statePath, progress))
+		if _, rejected := err.(*rejectedUploadError); !rejected {
+			return err
+		}
+		log.Info(logMsgPrefix + "The previous upload of " + details.TargetPath + " cannot be resumed, uploading it from its beginning.")
+	} else {
+		log.Info(logMsgPrefix+"Uploading in parts", details.TargetPath)
+	}
+	if uploadState, err = createUpload(serviceManager, details, statePath); err != nil {
+		return err
+	}
+	err = uploadParts(serviceManager, details, uploadState, statePath, progress)
+	return completeUpload(serviceManager, uploadState, statePath, err)
+}
+
+// Returns the path of the state of the file's upload.
+func getStatePath(artifactoryUrl string, details *UploadDetails) (string, error) {
+	uploadsDir, err := coreutils.CreateDirInJfrogHome(uploadsDirName)
+	if err != nil {
+		return "", err
+	}
+	absLocalFilePath, err := filepath.Abs(details.LocalFilePath)
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	checksum := sha256.Sum256([]byte(artifactoryUrl + details.TargetPath + "\n" + absLocalFilePath))
+	return filepath.Join(uploadsDir, hex.EncodeToString(checksum[:])+".json"), nil
+}
+
+// Loads the state of an upload started by a previous run.
+// Returns nil if there is no such upload, or if it was of a different version of the file.
+func loadState(statePath, artifactoryUrl string, details *UploadDetails) *state {
+	content, err := ioutil.ReadFile(statePath)
+	if err != nil {
+		return nil
+	}
+	existing := new(state)
+	if json.Unmarshal(content, existing) != nil || existing.Token == "" || existing.ArtifactoryUrl != artifactoryUrl || existing.TargetPath != details.TargetPath ||
+		existing.Size != details.Size || existing.Sha1 != details.Sha1 || existing.PartSize != details.PartSize {
+		return nil
+	}
+	return existing
+}
+
+func saveState(statePath string, uploadState *state) error {
+	content, err := json.Marshal(uploadState)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	return errorutils.CheckError(ioutil.WriteFile(statePath, content, 0600))
+}
+
+// Starts a new upload of the file, and saves its state.
+func createUpload(serviceManager artifactory.ArtifactoryServicesManager, details *UploadDetails, statePath string) (*state, error) {
+	repoAndPath := strings.SplitN(details.TargetPath, "/", 2)
+	if len(repoAndPath) < 2 {
+		return nil, errorutils.CheckError(errors.New("the target of a multipart upload should be a path in a repository: " + details.TargetPath))
+	}
+	createUrl, err := serviceutils.BuildArtifactoryUrl(serviceManager.GetConfig().GetServiceDetails().GetUrl(), uploadsApi+"create", map[string]string{
+		"repoKey":    repoAndPath[0],
+		"repoPath":   repoAndPath[1],
+		"partSizeMB": strconv.FormatInt(details.PartSize/(1024*1024), 10),
+	})
+	if err != nil {
+		return nil, err
+	}
+	httpClientDetails := serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := serviceManager.Client().SendPost(createUrl, []byte{}, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK); err != nil {
+		return nil, errorutils.CheckError(errors.New(err.Error() + " " + clientutils.IndentJson(body)))
+	}
+	response := new(createResponse)
+	if err = json.Unmarshal(body, response); errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	uploadState := &state{
+		ArtifactoryUrl: serviceManager.GetConfig().GetServiceDetails().GetUrl(),
+		TargetPath:     details.TargetPath,
+		Size:           details.Size,
+		Sha1:           details.Sha1,
+		PartSize:       details.PartSize,
+		Token:          response.Token,
+	}
+	return uploadState, saveState(statePath, uploadState)
+}
+
+// Uploads the parts which were not uploaded yet, recording each uploaded part in the upload's state.
+func uploadParts(serviceManager artifactory.ArtifactoryServicesManager, details *UploadDetails, uploadState *state, statePath string, progress ioUtils.ProgressMgr) error {
+	var missing []int
+	var missingSize int64
+	for partNumber := 1; partNumber <= details.partsCount(); partNumber++ {
+		if !uploadState.isUploaded(partNumber) {
+			missing = append(missing, partNumber)
+			missingSize += getPartSize(details, partNumber)
+		}
+	}
+	var progressId int
+	if progress != nil {
+		// The progress is of the missing parts only.
+		progressId = progress.NewProgressReader(missingSize, "Uploading", details.TargetPath).GetId()
+		defer progress.RemoveProgress(progressId)
+	}
+
+	threads := details.Threads
+	if threads < 1 {
+		threads = 1
+	}
+	partNumbers := make(chan int, len(missing))
+	for _, partNumber := range missing {
+		partNumbers <- partNumber
+	}
+	close(partNumbers)
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	errorsList := make([]error, threads)
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for partNumber := range partNumbers {
+				if errorsList[i] = uploadPart(serviceManager, details, uploadState.Token, partNumber, progress, progressId); errorsList[i] != nil {
+					return
+				}
+				mutex.Lock()
+				uploadState.UploadedParts = append(uploadState.UploadedParts, partNumber)
+				sort.Ints(uploadState.UploadedParts)
+				errorsList[i] = saveState(statePath, uploadState)
+				mutex.Unlock()
+				if errorsList[i] != nil {
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errorsList {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getPartSize(details *UploadDetails, partNumber int) int64 {
+	start := int64(partNumber-1) * details.PartSize
+	if details.Size-start < details.PartSize {
+		return details.Size - start
+	}
+	return details.PartSize
+}
+
+// Uploads a part of the file to the URL Artifactory provides for it.
+func uploadPart(serviceManager artifactory.ArtifactoryServicesManager, details *UploadDetails, token string, partNumber int, progress ioUtils.ProgressMgr, progressId int) error {
+	partUrl, err := getPartUrl(serviceManager, token, partNumber)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(details.LocalFilePath)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	defer file.Close()
+	partSize := getPartSize(details, partNumber)
+	retryExecutor := clientutils.RetryExecutor{
+		MaxRetries:   serviceManager.GetConfig().GetHttpRetries(),
+		ErrorMessage: fmt.Sprintf("Failure occurred while uploading part %d of %s", partNumber, details.TargetPath),
+		ExecutionHandler: func() (bool, error) {
+			var body io.Reader = io.NewSectionReader(file, int64(partNumber-1)*details.PartSize, partSize)
+			if progress != nil {
+				body = progress.GetProgress(progressId).ActionWithProgress(body)
+			}
+			// The URL of the part is signed, so the request is sent without the credentials of Artifactory.
+			resp, _, err := serviceManager.Client().GetHttpClient().UploadFileFromReader(body, partUrl, httputils.HttpClientDetails{}, partSize)
+			if err != nil {
+				return true, err
+			}
+			if resp.StatusCode >= http.StatusInternalServerError {
+				log.Warn("The server response: " + resp.Status)
+				return true, errorutils.CheckError(errors.New("server response: " + resp.Status))
+			}
+			return false, errorutils.CheckResponseStatus(resp, http.StatusOK, http.StatusCreated)
+		},
+	}
+	return retryExecutor.Execute()
+}
+
+// Returns the URL to upload a part of the file to.
+func getPartUrl(serviceManager artifactory.ArtifactoryServicesManager, token string, partNumber int) (string, error) {
+	partUrl, err := serviceutils.BuildArtifactoryUrl(serviceManager.GetConfig().GetServiceDetails().GetUrl(), uploadsApi+"urlPart", map[string]string{"partNumber": strconv.Itoa(partNumber)})
+	if err != nil {
+		return "", err
+	}
+	resp, body, err := serviceManager.Client().SendPost(partUrl, []byte{}, &httputils.HttpClientDetails{AccessToken: token})
+	if err != nil {
+		return "", err
+	}
+	if err = checkUploadResponse(resp, body, http.StatusOK); err != nil {
+		return "", err
+	}
+	response := new(urlPartResponse)
+	err = json.Unmarshal(body, response)
+	return response.Url, errorutils.CheckError(err)
+}
+
+// Completes the upload once all of its parts were uploaded, and waits for Artifactory to merge them.
+// The state of the upload is kept if its parts failed to upload or Artifactory did not finish merging them in time,
+// and removed once Artifactory completes or aborts the upload.
+func completeUpload(serviceManager artifactory.ArtifactoryServicesManager, uploadState *state, statePath string, uploadErr error) error {
+	if uploadErr != nil {
+		return uploadErr
+	}
+	if !uploadState.Completed {
+		completeUrl, err := serviceutils.BuildArtifactoryUrl(uploadState.ArtifactoryUrl, uploadsApi+"complete", map[string]string{"sha1": uploadState.Sha1})
+		if err != nil {
+			return err
+		}
+		resp, body, err := serviceManager.Client().SendPost(completeUrl, []byte{}, &httputils.HttpClientDetails{AccessToken: uploadState.Token})
+		if err != nil {
+			return err
+		}
+		if err = checkUploadResponse(resp, body, http.StatusOK, http.StatusAccepted); err != nil {
+			return err
+		}
+		uploadState.Completed = true
+		if err = saveState(statePath, uploadState); err != nil {
+			return err
+		}
+	}
+	return waitForMerge(serviceManager, uploadState, statePath, getMergeTimeout(uploadState.Size), statusPollingInterval)
+}
+
+// Returns the time to wait for Artifactory to merge the parts of a file of the provided size.
+func getMergeTimeout(size int64) time.Duration {
+	return minMergeTimeout + time.Duration(size/(1024*1024*1024))*mergeTimeoutPerGB
+}
+
+// Polls the status of a completed upload until Artifactory finishes or aborts it.
+// Fails without removing the state of the upload if the timeout is reached or Artifactory returns an unknown status,
+// so that resuming the upload waits for it again.
+func waitForMerge(serviceManager artifactory.ArtifactoryServicesManager, uploadState *state, statePath string, timeout, pollingInterval time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		status, err := getStatus(serviceManager, uploadState)
+		if err != nil {
+			return err
+		}
+		switch status.Status {
+		case finishedStatus:
+			return errorutils.CheckError(os.Remove(statePath))
+		case abortedStatus:
+			if err = errorutils.CheckError(os.Remove(statePath)); err != nil {
+				return err
+			}
+			return errorutils.CheckError(errors.New("Artifactory aborted the upload of " + uploadState.TargetPath + ": " + status.Error))
+		case partsStatus, queuedStatus, processingStatus:
+		default:
+			return errorutils.CheckError(errors.New("unexpected status of the upload of " + uploadState.TargetPath + ": '" + status.Status + "'"))
+		}
+		if time.Now().Add(pollingInterval).After(deadline) {
+			return errorutils.CheckError(fmt.Errorf("Artifactory did not finish merging the parts of %s within %s. "+
+				"Upload the file again with the --resume option to keep waiting for it", uploadState.TargetPath, timeout))
+		}
+		time.Sleep(pollingInterval)
+	}
+}
+
+func getStatus(serviceManager artifactory.ArtifactoryServicesManager, uploadState *state) (*statusResponse, error) {
+	statusUrl, err := serviceutils.BuildArtifactoryUrl(uploadState.ArtifactoryUrl, uploadsApi+"status", map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	resp, body, err := serviceManager.Client().SendPost(statusUrl, []byte{}, &httputils.HttpClientDetails{AccessToken: uploadState.Token})
+	if err != nil {
+		return nil, err
+	}
+	if err = checkUploadResponse(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	status := new(statusResponse)
+	err = json.Unmarshal(body, status)
+	return status, errorutils.CheckError(err)
+}
+
+// Checks the response to a request sent with the token of an upload.
+// Returns a rejectedUploadError if Artifactory does not accept the token.
+func checkUploadResponse(resp *http.Response, body []byte, expectedStatusCodes ...int) error {
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return &rejectedUploadError{status: resp.Status}
+	}
+	if err := errorutils.CheckResponseStatus(resp, expectedStatusCodes...); err != nil {
+		return errorutils.CheckError(errors.New(err.Error() + clientutils.IndentJson(body)))
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package multipartupload
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// A fake Artifactory which supports multipart uploads.
+type fakeServer struct {
+	*httptest.Server
+	mutex sync.Mutex
+	// The uploaded parts of each upload, by the upload's token.
+	parts map[string]map[int][]byte
+	// The number of uploads created.
+	created int
+	// The number of the part whose upload fails.
+	failingPart int
+	// The uploads whose token is no longer accepted.
+	expired map[string]bool
+	// The content of the completed file.
+	completed []byte
+	// The number of requests to complete an upload.
+	completeRequests int
+	// The status returned for the completed uploads.
+	status string
+}
+
+func newFakeServer(t *testing.T, partsCount int) *fakeServer {
+	server := &fakeServer{parts: map[string]map[int][]byte{}, expired: map[string]bool{}, status: finishedStatus}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		switch {
+		case r.URL.Path == "/api/v1/uploads/config":
+			w.Write([]byte(`{"supported":true}`))
+		case r.URL.Path == "/api/v1/uploads/create":
+			assert.Equal(t, "repo", r.URL.Query().Get("repoKey"))
+			assert.Equal(t, "dir/file.bin", r.URL.Query().Get("repoPath"))
+			server.created++
+			token = "token-" + strconv.Itoa(server.created)
+			server.parts[token] = map[int][]byte{}
+			w.Write([]byte(`{"token":"` + token + `"}`))
+		case server.expired[token]:
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/api/v1/uploads/urlPart":
+			w.Write([]byte(`{"url":"` + server.URL + "/parts/" + token + "/" + r.URL.Query().Get("partNumber") + `"}`))
+		case strings.HasPrefix(r.URL.Path, "/parts/"):
+			// The part's URL is signed, so it is sent without credentials.
+			assert.Empty(t, r.Header.Get("Authorization"))
+			path := strings.Split(strings.TrimPrefix(r.URL.Path, "/parts/"), "/")
+			partNumber, err := strconv.Atoi(path[1])
+			assert.NoError(t, err)
+			if partNumber == server.failingPart {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			content, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			server.parts[path[0]][partNumber] = content
+		case r.URL.Path == "/api/v1/uploads/complete":
+			var merged []byte
+			for i := 1; i <= partsCount; i++ {
+				merged = append(merged, server.parts[token][i]...)
+			}
+			checksum := sha1.Sum(merged)
+			assert.Equal(t, hex.EncodeToString(checksum[:]), r.URL.Query().Get("sha1"))
+			server.completed = merged
+			server.completeRequests++
+			w.WriteHeader(http.StatusAccepted)
+		case r.URL.Path == "/api/v1/uploads/status":
+			w.Write([]byte(`{"status":"` + server.status + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func (server *fakeServer) uploadedParts(token string) int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return len(server.parts[token])
+}
+
+func TestUpload(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	oldHome := os.Getenv(coreutils.HomeDir)
+	assert.NoError(t, os.Setenv(coreutils.HomeDir, filepath.Join(tmpDir, "home")))
+	defer os.Setenv(coreutils.HomeDir, oldHome)
+
+	fileContent := []byte("0123456789")
+	localFilePath := filepath.Join(tmpDir, "file.bin")
+	assert.NoError(t, ioutil.WriteFile(localFilePath, fileContent, 0644))
+	checksum := sha1.Sum(fileContent)
+	details := &UploadDetails{LocalFilePath: localFilePath, TargetPath: "repo/dir/file.bin", Size: int64(len(fileContent)), Sha1: hex.EncodeToString(checksum[:]), PartSize: 4, Threads: 1}
+
+	server := newFakeServer(t, details.partsCount())
+	defer server.Close()
+	serviceManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, 0, false)
+	assert.NoError(t, err)
+	supported, err := IsSupported(serviceManager)
+	assert.NoError(t, err)
+	assert.True(t, supported)
+
+	// The first run fails uploading the second part, so the state of the upload is kept.
+	server.failingPart = 2
+	assert.Error(t, Upload(serviceManager, details, nil, ""))
+	assert.Equal(t, 1, server.uploadedParts("token-1"))
+	statePath, err := getStatePath(server.URL+"/", details)
+	assert.NoError(t, err)
+	content, err := ioutil.ReadFile(statePath)
+	assert.NoError(t, err)
+	uploadState := new(state)
+	assert.NoError(t, json.Unmarshal(content, uploadState))
+	assert.Equal(t, []int{1}, uploadState.UploadedParts)
+
+	// The second run continues the same upload with the missing parts, and removes its state once completed.
+	server.failingPart = 0
+	assert.NoError(t, Upload(serviceManager, details, nil, ""))
+	assert.Equal(t, 1, server.created)
+	assert.Equal(t, 3, server.uploadedParts("token-1"))
+	assert.Equal(t, fileContent, server.completed)
+	exists, err := fileutils.IsFileExists(statePath, false)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestUploadRejectedToken(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	oldHome := os.Getenv(coreutils.HomeDir)
+	assert.NoError(t, os.Setenv(coreutils.HomeDir, filepath.Join(tmpDir, "home")))
+	defer os.Setenv(coreutils.HomeDir, oldHome)
+
+	fileContent := []byte("0123456789")
+	localFilePath := filepath.Join(tmpDir, "file.bin")
+	assert.NoError(t, ioutil.WriteFile(localFilePath, fileContent, 0644))
+	checksum := sha1.Sum(fileContent)
+	details := &UploadDetails{LocalFilePath: localFilePath, TargetPath: "repo/dir/file.bin", Size: int64(len(fileContent)), Sha1: hex.EncodeToString(checksum[:]), PartSize: 4, Threads: 2}
+
+	server := newFakeServer(t, details.partsCount())
+	defer server.Close()
+	serviceManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, 0, false)
+	assert.NoError(t, err)
+	server.failingPart = 3
+	assert.Error(t, Upload(serviceManager, details, nil, ""))
+
+	// An upload whose token is no longer accepted is started again.
+	server.failingPart = 0
+	server.expired["token-1"] = true
+	assert.NoError(t, Upload(serviceManager, details, nil, ""))
+	assert.Equal(t, 2, server.created)
+	assert.Equal(t, 3, server.uploadedParts("token-2"))
+	assert.Equal(t, fileContent, server.completed)
+}
+
+func TestUploadUnknownStatus(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	oldHome := os.Getenv(coreutils.HomeDir)
+	assert.NoError(t, os.Setenv(coreutils.HomeDir, filepath.Join(tmpDir, "home")))
+	defer os.Setenv(coreutils.HomeDir, oldHome)
+
+	fileContent := []byte("0123456789")
+	localFilePath := filepath.Join(tmpDir, "file.bin")
+	assert.NoError(t, ioutil.WriteFile(localFilePath, fileContent, 0644))
+	checksum := sha1.Sum(fileContent)
+	details := &UploadDetails{LocalFilePath: localFilePath, TargetPath: "repo/dir/file.bin", Size: int64(len(fileContent)), Sha1: hex.EncodeToString(checksum[:]), PartSize: 4, Threads: 1}
+
+	server := newFakeServer(t, details.partsCount())
+	defer server.Close()
+	serviceManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, 0, false)
+	assert.NoError(t, err)
+
+	// An unknown status fails the upload, and keeps its state.
+	server.status = "STUCK"
+	assert.EqualError(t, Upload(serviceManager, details, nil, ""), "unexpected status of the upload of repo/dir/file.bin: 'STUCK'")
+	statePath, err := getStatePath(server.URL+"/", details)
+	assert.NoError(t, err)
+	uploadState := loadState(statePath, server.URL+"/", details)
+	if assert.NotNil(t, uploadState) {
+		assert.True(t, uploadState.Completed)
+	}
+
+	// Resuming the upload waits for it again, without completing it again.
+	server.status = finishedStatus
+	assert.NoError(t, Upload(serviceManager, details, nil, ""))
+	assert.Equal(t, 1, server.created)
+	assert.Equal(t, 1, server.completeRequests)
+	exists, err := fileutils.IsFileExists(statePath, false)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestWaitForMergeTimeout(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+
+	server := newFakeServer(t, 1)
+	defer server.Close()
+	server.status = processingStatus
+	serviceManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, 0, false)
+	assert.NoError(t, err)
+	uploadState := &state{ArtifactoryUrl: server.URL + "/", TargetPath: "repo/dir/file.bin", Token: "token-1", Completed: true}
+	statePath := filepath.Join(tmpDir, "state.json")
+	assert.NoError(t, saveState(statePath, uploadState))
+
+	// The state of an upload which was not merged in time is kept, so that it can be resumed.
+	assert.Error(t, waitForMerge(serviceManager, uploadState, statePath, 10*time.Millisecond, time.Millisecond))
+	exists, err := fileutils.IsFileExists(statePath, false)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestGetMergeTimeout(t *testing.T) {
+	assert.Equal(t, minMergeTimeout, getMergeTimeout(0))
+	assert.Equal(t, minMergeTimeout, getMergeTimeout(1024*1024))
+	assert.Equal(t, minMergeTimeout+5*mergeTimeoutPerGB, getMergeTimeout(5*1024*1024*1024+1))
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
 	corelog "github.com/jfrog/jfrog-cli-core/utils/log"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	logUtils "github.com/jfrog/jfrog-cli/utils/log"
 	"github.com/jfrog/jfrog-client-go/utils"
 	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
@@ -220,7 +221,9 @@ func InitProgressBarIfPossible() (ioUtils.ProgressMgr, *os.File, error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	log.SetLogger(log.NewLogger(corelog.GetCliLogLevel(), logFile))
+	logger := log.NewLogger(corelog.GetCliLogLevel(), logFile)
+	logger.SetOutputWriter(cliutils.GetOutputWriter())
+	log.SetLogger(logger)
 
 	newProgressBar := &progressBarManager{}
 	newProgressBar.barsWg = new(sync.WaitGroup)
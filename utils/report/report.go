@@ -0,0 +1,237 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+)
+
+type Format string
+
+const (
+	Junit Format = "junit"
+	Sarif Format = "sarif"
+)
+
+// Parses the value of the --report-format option.
+func GetFormat(format string) (Format, error) {
+	switch Format(strings.ToLower(format)) {
+	case "", Junit:
+		return Junit, nil
+	case Sarif:
+		return Sarif, nil
+	}
+	return "", errorutils.CheckError(errors.New(fmt.Sprintf("unsupported report format '%s'. Supported formats are: junit and sarif", format)))
+}
+
+// A report of a command's result, in which each affected file is a test case (JUnit) or a result (SARIF).
+type Report struct {
+	// The reported command, such as 'rt upload'.
+	Command  string
+	FilePath string
+	Format   Format
+	Cases    []*Case
+}
+
+type Case struct {
+	// The path of the affected file, or a description of the case if it isn't related to a single file.
+	Name string
+	// Set if the case is related to a single file.
+	IsFile bool
+	// The failure message. Empty if the case passed.
+	Failure string
+}
+
+func New(command, filePath string, format Format) *Report {
+	return &Report{Command: command, FilePath: filePath, Format: format}
+}
+
+func (report *Report) AddCase(name, failure string) {
+	report.Cases = append(report.Cases, &Case{Name: name, Failure: failure})
+}
+
+func (report *Report) AddFileCase(path, failure string) {
+	report.Cases = append(report.Cases, &Case{Name: path, IsFile: true, Failure: failure})
+}
+
+// Adds a passing case for each file in the transfer details reader.
+// The reader is reset, so it can be used again after the report is created.
+func (report *Report) AddTransferDetails(reader *content.ContentReader) error {
+	if reader == nil {
+		return nil
+	}
+	reader.Reset()
+	defer reader.Reset()
+	for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		report.AddFileCase(transferDetails.TargetPath, "")
+	}
+	return reader.GetError()
+}
+
+// Adds a failing case for the command's failures.
+// The command results only include the number of failed files, so they are reported as a single case.
+func (report *Report) AddFailures(failed int, err error) {
+	if failed == 0 && err == nil {
+		return
+	}
+	message := fmt.Sprintf("%d files failed", failed)
+	if err != nil {
+		message = err.Error()
+		if failed > 0 {
+			message = fmt.Sprintf("%d files failed: %s", failed, message)
+		}
+	}
+	report.AddCase(report.Command, message)
+}
+
+func (report *Report) failuresCount() (failures int) {
+	for _, reportCase := range report.Cases {
+		if reportCase.Failure != "" {
+			failures++
+		}
+	}
+	return
+}
+
+// Writes the report to its file.
+func (report *Report) Write() error {
+	var content []byte
+	var err error
+	switch report.Format {
+	case Sarif:
+		content, err = report.toSarif()
+	default:
+		content, err = report.toJunit()
+	}
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckError(ioutil.WriteFile(report.FilePath, content, 0644))
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+func (report *Report) toJunit() ([]byte, error) {
+	suiteName := "jfrog " + report.Command
+	suite := junitTestSuite{Name: suiteName, Tests: len(report.Cases), Failures: report.failuresCount(), TestCases: []junitTestCase{}}
+	for _, reportCase := range report.Cases {
+		testCase := junitTestCase{ClassName: suiteName, Name: reportCase.Name}
+		if reportCase.Failure != "" {
+			testCase.Failure = &junitFailure{Message: reportCase.Failure}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	content, err := xml.MarshalIndent(junitTestSuites{TestSuites: []junitTestSuite{suite}}, "", "  ")
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(content, '\n')...), nil
+}
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "JFrog CLI"
+	toolUri      = "https://github.com/jfrog/jfrog-cli"
+)
+
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationUri string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	Kind      string          `json:"kind"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+func (report *Report) toSarif() ([]byte, error) {
+	ruleId := strings.ReplaceAll(report.Command, " ", "-")
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: toolName, InformationUri: toolUri, Rules: []sarifRule{{Id: ruleId}}}},
+		Results: []sarifResult{},
+	}
+	for _, reportCase := range report.Cases {
+		result := sarifResult{RuleId: ruleId, Kind: "pass", Level: "none", Message: sarifMessage{Text: reportCase.Name}}
+		if reportCase.Failure != "" {
+			result.Kind = "fail"
+			result.Level = "error"
+			result.Message.Text = reportCase.Failure
+		}
+		if reportCase.IsFile {
+			result.Locations = []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{Uri: reportCase.Name}}}}
+		}
+		run.Results = append(run.Results, result)
+	}
+	content, err := json.Marshal(sarifReport{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}})
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	return []byte(clientutils.IndentJson(content) + "\n"), nil
+}
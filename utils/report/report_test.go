@@ -0,0 +1,93 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetFormat(t *testing.T) {
+	format, err := GetFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, Junit, format)
+	format, err = GetFormat("SARIF")
+	assert.NoError(t, err)
+	assert.Equal(t, Sarif, format)
+	_, err = GetFormat("html")
+	assert.Error(t, err)
+}
+
+func TestWriteReport(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+
+	// Create a transfer details reader, as returned by the upload and download commands.
+	writer, err := content.NewContentWriter("files", true, false)
+	assert.NoError(t, err)
+	writer.Write(utils.FileTransferDetails{SourcePath: "a.zip", TargetPath: "repo/a.zip"})
+	writer.Write(utils.FileTransferDetails{SourcePath: "b.zip", TargetPath: "repo/b.zip"})
+	assert.NoError(t, writer.Close())
+	reader := content.NewContentReader(writer.GetFilePath(), "files")
+	defer reader.Close()
+
+	junitPath := filepath.Join(tmpDir, "report.xml")
+	junitReport := New("rt upload", junitPath, Junit)
+	assert.NoError(t, junitReport.AddTransferDetails(reader))
+	junitReport.AddFailures(1, errors.New("upload finished with errors"))
+	assert.NoError(t, junitReport.Write())
+
+	// The reader should remain usable after the report is created.
+	length, err := reader.Length()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, length)
+
+	junitContent, err := ioutil.ReadFile(junitPath)
+	assert.NoError(t, err)
+	suites := new(junitTestSuites)
+	assert.NoError(t, xml.Unmarshal(junitContent, suites))
+	if assert.Len(t, suites.TestSuites, 1) {
+		suite := suites.TestSuites[0]
+		assert.Equal(t, "jfrog rt upload", suite.Name)
+		assert.Equal(t, 3, suite.Tests)
+		assert.Equal(t, 1, suite.Failures)
+		if assert.Len(t, suite.TestCases, 3) {
+			assert.Equal(t, "repo/a.zip", suite.TestCases[0].Name)
+			assert.Nil(t, suite.TestCases[0].Failure)
+			if assert.NotNil(t, suite.TestCases[2].Failure) {
+				assert.Equal(t, "1 files failed: upload finished with errors", suite.TestCases[2].Failure.Message)
+			}
+		}
+	}
+
+	sarifPath := filepath.Join(tmpDir, "report.sarif")
+	deleteReport := New("rt delete", sarifPath, Sarif)
+	deleteReport.AddFileCase("repo/a.zip", "")
+	deleteReport.AddFailures(2, nil)
+	assert.NoError(t, deleteReport.Write())
+	sarifContent, err := ioutil.ReadFile(sarifPath)
+	assert.NoError(t, err)
+	sarif := new(sarifReport)
+	assert.NoError(t, json.Unmarshal(sarifContent, sarif))
+	assert.Equal(t, sarifVersion, sarif.Version)
+	if assert.Len(t, sarif.Runs, 1) && assert.Len(t, sarif.Runs[0].Results, 2) {
+		passed, failed := sarif.Runs[0].Results[0], sarif.Runs[0].Results[1]
+		assert.Equal(t, "rt-delete", passed.RuleId)
+		assert.Equal(t, "pass", passed.Kind)
+		assert.Equal(t, "repo/a.zip", passed.Locations[0].PhysicalLocation.ArtifactLocation.Uri)
+		assert.Equal(t, "fail", failed.Kind)
+		assert.Equal(t, "error", failed.Level)
+		assert.Equal(t, "2 files failed", failed.Message.Text)
+		assert.Empty(t, failed.Locations)
+	}
+}
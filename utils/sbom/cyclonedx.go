@@ -0,0 +1,88 @@
+package sbom
+
+import (
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+)
+
+// A CycloneDX 1.4 BOM, as defined by https://cyclonedx.org/docs/1.4/json.
+type cycloneDxBom struct {
+	BomFormat    string                 `json:"bomFormat"`
+	SpecVersion  string                 `json:"specVersion"`
+	SerialNumber string                 `json:"serialNumber"`
+	Version      int                    `json:"version"`
+	Metadata     cycloneDxMetadata      `json:"metadata"`
+	Components   []*cycloneDxComponent  `json:"components"`
+	Dependencies []*cycloneDxDependency `json:"dependencies"`
+}
+
+type cycloneDxMetadata struct {
+	Timestamp string              `json:"timestamp"`
+	Tools     []cycloneDxTool     `json:"tools"`
+	Component *cycloneDxComponent `json:"component"`
+}
+
+type cycloneDxTool struct {
+	Vendor  string `json:"vendor"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type cycloneDxComponent struct {
+	Type    string          `json:"type"`
+	BomRef  string          `json:"bom-ref"`
+	Group   string          `json:"group,omitempty"`
+	Name    string          `json:"name"`
+	Version string          `json:"version,omitempty"`
+	Hashes  []cycloneDxHash `json:"hashes,omitempty"`
+	Purl    string          `json:"purl,omitempty"`
+}
+
+type cycloneDxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cycloneDxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// The build is the component the BOM describes, and it depends on its modules.
+func createCycloneDxBom(buildInfo *buildinfo.BuildInfo, components []*component) *cycloneDxBom {
+	buildRef := "build:" + buildInfo.Name + "/" + buildInfo.Number
+	bom := &cycloneDxBom{
+		BomFormat:    "CycloneDX",
+		SpecVersion:  "1.4",
+		SerialNumber: "urn:uuid:" + getBuildUuid(buildInfo),
+		Version:      1,
+		Metadata: cycloneDxMetadata{
+			Timestamp: getTimestamp(buildInfo),
+			Tools:     []cycloneDxTool{{Vendor: "JFrog", Name: getToolName(), Version: coreutils.GetCliUserAgentVersion()}},
+			Component: &cycloneDxComponent{Type: "application", BomRef: buildRef, Name: buildInfo.Name, Version: buildInfo.Number},
+		},
+		Components:   []*cycloneDxComponent{},
+		Dependencies: []*cycloneDxDependency{},
+	}
+	buildDependency := &cycloneDxDependency{Ref: buildRef, DependsOn: []string{}}
+	bom.Dependencies = append(bom.Dependencies, buildDependency)
+	for _, c := range components {
+		bomComponent := &cycloneDxComponent{Type: "library", BomRef: c.purl, Group: c.group, Name: c.name, Version: c.version, Purl: c.purl}
+		if c.sha1 != "" {
+			bomComponent.Hashes = append(bomComponent.Hashes, cycloneDxHash{Alg: "SHA-1", Content: c.sha1})
+		}
+		if c.md5 != "" {
+			bomComponent.Hashes = append(bomComponent.Hashes, cycloneDxHash{Alg: "MD5", Content: c.md5})
+		}
+		bom.Components = append(bom.Components, bomComponent)
+		if c.isModule {
+			buildDependency.DependsOn = append(buildDependency.DependsOn, c.purl)
+		}
+		dependsOn := c.dependsOn
+		if dependsOn == nil {
+			dependsOn = []string{}
+		}
+		bom.Dependencies = append(bom.Dependencies, &cycloneDxDependency{Ref: c.purl, DependsOn: dependsOn})
+	}
+	return bom
+}
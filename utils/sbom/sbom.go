@@ -0,0 +1,283 @@
+package sbom
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The supported SBOM formats.
+const (
+	CycloneDx = "cyclonedx"
+	Spdx      = "spdx"
+)
+
+// Returns an error if the SBOM format is not supported.
+func ValidateFormat(format string) error {
+	if format != CycloneDx && format != Spdx {
+		return errorutils.CheckError(errors.New("unsupported SBOM format '" + format + "'. Supported formats are: " + CycloneDx + " and " + Spdx))
+	}
+	return nil
+}
+
+// Returns the file name extension of the SBOM format.
+func GetFileExtension(format string) string {
+	if format == Spdx {
+		return ".spdx.json"
+	}
+	return ".cdx.json"
+}
+
+// Generates the SBOM of the build-info, as JSON in the given format.
+// The modules of the build and their dependencies are the components of the SBOM.
+// SPDX documents are namespaced by the URL of the build in Artifactory.
+func Generate(buildInfo *buildinfo.BuildInfo, format, artifactoryUrl string) ([]byte, error) {
+	if err := ValidateFormat(format); err != nil {
+		return nil, err
+	}
+	components := collectComponents(buildInfo)
+	var bom interface{}
+	if format == Spdx {
+		bom = createSpdxDocument(buildInfo, components, artifactoryUrl)
+	} else {
+		bom = createCycloneDxBom(buildInfo, components)
+	}
+	content, err := json.MarshalIndent(bom, "", "  ")
+	return content, errorutils.CheckError(err)
+}
+
+// A module of the build or one of its dependencies.
+type component struct {
+	purl    string
+	group   string
+	name    string
+	version string
+	sha1    string
+	md5     string
+	// The purl qualifiers, in the form of key=value.
+	qualifiers []string
+	// True for the modules of the build.
+	isModule bool
+	// The purls of the components this component depends on directly.
+	dependsOn []string
+}
+
+// Returns the modules and dependencies of the build, by their order in the build-info.
+// A dependency of several modules is returned once, and depends on the union of its dependencies in all modules.
+func collectComponents(buildInfo *buildinfo.BuildInfo) []*component {
+	var components []*component
+	componentsByPurl := map[string]*component{}
+	addComponent := func(moduleType buildinfo.ModuleType, id string, checksum *buildinfo.Checksum, isModule bool) *component {
+		newComponent := createComponent(moduleType, id, checksum, isModule)
+		if existing, exists := componentsByPurl[newComponent.purl]; exists {
+			return existing
+		}
+		componentsByPurl[newComponent.purl] = newComponent
+		components = append(components, newComponent)
+		return newComponent
+	}
+
+	for _, module := range buildInfo.Modules {
+		moduleComponent := addComponent(module.Type, module.Id, nil, true)
+		dependencies := map[string]*component{}
+		for _, dependency := range module.Dependencies {
+			dependencies[dependency.Id] = addComponent(module.Type, dependency.Id, dependency.Checksum, false)
+		}
+		// The first element of each requestedBy path is the direct parent of the dependency.
+		// Dependencies without requestedBy paths are direct dependencies of the module.
+		for _, dependency := range module.Dependencies {
+			dependencyPurl := dependencies[dependency.Id].purl
+			if len(dependency.RequestedBy) == 0 {
+				moduleComponent.addDependency(dependencyPurl)
+			}
+			for _, path := range dependency.RequestedBy {
+				if len(path) == 0 {
+					continue
+				}
+				if parent, exists := dependencies[path[0]]; exists && path[0] != module.Id {
+					parent.addDependency(dependencyPurl)
+				} else {
+					moduleComponent.addDependency(dependencyPurl)
+				}
+			}
+		}
+	}
+	return components
+}
+
+func (c *component) addDependency(purl string) {
+	if purl == c.purl {
+		return
+	}
+	for _, existing := range c.dependsOn {
+		if existing == purl {
+			return
+		}
+	}
+	c.dependsOn = append(c.dependsOn, purl)
+}
+
+// Creates the component of a module or a dependency, with its purl derived from the type of the module.
+// IDs which don't match the format of their module type, such as Docker layers and files added by 'build-add-dependencies', get generic purls.
+func createComponent(moduleType buildinfo.ModuleType, id string, checksum *buildinfo.Checksum, isModule bool) *component {
+	c := &component{isModule: isModule}
+	if checksum != nil {
+		c.sha1, c.md5 = checksum.Sha1, checksum.Md5
+	}
+	purlType := ""
+	switch moduleType {
+	case buildinfo.Maven, buildinfo.Gradle:
+		if parts := strings.Split(id, ":"); len(parts) >= 3 {
+			purlType, c.group, c.name, c.version = "maven", parts[0], parts[1], parts[2]
+		}
+	case buildinfo.Npm:
+		purlType, c.name, c.version = "npm", id, ""
+		if separator := strings.LastIndex(id, ":"); separator > 0 {
+			c.name, c.version = id[:separator], id[separator+1:]
+		}
+		if strings.HasPrefix(c.name, "@") && strings.Contains(c.name, "/") {
+			c.group, c.name = c.name[:strings.Index(c.name, "/")], c.name[strings.Index(c.name, "/")+1:]
+		}
+	case buildinfo.Go:
+		purlType, c.name = "golang", id
+		if separator := strings.LastIndex(id, ":"); separator > 0 {
+			c.name, c.version = id[:separator], id[separator+1:]
+		}
+		if separator := strings.LastIndex(c.name, "/"); separator > 0 {
+			c.group, c.name = c.name[:separator], c.name[separator+1:]
+		}
+	case buildinfo.Pip:
+		// The IDs of the dependencies are the names of their files, or their names if their files are unknown.
+		purlType, c.name = "pypi", id
+		if !isModule {
+			c.name, c.version = parsePythonFileName(id)
+		}
+		c.name = normalizePythonName(c.name)
+	case buildinfo.Nuget:
+		purlType, c.name = "nuget", id
+		if separator := strings.LastIndex(id, ":"); separator > 0 {
+			c.name, c.version = id[:separator], id[separator+1:]
+		}
+	case buildinfo.Docker:
+		// Only the image is a Docker package. Its layers are generic files.
+		if isModule {
+			purlType, c.name = "docker", id
+			if separator := strings.LastIndex(id, ":"); separator > strings.LastIndex(id, "/") {
+				c.name, c.version = id[:separator], id[separator+1:]
+			}
+			// The registry of the image, such as 'acme.jfrog.io' in 'acme.jfrog.io/docker/app', is a qualifier.
+			if parts := strings.SplitN(c.name, "/", 2); len(parts) == 2 && strings.ContainsAny(parts[0], ".:") {
+				c.qualifiers = append(c.qualifiers, "repository_url="+parts[0])
+				c.name = parts[1]
+			}
+			if separator := strings.LastIndex(c.name, "/"); separator > 0 {
+				c.group, c.name = c.name[:separator], c.name[separator+1:]
+			}
+		}
+	}
+	if purlType == "" {
+		purlType, c.group, c.name, c.version = "generic", "", id, ""
+		// Generic packages are identified by their checksums.
+		if c.sha1 != "" {
+			c.qualifiers = append(c.qualifiers, "checksum=sha1:"+c.sha1)
+		}
+	}
+	c.purl = createPurl(purlType, c.group, c.name, c.version, c.qualifiers)
+	return c
+}
+
+// The file name extensions of Python source distributions.
+var sdistExtensions = []string{".tar.gz", ".tar.bz2", ".tar.xz", ".tgz", ".zip"}
+
+var pythonNameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// Returns the name and version of a Python package by the name of its file.
+// Wheels are named 'name-version(-build)?-python-abi-platform.whl', as defined by PEP 427, and source distributions
+// are named 'name-version' followed by their archive extension. Other IDs are returned as names without versions.
+func parsePythonFileName(fileName string) (name, version string) {
+	if strings.HasSuffix(fileName, ".whl") {
+		if parts := strings.Split(strings.TrimSuffix(fileName, ".whl"), "-"); len(parts) == 5 || len(parts) == 6 {
+			return parts[0], parts[1]
+		}
+		return fileName, ""
+	}
+	for _, extension := range sdistExtensions {
+		if strings.HasSuffix(fileName, extension) {
+			nameAndVersion := strings.TrimSuffix(fileName, extension)
+			// Versions don't contain hyphens, while the names of old source distributions may contain them.
+			if separator := strings.LastIndex(nameAndVersion, "-"); separator > 0 {
+				return nameAndVersion[:separator], nameAndVersion[separator+1:]
+			}
+			return fileName, ""
+		}
+	}
+	return fileName, ""
+}
+
+// Normalizes the name of a Python package, as defined by PEP 503.
+func normalizePythonName(name string) string {
+	return pythonNameSeparators.ReplaceAllString(strings.ToLower(name), "-")
+}
+
+// Creates a package URL, as defined by https://github.com/package-url/purl-spec.
+func createPurl(purlType, namespace, name, version string, qualifiers []string) string {
+	purl := "pkg:" + purlType + "/"
+	if namespace != "" {
+		var segments []string
+		for _, segment := range strings.Split(namespace, "/") {
+			segments = append(segments, escapePurlSegment(segment))
+		}
+		purl += strings.Join(segments, "/") + "/"
+	}
+	purl += escapePurlSegment(name)
+	if version != "" {
+		purl += "@" + escapePurlSegment(version)
+	}
+	if len(qualifiers) > 0 {
+		purl += "?" + strings.Join(qualifiers, "&")
+	}
+	return purl
+}
+
+func escapePurlSegment(segment string) string {
+	return strings.ReplaceAll(url.PathEscape(segment), "@", "%40")
+}
+
+// Returns the time the build started, or the current time if it's unknown.
+func getTimestamp(buildInfo *buildinfo.BuildInfo) string {
+	started, err := time.Parse(buildinfo.TimeFormat, buildInfo.Started)
+	if err != nil {
+		started = time.Now()
+	}
+	return started.UTC().Format(time.RFC3339)
+}
+
+// Returns a UUID derived from the build, so that the SBOMs of a build are identified by the same UUID.
+// The UUID is a version 5 UUID, in the URL namespace.
+func getBuildUuid(buildInfo *buildinfo.BuildInfo) string {
+	urlNamespace := []byte{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	hash := sha1.New()
+	hash.Write(urlNamespace)
+	hash.Write([]byte(buildInfo.Name + "/" + buildInfo.Number + "/" + buildInfo.Started))
+	uuid := hash.Sum(nil)[:16]
+	uuid[6] = (uuid[6] & 0x0f) | 0x50
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16])
+}
+
+func getToolName() string {
+	name := coreutils.GetCliUserAgentName()
+	if name == "" {
+		name = "jfrog-cli-go"
+	}
+	return name
+}
@@ -0,0 +1,135 @@
+package sbom
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateComponent(t *testing.T) {
+	tests := []struct {
+		moduleType buildinfo.ModuleType
+		id         string
+		isModule   bool
+		expected   string
+	}{
+		{buildinfo.Maven, "org.acme:app:1.0", true, "pkg:maven/org.acme/app@1.0"},
+		{buildinfo.Gradle, "org.acme:lib:2.0", false, "pkg:maven/org.acme/lib@2.0"},
+		{buildinfo.Npm, "@scope/pkg:1.2.3", false, "pkg:npm/%40scope/pkg@1.2.3"},
+		{buildinfo.Npm, "left-pad:1.3.0", false, "pkg:npm/left-pad@1.3.0"},
+		{buildinfo.Go, "github.com/acme/app", true, "pkg:golang/github.com/acme/app"},
+		{buildinfo.Go, "github.com/pkg/errors:v0.9.1", false, "pkg:golang/github.com/pkg/errors@v0.9.1"},
+		{buildinfo.Pip, "my_app", true, "pkg:pypi/my-app"},
+		{buildinfo.Pip, "Flask_Cors-3.0.10-py2.py3-none-any.whl", false, "pkg:pypi/flask-cors@3.0.10"},
+		{buildinfo.Pip, "numpy-1.19.5-cp38-cp38-manylinux2010_x86_64.whl", false, "pkg:pypi/numpy@1.19.5"},
+		{buildinfo.Pip, "zope.interface-5.2.0-1-cp38-cp38-manylinux2010_x86_64.whl", false, "pkg:pypi/zope-interface@5.2.0"},
+		{buildinfo.Pip, "PyYAML-5.4.1.tar.gz", false, "pkg:pypi/pyyaml@5.4.1"},
+		{buildinfo.Pip, "python-dateutil-2.8.1.zip", false, "pkg:pypi/python-dateutil@2.8.1"},
+		{buildinfo.Pip, "requests", false, "pkg:pypi/requests"},
+		{buildinfo.Nuget, "Newtonsoft.Json:12.0.3", false, "pkg:nuget/Newtonsoft.Json@12.0.3"},
+		{buildinfo.Docker, "registry:8081/acme/app:1.0", true, "pkg:docker/acme/app@1.0?repository_url=registry:8081"},
+		{buildinfo.Docker, "app:latest", true, "pkg:docker/app@latest"},
+		{buildinfo.Docker, "sha256__abc", false, "pkg:generic/sha256__abc?checksum=sha1:s"},
+		{buildinfo.Generic, "lib/file.zip", false, "pkg:generic/lib%2Ffile.zip?checksum=sha1:s"},
+		{buildinfo.Maven, "no-version", false, "pkg:generic/no-version?checksum=sha1:s"},
+	}
+	for _, test := range tests {
+		c := createComponent(test.moduleType, test.id, &buildinfo.Checksum{Sha1: "s"}, test.isModule)
+		assert.Equal(t, test.expected, c.purl, test.id)
+	}
+}
+
+func createTestBuildInfo() *buildinfo.BuildInfo {
+	return &buildinfo.BuildInfo{
+		Name:    "app",
+		Number:  "42",
+		Started: "2021-03-01T10:00:00.000+0200",
+		Modules: []buildinfo.Module{
+			{
+				Type: buildinfo.Maven,
+				Id:   "org.acme:app:42",
+				Dependencies: []buildinfo.Dependency{
+					{Id: "org.acme:lib:1.0", Checksum: &buildinfo.Checksum{Sha1: "l1", Md5: "m1"}, RequestedBy: [][]string{{"org.acme:app:42"}}},
+					{Id: "org.acme:transitive:2.0", Checksum: &buildinfo.Checksum{Sha1: "t2"}, RequestedBy: [][]string{{"org.acme:lib:1.0", "org.acme:app:42"}}},
+				},
+			},
+			{
+				Type:         buildinfo.Maven,
+				Id:           "org.acme:cli:42",
+				Dependencies: []buildinfo.Dependency{{Id: "org.acme:lib:1.0", Checksum: &buildinfo.Checksum{Sha1: "l1", Md5: "m1"}}},
+			},
+		},
+	}
+}
+
+func TestCollectComponents(t *testing.T) {
+	components := collectComponents(createTestBuildInfo())
+	dependsOn := map[string][]string{}
+	for _, c := range components {
+		dependsOn[c.purl] = c.dependsOn
+	}
+	assert.Equal(t, map[string][]string{
+		"pkg:maven/org.acme/app@42":         {"pkg:maven/org.acme/lib@1.0"},
+		"pkg:maven/org.acme/lib@1.0":        {"pkg:maven/org.acme/transitive@2.0"},
+		"pkg:maven/org.acme/transitive@2.0": nil,
+		"pkg:maven/org.acme/cli@42":         {"pkg:maven/org.acme/lib@1.0"},
+	}, dependsOn)
+	assert.Len(t, components, 4)
+	assert.True(t, components[0].isModule)
+	assert.False(t, components[1].isModule)
+}
+
+func TestGenerateCycloneDx(t *testing.T) {
+	content, err := Generate(createTestBuildInfo(), CycloneDx, "https://acme.jfrog.io/artifactory/")
+	assert.NoError(t, err)
+	bom := new(cycloneDxBom)
+	assert.NoError(t, json.Unmarshal(content, bom))
+	assert.Equal(t, "CycloneDX", bom.BomFormat)
+	assert.Equal(t, "1.4", bom.SpecVersion)
+	assert.Regexp(t, regexp.MustCompile("^urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$"), bom.SerialNumber)
+	assert.Equal(t, "2021-03-01T08:00:00Z", bom.Metadata.Timestamp)
+	assert.Equal(t, &cycloneDxComponent{Type: "application", BomRef: "build:app/42", Name: "app", Version: "42"}, bom.Metadata.Component)
+	if assert.Len(t, bom.Components, 4) {
+		assert.Equal(t, &cycloneDxComponent{Type: "library", BomRef: "pkg:maven/org.acme/lib@1.0", Group: "org.acme", Name: "lib", Version: "1.0",
+			Hashes: []cycloneDxHash{{Alg: "SHA-1", Content: "l1"}, {Alg: "MD5", Content: "m1"}}, Purl: "pkg:maven/org.acme/lib@1.0"}, bom.Components[1])
+	}
+	if assert.Len(t, bom.Dependencies, 5) {
+		assert.Equal(t, &cycloneDxDependency{Ref: "build:app/42", DependsOn: []string{"pkg:maven/org.acme/app@42", "pkg:maven/org.acme/cli@42"}}, bom.Dependencies[0])
+	}
+
+	// The SBOMs of the same build are identical.
+	again, err := Generate(createTestBuildInfo(), CycloneDx, "https://acme.jfrog.io/artifactory/")
+	assert.NoError(t, err)
+	assert.Equal(t, string(content), string(again))
+}
+
+func TestGenerateSpdx(t *testing.T) {
+	content, err := Generate(createTestBuildInfo(), Spdx, "https://acme.jfrog.io/artifactory/")
+	assert.NoError(t, err)
+	document := new(spdxDocument)
+	assert.NoError(t, json.Unmarshal(content, document))
+	assert.Equal(t, "SPDX-2.2", document.SpdxVersion)
+	assert.Equal(t, "app-42", document.Name)
+	assert.Regexp(t, regexp.MustCompile("^https://acme.jfrog.io/artifactory/api/build/app/42/[0-9a-f-]{36}$"), document.DocumentNamespace)
+	if assert.Len(t, document.Packages, 4) {
+		assert.Equal(t, "SPDXRef-Package-2", document.Packages[1].SpdxId)
+		assert.Equal(t, "org.acme:lib", document.Packages[1].Name)
+		assert.Equal(t, "1.0", document.Packages[1].VersionInfo)
+		assert.Equal(t, []spdxExternalRef{{ReferenceCategory: "PACKAGE_MANAGER", ReferenceType: "purl", ReferenceLocator: "pkg:maven/org.acme/lib@1.0"}}, document.Packages[1].ExternalRefs)
+	}
+	assert.Equal(t, []*spdxRelationship{
+		{SpdxElementId: "SPDXRef-DOCUMENT", RelatedSpdxElement: "SPDXRef-Package-1", RelationshipType: "DESCRIBES"},
+		{SpdxElementId: "SPDXRef-Package-1", RelatedSpdxElement: "SPDXRef-Package-2", RelationshipType: "DEPENDS_ON"},
+		{SpdxElementId: "SPDXRef-Package-2", RelatedSpdxElement: "SPDXRef-Package-3", RelationshipType: "DEPENDS_ON"},
+		{SpdxElementId: "SPDXRef-DOCUMENT", RelatedSpdxElement: "SPDXRef-Package-4", RelationshipType: "DESCRIBES"},
+		{SpdxElementId: "SPDXRef-Package-4", RelatedSpdxElement: "SPDXRef-Package-2", RelationshipType: "DEPENDS_ON"},
+	}, document.Relationships)
+}
+
+func TestGenerateUnsupportedFormat(t *testing.T) {
+	_, err := Generate(createTestBuildInfo(), "swid", "")
+	assert.EqualError(t, err, "unsupported SBOM format 'swid'. Supported formats are: cyclonedx and spdx")
+}
@@ -0,0 +1,115 @@
+package sbom
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+)
+
+// An SPDX 2.2 document, as defined by https://spdx.github.io/spdx-spec/v2.2.2.
+type spdxDocument struct {
+	SpdxVersion       string              `json:"spdxVersion"`
+	DataLicense       string              `json:"dataLicense"`
+	SpdxId            string              `json:"SPDXID"`
+	Name              string              `json:"name"`
+	DocumentNamespace string              `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo    `json:"creationInfo"`
+	Packages          []*spdxPackage      `json:"packages"`
+	Relationships     []*spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SpdxId           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	CopyrightText    string            `json:"copyrightText"`
+	Checksums        []spdxChecksum    `json:"checksums,omitempty"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SpdxElementId      string `json:"spdxElementId"`
+	RelatedSpdxElement string `json:"relatedSpdxElement"`
+	RelationshipType   string `json:"relationshipType"`
+}
+
+// The build-info doesn't hold licenses or download locations, so they are not asserted.
+const spdxNoAssertion = "NOASSERTION"
+
+// The document describes the modules of the build, which depend on their dependencies.
+func createSpdxDocument(buildInfo *buildinfo.BuildInfo, components []*component, artifactoryUrl string) *spdxDocument {
+	document := &spdxDocument{
+		SpdxVersion:       "SPDX-2.2",
+		DataLicense:       "CC0-1.0",
+		SpdxId:            "SPDXRef-DOCUMENT",
+		Name:              buildInfo.Name + "-" + buildInfo.Number,
+		DocumentNamespace: strings.TrimSuffix(artifactoryUrl, "/") + "/api/build/" + url.PathEscape(buildInfo.Name) + "/" + url.PathEscape(buildInfo.Number) + "/" + getBuildUuid(buildInfo),
+		CreationInfo: spdxCreationInfo{
+			Created:  getTimestamp(buildInfo),
+			Creators: []string{"Organization: JFrog", "Tool: " + getToolName() + "-" + coreutils.GetCliUserAgentVersion()},
+		},
+		Packages:      []*spdxPackage{},
+		Relationships: []*spdxRelationship{},
+	}
+	// SPDX IDs may only contain letters, numbers, dots and dashes, so the packages are identified by their indexes.
+	spdxIds := map[string]string{}
+	for i, c := range components {
+		spdxIds[c.purl] = "SPDXRef-Package-" + strconv.Itoa(i+1)
+	}
+	for _, c := range components {
+		name := c.name
+		if c.group != "" {
+			name = c.group + "/" + c.name
+			if strings.HasPrefix(c.purl, "pkg:maven/") {
+				name = c.group + ":" + c.name
+			}
+		}
+		spdxPackage := &spdxPackage{
+			SpdxId:           spdxIds[c.purl],
+			Name:             name,
+			VersionInfo:      c.version,
+			DownloadLocation: spdxNoAssertion,
+			LicenseConcluded: spdxNoAssertion,
+			LicenseDeclared:  spdxNoAssertion,
+			CopyrightText:    spdxNoAssertion,
+			ExternalRefs:     []spdxExternalRef{{ReferenceCategory: "PACKAGE_MANAGER", ReferenceType: "purl", ReferenceLocator: c.purl}},
+		}
+		if c.sha1 != "" {
+			spdxPackage.Checksums = append(spdxPackage.Checksums, spdxChecksum{Algorithm: "SHA1", ChecksumValue: c.sha1})
+		}
+		if c.md5 != "" {
+			spdxPackage.Checksums = append(spdxPackage.Checksums, spdxChecksum{Algorithm: "MD5", ChecksumValue: c.md5})
+		}
+		document.Packages = append(document.Packages, spdxPackage)
+		if c.isModule {
+			document.Relationships = append(document.Relationships, &spdxRelationship{SpdxElementId: document.SpdxId, RelatedSpdxElement: spdxPackage.SpdxId, RelationshipType: "DESCRIBES"})
+		}
+		for _, dependency := range c.dependsOn {
+			document.Relationships = append(document.Relationships, &spdxRelationship{SpdxElementId: spdxPackage.SpdxId, RelatedSpdxElement: spdxIds[dependency], RelationshipType: "DEPENDS_ON"})
+		}
+	}
+	return document
+}
@@ -0,0 +1,271 @@
+package splitdownload
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	downloadsDirName = "downloads"
+	manifestFileName = "manifest.json"
+)
+
+// A file to download in parts.
+type DownloadDetails struct {
+	// The URL of the file in Artifactory.
+	DownloadUrl string
+	// The path of the file in Artifactory, displayed in the progress bar.
+	RelativePath  string
+	LocalFilePath string
+	Size          int64
+	// The checksum of the file in Artifactory, which the downloaded file is verified against.
+	Sha256     string
+	SplitCount int
+}
+
+// Describes the parts of a file being downloaded.
+// The manifest is kept next to the parts' files, so that an interrupted download can continue from the missing ranges.
+type manifest struct {
+	DownloadUrl   string  `json:"downloadUrl"`
+	LocalFilePath string  `json:"localFilePath"`
+	Size          int64   `json:"size"`
+	Sha256        string  `json:"sha256"`
+	Parts         []*part `json:"parts"`
+}
+
+// The range of a part's bytes in the file. The end is exclusive.
+type part struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+func (p *part) size() int64 {
+	return p.End - p.Start
+}
+
+// Downloads the file in parts, concurrently.
+// The parts are kept in the JFrog CLI home directory until the file is merged, so if the download fails, downloading it again
+// only downloads the ranges which are still missing.
+// The merged file is verified against the file's sha256 checksum. If it does not match, the merged file and its parts are removed.
+func Download(serviceManager artifactory.ArtifactoryServicesManager, details *DownloadDetails, progress ioUtils.ProgressMgr, logMsgPrefix string) error {
+	partsDir, err := GetPartsDir(details.DownloadUrl, details.LocalFilePath)
+	if err != nil {
+		return err
+	}
+	fileManifest, err := loadManifest(partsDir, details)
+	if err != nil {
+		return err
+	}
+	var missing int64
+	for i, filePart := range fileManifest.Parts {
+		missing += filePart.size() - getDownloadedSize(getPartPath(partsDir, i))
+	}
+	if missing < details.Size {
+		log.Info(fmt.Sprintf("%sResuming the download of %s, %d of %d bytes were already downloaded.", logMsgPrefix, details.RelativePath, details.Size-missing, details.Size))
+	} else {
+		log.Info(logMsgPrefix+"Downloading", details.RelativePath)
+	}
+
+	var progressId int
+	if progress != nil {
+		// The progress is of the missing bytes only.
+		progressId = progress.NewProgressReader(missing, "Downloading", details.RelativePath).GetId()
+		defer progress.RemoveProgress(progressId)
+	}
+	if err = downloadParts(serviceManager, fileManifest, partsDir, progress, progressId); err != nil {
+		return err
+	}
+	if progress != nil {
+		progress.SetProgressState(progressId, "Merging")
+	}
+	if err = mergeParts(fileManifest, partsDir); err != nil {
+		return err
+	}
+	return errorutils.CheckError(os.RemoveAll(partsDir))
+}
+
+// Returns the directory of the parts of a file being downloaded.
+func GetPartsDir(downloadUrl, localFilePath string) (string, error) {
+	downloadsDir, err := coreutils.CreateDirInJfrogHome(downloadsDirName)
+	if err != nil {
+		return "", err
+	}
+	absLocalFilePath, err := filepath.Abs(localFilePath)
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	checksum := sha256.Sum256([]byte(downloadUrl + "\n" + absLocalFilePath))
+	return filepath.Join(downloadsDir, hex.EncodeToString(checksum[:])), nil
+}
+
+// Loads the manifest of the download's parts.
+// If the parts were downloaded from a different version of the file, they are removed and a new manifest is created.
+func loadManifest(partsDir string, details *DownloadDetails) (*manifest, error) {
+	manifestPath := filepath.Join(partsDir, manifestFileName)
+	existing := new(manifest)
+	content, err := ioutil.ReadFile(manifestPath)
+	if err == nil && json.Unmarshal(content, existing) == nil &&
+		existing.Size == details.Size && existing.Sha256 == details.Sha256 && existing.DownloadUrl == details.DownloadUrl {
+		return existing, nil
+	}
+	if err = os.RemoveAll(partsDir); errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(partsDir, 0700); errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	fileManifest := &manifest{DownloadUrl: details.DownloadUrl, LocalFilePath: details.LocalFilePath, Size: details.Size, Sha256: details.Sha256}
+	partSize := details.Size / int64(details.SplitCount)
+	for i := 0; i < details.SplitCount; i++ {
+		fileManifest.Parts = append(fileManifest.Parts, &part{Start: partSize * int64(i), End: partSize * int64(i+1)})
+	}
+	// The last part includes the remainder of the division.
+	fileManifest.Parts[details.SplitCount-1].End = details.Size
+	content, err = json.Marshal(fileManifest)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	return fileManifest, errorutils.CheckError(ioutil.WriteFile(manifestPath, content, 0600))
+}
+
+func getPartPath(partsDir string, index int) string {
+	return filepath.Join(partsDir, "part-"+strconv.Itoa(index))
+}
+
+// Returns the number of bytes of the part that were already downloaded.
+func getDownloadedSize(partPath string) int64 {
+	info, err := os.Stat(partPath)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+func downloadParts(serviceManager artifactory.ArtifactoryServicesManager, fileManifest *manifest, partsDir string, progress ioUtils.ProgressMgr, progressId int) error {
+	var wg sync.WaitGroup
+	errorsList := make([]error, len(fileManifest.Parts))
+	for i, filePart := range fileManifest.Parts {
+		wg.Add(1)
+		go func(i int, filePart *part) {
+			defer wg.Done()
+			errorsList[i] = downloadPart(serviceManager, fileManifest.DownloadUrl, filePart, getPartPath(partsDir, i), progress, progressId)
+		}(i, filePart)
+	}
+	wg.Wait()
+	for _, err := range errorsList {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Downloads the missing range of the part, and appends it to the part's file.
+func downloadPart(serviceManager artifactory.ArtifactoryServicesManager, downloadUrl string, filePart *part, partPath string, progress ioUtils.ProgressMgr, progressId int) error {
+	downloaded := getDownloadedSize(partPath)
+	if downloaded == filePart.size() {
+		return nil
+	}
+	if downloaded > filePart.size() {
+		if err := os.Remove(partPath); errorutils.CheckError(err) != nil {
+			return err
+		}
+		downloaded = 0
+	}
+	partFile, err := os.OpenFile(partPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	defer partFile.Close()
+
+	httpClientDetails := serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	if httpClientDetails.Headers == nil {
+		httpClientDetails.Headers = map[string]string{}
+	}
+	httpClientDetails.Headers["Range"] = "bytes=" + strconv.FormatInt(filePart.Start+downloaded, 10) + "-" + strconv.FormatInt(filePart.End-1, 10)
+	resp, _, _, err := serviceManager.Client().Send(http.MethodGet, downloadUrl, nil, true, false, &httpClientDetails, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		return errorutils.CheckError(errors.New("Artifactory response: " + resp.Status + " for the range " + httpClientDetails.Headers["Range"] + " of " + downloadUrl))
+	}
+	var body io.Reader = resp.Body
+	if progress != nil {
+		body = progress.GetProgress(progressId).ActionWithProgress(body)
+	}
+	// The bytes which were written before a failure are kept, and are not downloaded again.
+	written, err := io.Copy(partFile, io.LimitReader(body, filePart.size()-downloaded))
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	if written != filePart.size()-downloaded {
+		return errorutils.CheckError(errors.New(fmt.Sprintf("received %d bytes for the range %s of %s", written, httpClientDetails.Headers["Range"], downloadUrl)))
+	}
+	return nil
+}
+
+// Merges the parts into the local file, while calculating its sha256 checksum.
+func mergeParts(fileManifest *manifest, partsDir string) (err error) {
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(fileManifest.LocalFilePath)); err != nil {
+		return
+	}
+	localFile, err := os.OpenFile(fileManifest.LocalFilePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if errorutils.CheckError(err) != nil {
+		return
+	}
+	hash := sha256.New()
+	err = copyParts(io.MultiWriter(localFile, hash), len(fileManifest.Parts), partsDir)
+	if closeErr := localFile.Close(); err == nil {
+		err = errorutils.CheckError(closeErr)
+	}
+	if err != nil {
+		return
+	}
+	actualSha256 := hex.EncodeToString(hash.Sum(nil))
+	if strings.EqualFold(actualSha256, fileManifest.Sha256) {
+		return nil
+	}
+	// The parts are corrupted, so they are removed with the merged file, to download them again.
+	if err = errorutils.CheckError(os.Remove(fileManifest.LocalFilePath)); err != nil {
+		return
+	}
+	if err = errorutils.CheckError(os.RemoveAll(partsDir)); err != nil {
+		return
+	}
+	return errorutils.CheckError(errors.New(fmt.Sprintf("the sha256 checksum of the downloaded file %s is %s, while the expected checksum is %s", fileManifest.LocalFilePath, actualSha256, fileManifest.Sha256)))
+}
+
+func copyParts(writer io.Writer, partsCount int, partsDir string) error {
+	for i := 0; i < partsCount; i++ {
+		partFile, err := os.Open(getPartPath(partsDir, i))
+		if errorutils.CheckError(err) != nil {
+			return err
+		}
+		_, err = io.Copy(writer, partFile)
+		partFile.Close()
+		if errorutils.CheckError(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package splitdownload
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/utils/log"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	log.SetDefaultLogger()
+}
+
+func TestDownload(t *testing.T) {
+	tmpDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer fileutils.RemoveTempDir(tmpDir)
+	oldHome := os.Getenv(coreutils.HomeDir)
+	assert.NoError(t, os.Setenv(coreutils.HomeDir, filepath.Join(tmpDir, "home")))
+	defer os.Setenv(coreutils.HomeDir, oldHome)
+
+	fileContent := bytes.Repeat([]byte("0123456789"), 100)
+	checksum := sha256.Sum256(fileContent)
+
+	// A fake Artifactory, which fails the download of the last part while 'failing' is set.
+	var mutex sync.Mutex
+	var ranges []string
+	failing := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		ranges = append(ranges, r.Header.Get("Range"))
+		mutex.Unlock()
+		if failing && r.Header.Get("Range") == "bytes=666-999" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		http.ServeContent(w, r, "file.bin", time.Now(), bytes.NewReader(fileContent))
+	}))
+	defer server.Close()
+	serviceManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, 0, false)
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	details := &DownloadDetails{
+		DownloadUrl:   server.URL + "/repo/file.bin",
+		RelativePath:  "repo/file.bin",
+		LocalFilePath: filepath.Join(tmpDir, "target", "file.bin"),
+		Size:          int64(len(fileContent)),
+		Sha256:        hex.EncodeToString(checksum[:]),
+		SplitCount:    3,
+	}
+
+	// The first download fails, and keeps the downloaded parts.
+	assert.Error(t, Download(serviceManager, details, nil, ""))
+	partsDir, err := GetPartsDir(details.DownloadUrl, details.LocalFilePath)
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(partsDir, manifestFileName))
+	assert.Equal(t, int64(333), getDownloadedSize(getPartPath(partsDir, 0)))
+	assert.Equal(t, int64(333), getDownloadedSize(getPartPath(partsDir, 1)))
+	assert.NoFileExists(t, details.LocalFilePath)
+
+	// Simulate a download which was interrupted in the middle of the first part.
+	assert.NoError(t, os.Truncate(getPartPath(partsDir, 0), 100))
+
+	// The second download downloads the missing ranges only, and removes the parts once the file is merged.
+	failing = false
+	ranges = nil
+	assert.NoError(t, Download(serviceManager, details, nil, ""))
+	sort.Strings(ranges)
+	assert.Equal(t, []string{"bytes=100-332", "bytes=666-999"}, ranges)
+	downloaded, err := ioutil.ReadFile(details.LocalFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, fileContent, downloaded)
+	assert.NoDirExists(t, partsDir)
+
+	// A file which does not match the expected checksum is removed with its parts.
+	details.Sha256 = "0000"
+	assert.Error(t, Download(serviceManager, details, nil, ""))
+	assert.NoFileExists(t, details.LocalFilePath)
+	assert.NoDirExists(t, partsDir)
+}